@@ -60,8 +60,8 @@ type ContextEntry struct {
 	// ConfigMap is the ConfigMap reference.
 	ConfigMap *ConfigMapReference `json:"configMap,omitempty" yaml:"configMap,omitempty"`
 
-	// APICall defines an HTTP request to the Kubernetes API server. The JSON
-	// data retrieved is stored in the context.
+	// APICall defines an HTTP request to the Kubernetes API server, or to a
+	// JSON web service. The JSON data retrieved is stored in the context.
 	APICall *APICall `json:"apiCall,omitempty" yaml:"apiCall,omitempty"`
 
 	// ImageRegistry defines requests to an OCI/Docker V2 registry to fetch image
@@ -70,6 +70,20 @@ type ContextEntry struct {
 
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// GlobalReference is a reference to a cluster-scoped GlobalContextEntry.
+	GlobalReference *GlobalContextEntryReference `json:"globalReference,omitempty" yaml:"globalReference,omitempty"`
+}
+
+// GlobalContextEntryReference is a reference to a cluster-scoped GlobalContextEntry.
+type GlobalContextEntryReference struct {
+	// Name of the global context entry.
+	Name string `json:"name" yaml:"name"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the data stored in the global context entry.
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -112,15 +126,22 @@ type ConfigMapReference struct {
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
-// APICall defines an HTTP request to the Kubernetes API server. The JSON
-// data retrieved is stored in the context. An APICall contains a URLPath
-// used to perform the HTTP GET request and an optional JMESPath used to
+// APICall defines an HTTP request to the Kubernetes API server, or to a JSON
+// web service. The JSON data retrieved is stored in the context. An APICall
+// contains either a URLPath used to perform the HTTP GET request to the
+// Kubernetes API server or a Service call, and an optional JMESPath used to
 // transform the retrieved JSON data.
 type APICall struct {
 	// URLPath is the URL path to be used in the HTTP GET request to the
 	// Kubernetes API server (e.g. "/api/v1/namespaces" or  "/apis/apps/v1/deployments").
 	// The format required is the same format used by the `kubectl get --raw` command.
-	URLPath string `json:"urlPath" yaml:"urlPath"`
+	// +optional
+	URLPath string `json:"urlPath,omitempty" yaml:"urlPath,omitempty"`
+
+	// Service is an API call to a JSON web service.
+	// URLPath and Service are mutually exclusive.
+	// +optional
+	Service *ServiceCall `json:"service,omitempty" yaml:"service,omitempty"`
 
 	// JMESPath is an optional JSON Match Expression that can be used to
 	// transform the JSON response returned from the API server. For example
@@ -131,6 +152,66 @@ type APICall struct {
 	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
 }
 
+// Method is the HTTP request type.
+// +kubebuilder:validation:Enum=GET;POST
+type Method string
+
+const (
+	// MethodGet is the HTTP GET method.
+	MethodGet Method = "GET"
+	// MethodPost is the HTTP POST method.
+	MethodPost Method = "POST"
+)
+
+// ServiceCall defines an HTTP request to a JSON web service.
+// Variables are substituted in the URL, headers and request data.
+type ServiceCall struct {
+	// URL is the JSON web service URL.
+	// The typical format is `https://{service}.{namespace}:{port}/{path}`.
+	URL string `json:"url" yaml:"url"`
+
+	// Method is the HTTP request type (GET or POST). Defaults to GET.
+	// +kubebuilder:default=GET
+	// +optional
+	Method Method `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Headers is a list of HTTP headers sent with the request.
+	// +optional
+	Headers []HTTPHeader `json:"headers,omitempty" yaml:"headers,omitempty"`
+
+	// Data specifies the POST request body. The list of key-value pairs
+	// is sent as a JSON object.
+	// +optional
+	Data []RequestData `json:"data,omitempty" yaml:"data,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate
+	// the server certificate.
+	// +optional
+	CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+
+	// Timeout is the maximum duration of the request. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// HTTPHeader defines an HTTP header sent with a service call.
+type HTTPHeader struct {
+	// Key is the header name.
+	Key string `json:"key" yaml:"key"`
+
+	// Value is the header value.
+	Value string `json:"value" yaml:"value"`
+}
+
+// RequestData contains the HTTP POST data
+type RequestData struct {
+	// Key is a unique identifier for the data value
+	Key string `json:"key" yaml:"key"`
+
+	// Value is the data value
+	Value *apiextv1.JSON `json:"value" yaml:"value"`
+}
+
 // Condition defines variable-based conditional criteria for rule execution.
 type Condition struct {
 	// Key is the context entry (using JMESPath) for conditional rule evaluation.
@@ -8,6 +8,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+const testPublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE2G2Y+2tabdTV5BcGiBIx0a9fAFwr
+kBbmLSGtks4L3qX6yYY0zufBnhC8Ur/iy55GhWP/9A/bY2LhC30M9+RYtw==
+-----END PUBLIC KEY-----`
+
 func Test_ImageVerification(t *testing.T) {
 	path := field.NewPath("dummy")
 	testCases := []struct {
@@ -164,6 +169,77 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid offline keyless attestor",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{
+							Rekor:   &CTLog{RekorPubKey: testPublicKey, CTLogPubKey: testPublicKey, IgnoreTlog: true},
+							Roots:   "bla",
+							Issuer:  "bla",
+							Subject: "bla",
+						},
+					}}},
+				},
+			},
+		},
+		{
+			name: "keyless attestor with regular expressions",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{
+							Rekor:         &CTLog{URL: "https://rekor.sigstore.dev"},
+							SubjectRegExp: `^https://github\.com/kyverno/.+@refs/tags/v.+$`,
+							IssuerRegExp:  `^https://token\.actions\.githubusercontent\.com$`,
+						},
+					}}},
+				},
+			},
+		},
+		{
+			name: "keyless attestor with invalid regular expressions",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{
+							Rekor:         &CTLog{URL: "https://rekor.sigstore.dev"},
+							SubjectRegExp: "(",
+							IssuerRegExp:  "[",
+						},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				keylessPath := path.Child("attestors").Index(0).Child("entries").Index(0).Child("keyless")
+				return field.ErrorList{
+					field.Invalid(keylessPath.Child("subjectRegExp"), "(", "Invalid regular expression: error parsing regexp: missing closing ): `(`"),
+					field.Invalid(keylessPath.Child("issuerRegExp"), "[", "Invalid regular expression: error parsing regexp: missing closing ]: `[`"),
+				}
+			},
+		},
+		{
+			name: "invalid transparency log public keys",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{PublicKeys: "bla", Rekor: &CTLog{URL: "https://rekor.sigstore.dev", RekorPubKey: "bla", CTLogPubKey: "bla"}},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				rekorPath := path.Child("attestors").Index(0).Child("entries").Index(0).Child("keys").Child("rekor")
+				return field.ErrorList{
+					field.Invalid(rekorPath.Child("pubkey"), "bla", "A PEM encoded public key is required"),
+					field.Invalid(rekorPath.Child("ctLogPubKey"), "bla", "A PEM encoded public key is required"),
+				}
+			},
+		},
 		{
 			name: "valid keyless attestor",
 			subject: ImageVerification{
@@ -197,6 +273,55 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid notary certificates attestor",
+			subject: ImageVerification{
+				Type:            Notary,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Certificates: &CertificateAttestor{Certificate: "cert"},
+					}}},
+				},
+			},
+		},
+		{
+			name: "notary with keys attestor",
+			subject: ImageVerification{
+				Type:            Notary,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{PublicKeys: "key"},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(
+						path.Child("attestors").Index(0).Child("entries").Index(0),
+						i.Attestors[0].Entries[0],
+						"Only certificates attestors are supported with the Notary type"),
+				}
+			},
+		},
+		{
+			name: "notary with attestations",
+			subject: ImageVerification{
+				Type:            Notary,
+				ImageReferences: []string{"*"},
+				Attestations: []Attestation{
+					{
+						PredicateType: "foo",
+					},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestations"), i.Attestations, "Attestations are not supported with the Notary type"),
+				}
+			},
+		},
 	}
 
 	for _, test := range testCases {
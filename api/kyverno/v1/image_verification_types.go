@@ -1,17 +1,37 @@
 package v1
 
 import (
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"regexp"
 
 	"github.com/pkg/errors"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// ImageVerificationType selects the type of verifier to use for verifying images.
+// +kubebuilder:validation:Enum=Cosign;Notary
+type ImageVerificationType string
+
+const (
+	// Cosign verifies image signatures and attestations using Sigstore Cosign.
+	Cosign ImageVerificationType = "Cosign"
+	// Notary verifies Notary v2 image signatures (for example created by Notation).
+	Notary ImageVerificationType = "Notary"
+)
+
 // ImageVerification validates that images that match the specified pattern
 // are signed with the supplied public key. Once the image is verified it is
 // mutated to include the SHA digest retrieved during the registration.
 type ImageVerification struct {
+	// Type specifies the method of signature validation. The allowed options
+	// are Cosign and Notary. By default Cosign is used if a type is not specified.
+	// +kubebuilder:validation:Optional
+	Type ImageVerificationType `json:"type,omitempty" yaml:"type,omitempty"`
+
 	// Image is the image name consisting of the registry address, repository, image, and tag.
 	// Wildcards ('*' and '?') are allowed. See: https://kubernetes.io/docs/concepts/containers/images.
 	// Deprecated. Use ImageReferences instead.
@@ -189,6 +209,17 @@ type KeylessAttestor struct {
 	// +kubebuilder:validation:Optional
 	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
 
+	// IssuerRegExp is a regular expression the certificate issuer used for keyless signing must match.
+	// The expression is not anchored, use ^ and $ to match the whole issuer.
+	// +kubebuilder:validation:Optional
+	IssuerRegExp string `json:"issuerRegExp,omitempty" yaml:"issuerRegExp,omitempty"`
+
+	// SubjectRegExp is a regular expression the verified identity used for keyless signing must match,
+	// for example to accept the workflows of any branch or tag of a repository. The expression is not
+	// anchored, use ^ and $ to match the whole subject.
+	// +kubebuilder:validation:Optional
+	SubjectRegExp string `json:"subjectRegExp,omitempty" yaml:"subjectRegExp,omitempty"`
+
 	// Roots is an optional set of PEM encoded trusted root certificates.
 	// If not provided, the system roots are used.
 	// +kubebuilder:validation:Optional
@@ -201,9 +232,28 @@ type KeylessAttestor struct {
 
 type CTLog struct {
 	// URL is the address of the transparency log. Defaults to the public log https://rekor.sigstore.dev.
-	// +kubebuilder:validation:Required
+	// The URL is not required when IgnoreTlog is set.
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:Default:=https://rekor.sigstore.dev
-	URL string `json:"url" yaml:"url"`
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+
+	// RekorPubKey is an optional PEM encoded public key of a private Rekor instance. If set, it is
+	// used instead of the keys of the public Sigstore instance, resolved through TUF, to verify the
+	// signed entry timestamps (SET) bundled with signatures and the transparency log entries.
+	// +kubebuilder:validation:Optional
+	RekorPubKey string `json:"pubkey,omitempty" yaml:"pubkey,omitempty"`
+
+	// CTLogPubKey is an optional PEM encoded public key of a private certificate transparency log.
+	// If set, it is used instead of the keys resolved through TUF to verify the signed certificate
+	// timestamps (SCT) embedded in the certificates issued by Fulcio.
+	// +kubebuilder:validation:Optional
+	CTLogPubKey string `json:"ctLogPubKey,omitempty" yaml:"ctLogPubKey,omitempty"`
+
+	// IgnoreTlog skips the online lookup of signatures in the transparency log, for clusters that
+	// cannot reach it. Signatures must then be bundled with a signed entry timestamp (SET), which is
+	// verified offline with the Rekor public key.
+	// +kubebuilder:validation:Optional
+	IgnoreTlog bool `json:"ignoreTlog,omitempty" yaml:"ignoreTlog,omitempty"`
 }
 
 // Attestation are checks for signed in-toto Statements that are used to verify the image.
@@ -243,6 +293,42 @@ func (iv *ImageVerification) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, attestorErrors...)
 	}
 
+	if copy.Type == Notary {
+		errs = append(errs, copy.validateNotary(path)...)
+	}
+
+	return errs
+}
+
+// validateNotary checks the verification only relies on features supported by the Notary verifier
+func (iv *ImageVerification) validateNotary(path *field.Path) (errs field.ErrorList) {
+	if len(iv.Attestations) > 0 {
+		errs = append(errs, field.Invalid(path.Child("attestations"), iv.Attestations, "Attestations are not supported with the Notary type"))
+	}
+
+	attestorsPath := path.Child("attestors")
+	for i, as := range iv.Attestors {
+		errs = append(errs, validateNotaryAttestorSet(&as, attestorsPath.Index(i))...)
+	}
+
+	return errs
+}
+
+func validateNotaryAttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorList) {
+	entriesPath := path.Child("entries")
+	for i, e := range as.Entries {
+		entryPath := entriesPath.Index(i)
+		if e.Keys != nil || e.Keyless != nil {
+			errs = append(errs, field.Invalid(entryPath, e, "Only certificates attestors are supported with the Notary type"))
+		}
+
+		if e.Attestor != nil {
+			if nested, err := AttestorSetUnmarshal(e.Attestor); err == nil {
+				errs = append(errs, validateNotaryAttestorSet(nested, entryPath.Child("attestor"))...)
+			}
+		}
+	}
+
 	return errs
 }
 
@@ -341,6 +427,9 @@ func (ska *StaticKeyAttestor) Validate(path *field.Path) (errs field.ErrorList)
 	if ska.PublicKeys != "" && ska.SignatureAlgorithm != "" && ska.SignatureAlgorithm != "sha256" && ska.SignatureAlgorithm != "sha512" {
 		errs = append(errs, field.Invalid(path, ska, "Invalid signature algorithm provided"))
 	}
+	if ska.Rekor != nil {
+		errs = append(errs, ska.Rekor.Validate(path.Child("rekor"))...)
+	}
 	return errs
 }
 
@@ -349,6 +438,10 @@ func (ca *CertificateAttestor) Validate(path *field.Path) (errs field.ErrorList)
 		errs = append(errs, field.Invalid(path, ca, "cert or certChain required"))
 	}
 
+	if ca.Rekor != nil {
+		errs = append(errs, ca.Rekor.Validate(path.Child("rekor"))...)
+	}
+
 	return errs
 }
 
@@ -357,13 +450,50 @@ func (ka *KeylessAttestor) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, field.Invalid(path, ka, "Either Rekor URL or roots are required"))
 	}
 
-	if ka.Rekor != nil && ka.Rekor.URL == "" {
+	if ka.Rekor != nil && ka.Rekor.URL == "" && !ka.Rekor.IgnoreTlog {
 		errs = append(errs, field.Invalid(path, ka, "An URL is required"))
 	}
 
+	if ka.Rekor != nil {
+		errs = append(errs, ka.Rekor.Validate(path.Child("rekor"))...)
+	}
+
+	if ka.SubjectRegExp != "" {
+		if _, err := regexp.Compile(ka.SubjectRegExp); err != nil {
+			errs = append(errs, field.Invalid(path.Child("subjectRegExp"), ka.SubjectRegExp, fmt.Sprintf("Invalid regular expression: %v", err)))
+		}
+	}
+
+	if ka.IssuerRegExp != "" {
+		if _, err := regexp.Compile(ka.IssuerRegExp); err != nil {
+			errs = append(errs, field.Invalid(path.Child("issuerRegExp"), ka.IssuerRegExp, fmt.Sprintf("Invalid regular expression: %v", err)))
+		}
+	}
+
+	return errs
+}
+
+func (ctl *CTLog) Validate(path *field.Path) (errs field.ErrorList) {
+	if ctl.RekorPubKey != "" && !isPEMPublicKey(ctl.RekorPubKey) {
+		errs = append(errs, field.Invalid(path.Child("pubkey"), ctl.RekorPubKey, "A PEM encoded public key is required"))
+	}
+
+	if ctl.CTLogPubKey != "" && !isPEMPublicKey(ctl.CTLogPubKey) {
+		errs = append(errs, field.Invalid(path.Child("ctLogPubKey"), ctl.CTLogPubKey, "A PEM encoded public key is required"))
+	}
+
 	return errs
 }
 
+func isPEMPublicKey(key string) bool {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return false
+	}
+	_, err := x509.ParsePKIXPublicKey(block.Bytes)
+	return err == nil
+}
+
 func (iv *ImageVerification) Convert() *ImageVerification {
 	if iv.Image == "" && iv.Key == "" && iv.Issuer == "" {
 		return iv
@@ -32,6 +32,25 @@ type ImageExtractorConfig struct {
 	// Note - this field MUST be unique.
 	// +optional
 	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// Artifact is an optional name of the field within 'path' holding the artifact name, appended to the
+	// repository in 'value', e.g. the chart of a Helm repository. The field can be slash-separated.
+	// +optional
+	Artifact string `json:"artifact,omitempty" yaml:"artifact,omitempty"`
+	// Tag is an optional name of the field within 'path' holding the tag or version of the artifact,
+	// appended to the reference in 'value'. The field can be slash-separated.
+	// +optional
+	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	// Semver is an optional name of the field within 'path' holding a semver constraint with the syntax of Flux,
+	// e.g. '^1.2.0' or '~1.2', resolved to the highest matching tag of the repository. Prerelease tags only match
+	// constraints with a prerelease. It takes precedence over 'tag'. The field can be slash-separated.
+	// +optional
+	Semver string `json:"semver,omitempty" yaml:"semver,omitempty"`
+	// Digest is an optional name of the field within 'path' holding the artifact digest. It takes precedence
+	// over 'tag' and 'semver', and digest mutation writes the digest to this field. The field in 'value' is never
+	// rewritten when 'artifact', 'tag' or 'semver' are set, without 'digest' the digest is then not mutated.
+	// The field can be slash-separated.
+	// +optional
+	Digest string `json:"digest,omitempty" yaml:"digest,omitempty"`
 }
 
 // Rule defines a validation, mutation, or generation control for matching resources.
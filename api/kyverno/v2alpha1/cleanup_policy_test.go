@@ -78,6 +78,25 @@ func Test_ClusterCleanupPolicy_Schedule(t *testing.T) {
 	assert.Equal(t, errs[0].Error(), fmt.Sprintf(`spec.schedule: Invalid value: "%s": schedule spec in the cleanupPolicy is not in proper cron format`, subject.Spec.Schedule))
 }
 
+func Test_CleanupPolicy_DeletionLimits(t *testing.T) {
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule:           "* * * * *",
+			MaxDeletionsPerRun: -1,
+			DeletionsPerSecond: -1,
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.maxDeletionsPerRun")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[1].Field, "spec.deletionsPerSecond")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeInvalid)
+}
+
 func Test_doesMatchExcludeConflict(t *testing.T) {
 	path := field.NewPath("dummy")
 	testcases := []struct {
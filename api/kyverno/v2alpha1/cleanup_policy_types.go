@@ -22,6 +22,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -34,6 +35,7 @@ import (
 // +kubebuilder:resource:shortName=cleanpol,categories=kyverno
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Last Execution",type="date",JSONPath=".status.lastExecutionTime"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // CleanupPolicy defines a rule for resource cleanup.
@@ -94,6 +96,7 @@ type CleanupPolicyList struct {
 // +kubebuilder:resource:scope=Cluster,shortName=ccleanpol,categories=kyverno
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Last Execution",type="date",JSONPath=".status.lastExecutionTime"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ClusterCleanupPolicy defines rule for resource cleanup.
@@ -149,6 +152,10 @@ type ClusterCleanupPolicyList struct {
 // CleanupPolicySpec stores specifications for selecting resources that the user needs to delete
 // and schedule when the matching resources needs deleted.
 type CleanupPolicySpec struct {
+	// Context defines variables and data sources that can be used during rule execution.
+	// +optional
+	Context []kyvernov1.ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
+
 	// MatchResources defines when cleanuppolicy should be applied. The match
 	// criteria can include resource information (e.g. kind, name, namespace, labels)
 	// and admission review request information like the user name or role.
@@ -167,11 +174,69 @@ type CleanupPolicySpec struct {
 	// Conditions defines conditions used to select resources which user needs to delete
 	// +optional
 	Conditions *kyvernov2beta1.AnyAllConditions `json:"conditions,omitempty"`
+
+	// DryRun, when set to true, makes the cleanup controller report the resources that would
+	// be deleted (as events, in the policy status and in metrics) without deleting them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPropagationPolicy defines how dependents of deleted resources are handled.
+	// Valid values are Foreground, Background and Orphan, the API server default is used if not set.
+	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
+	// +optional
+	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// MaxDeletionsPerRun is the maximum number of resources deleted in a single execution of the policy.
+	// Zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletionsPerRun int `json:"maxDeletionsPerRun,omitempty"`
+
+	// DeletionsPerSecond is the maximum number of resources deleted per second.
+	// Zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DeletionsPerSecond int `json:"deletionsPerSecond,omitempty"`
 }
 
 // CleanupPolicyStatus stores the status of the policy.
 type CleanupPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// LastExecutionTime is the time the policy was last executed.
+	// +optional
+	LastExecutionTime *metav1.Time `json:"lastExecutionTime,omitempty"`
+
+	// NextExecutionTime is the next time the policy is scheduled to be executed.
+	// +optional
+	NextExecutionTime *metav1.Time `json:"nextExecutionTime,omitempty"`
+
+	// LastExecutionResults stores the results of the last execution per resource kind.
+	// +optional
+	LastExecutionResults []CleanupResult `json:"lastExecutionResults,omitempty"`
+
+	// LastError is the error message of the last execution, empty if it succeeded.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// DryRunResources lists the resources that would have been deleted by the last
+	// execution of the policy in dry run mode. The list is truncated if too long.
+	// +optional
+	DryRunResources []corev1.ObjectReference `json:"dryRunResources,omitempty"`
+}
+
+// CleanupResult stores the results of a policy execution for a resource kind.
+type CleanupResult struct {
+	// Kind is the resource kind.
+	Kind string `json:"kind"`
+
+	// Deleted is the number of resources deleted.
+	// +optional
+	Deleted int `json:"deleted,omitempty"`
+
+	// Failed is the number of resources that could not be deleted.
+	// +optional
+	Failed int `json:"failed,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -182,6 +247,12 @@ func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.Str
 		errs = append(errs, p.ExcludeResources.Validate(path.Child("exclude"), namespaced, clusterResources)...)
 	}
 	errs = append(errs, p.ValidateMatchExcludeConflict(path)...)
+	if p.MaxDeletionsPerRun < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxDeletionsPerRun"), p.MaxDeletionsPerRun, "must be greater than or equal to 0"))
+	}
+	if p.DeletionsPerSecond < 0 {
+		errs = append(errs, field.Invalid(path.Child("deletionsPerSecond"), p.DeletionsPerSecond, "must be greater than or equal to 0"))
+	}
 	return errs
 }
 
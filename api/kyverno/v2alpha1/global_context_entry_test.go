@@ -0,0 +1,81 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_GlobalContextEntry_Validate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		spec   GlobalContextEntrySpec
+		errors []*field.Error
+	}{{
+		name: "kubernetes resource",
+		spec: GlobalContextEntrySpec{
+			KubernetesResource: &KubernetesResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		},
+	}, {
+		name: "api call",
+		spec: GlobalContextEntrySpec{
+			APICall: &ExternalAPICall{
+				APICall:         kyvernov1.APICall{URLPath: "/api/v1/namespaces"},
+				RefreshInterval: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}, {
+		name: "empty",
+		spec: GlobalContextEntrySpec{},
+		errors: []*field.Error{
+			{Type: field.ErrorTypeRequired, Field: "spec"},
+		},
+	}, {
+		name: "both",
+		spec: GlobalContextEntrySpec{
+			KubernetesResource: &KubernetesResource{Version: "v1", Resource: "namespaces"},
+			APICall:            &ExternalAPICall{APICall: kyvernov1.APICall{URLPath: "/api/v1/namespaces"}},
+		},
+		errors: []*field.Error{
+			{Type: field.ErrorTypeForbidden, Field: "spec"},
+		},
+	}, {
+		name: "missing resource fields",
+		spec: GlobalContextEntrySpec{
+			KubernetesResource: &KubernetesResource{Group: "apps"},
+		},
+		errors: []*field.Error{
+			{Type: field.ErrorTypeRequired, Field: "spec.kubernetesResource.version"},
+			{Type: field.ErrorTypeRequired, Field: "spec.kubernetesResource.resource"},
+		},
+	}, {
+		name: "invalid api call",
+		spec: GlobalContextEntrySpec{
+			APICall: &ExternalAPICall{
+				RefreshInterval: &metav1.Duration{},
+			},
+		},
+		errors: []*field.Error{
+			{Type: field.ErrorTypeRequired, Field: "spec.apiCall"},
+			{Type: field.ErrorTypeInvalid, Field: "spec.apiCall.refreshInterval"},
+		},
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			subject := GlobalContextEntry{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       test.spec,
+			}
+			errs := subject.Validate()
+			assert.Equal(t, len(errs), len(test.errors))
+			for i := range errs {
+				assert.Equal(t, errs[i].Type, test.errors[i].Type)
+				assert.Equal(t, errs[i].Field, test.errors[i].Field)
+			}
+		})
+	}
+}
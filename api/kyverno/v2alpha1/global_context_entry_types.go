@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// GlobalContextEntryConditionReady means that the global context entry data is available
+	GlobalContextEntryConditionReady = "Ready"
+)
+
+const (
+	// GlobalContextEntryReasonSucceeded is the reason set when the data was loaded
+	GlobalContextEntryReasonSucceeded = "Succeeded"
+	// GlobalContextEntryReasonFailed is the reason set when the data could not be loaded
+	GlobalContextEntryReasonFailed = "Failed"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=gctxentry,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=".spec.apiCall.refreshInterval"
+// +kubebuilder:printcolumn:name="Last Refresh",type="date",JSONPath=".status.lastRefreshTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// GlobalContextEntry declares data shared across policies and kept up to date
+// by Kyverno. Rules reference it from their context using a global reference.
+type GlobalContextEntry struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the data source of the global context entry.
+	Spec GlobalContextEntrySpec `json:"spec"`
+
+	// Status contains the global context entry runtime data.
+	// +optional
+	Status GlobalContextEntryStatus `json:"status,omitempty"`
+}
+
+// GetStatus returns the global context entry status
+func (e *GlobalContextEntry) GetStatus() *GlobalContextEntryStatus {
+	return &e.Status
+}
+
+// Validate implements programmatic validation
+func (e *GlobalContextEntry) Validate() (errs field.ErrorList) {
+	errs = append(errs, e.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GlobalContextEntryList is a list of Global Context Entries
+type GlobalContextEntryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []GlobalContextEntry `json:"items"`
+}
+
+// GlobalContextEntrySpec stores the data source of a global context entry.
+// Exactly one of KubernetesResource or APICall must be set.
+type GlobalContextEntrySpec struct {
+	// KubernetesResource stores the list of Kubernetes resources of the given type,
+	// kept up to date with an informer.
+	// +optional
+	KubernetesResource *KubernetesResource `json:"kubernetesResource,omitempty"`
+
+	// APICall stores the result of an HTTP request to the Kubernetes API server,
+	// or to a JSON web service, refreshed periodically.
+	// +optional
+	APICall *ExternalAPICall `json:"apiCall,omitempty"`
+}
+
+// KubernetesResource stores the list of Kubernetes resources of the given type.
+type KubernetesResource struct {
+	// Group defines the group of the resource.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version defines the version of the resource.
+	Version string `json:"version"`
+
+	// Resource defines the type of the resource, in plural form.
+	Resource string `json:"resource"`
+
+	// Namespace defines the namespace of the resources. Leave empty for cluster
+	// scoped resources or to list resources in all namespaces.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ExternalAPICall defines an APICall refreshed periodically.
+type ExternalAPICall struct {
+	kyvernov1.APICall `json:",inline"`
+
+	// RefreshInterval defines how often the data is refreshed. Defaults to 10m.
+	// +kubebuilder:default:="10m"
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// GlobalContextEntryStatus stores the status of the global context entry.
+type GlobalContextEntryStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// LastRefreshTime is the time the data was last refreshed.
+	// +optional
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+}
+
+// SetReady sets the Ready condition of the global context entry
+func (status *GlobalContextEntryStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    GlobalContextEntryConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = GlobalContextEntryReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GlobalContextEntryReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsReady indicates if the global context entry data is available
+func (status *GlobalContextEntryStatus) IsReady() bool {
+	return meta.IsStatusConditionTrue(status.Conditions, GlobalContextEntryConditionReady)
+}
+
+// Validate implements programmatic validation
+func (s *GlobalContextEntrySpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.KubernetesResource == nil && s.APICall == nil {
+		errs = append(errs, field.Required(path, "either kubernetesResource or apiCall must be specified"))
+	}
+	if s.KubernetesResource != nil && s.APICall != nil {
+		errs = append(errs, field.Forbidden(path, "only one of kubernetesResource or apiCall can be specified"))
+	}
+	if s.KubernetesResource != nil {
+		errs = append(errs, s.KubernetesResource.Validate(path.Child("kubernetesResource"))...)
+	}
+	if s.APICall != nil {
+		errs = append(errs, s.APICall.Validate(path.Child("apiCall"))...)
+	}
+	return errs
+}
+
+// Validate implements programmatic validation
+func (r *KubernetesResource) Validate(path *field.Path) (errs field.ErrorList) {
+	if r.Version == "" {
+		errs = append(errs, field.Required(path.Child("version"), "a version is required"))
+	}
+	if r.Resource == "" {
+		errs = append(errs, field.Required(path.Child("resource"), "a resource is required"))
+	}
+	return errs
+}
+
+// Validate implements programmatic validation
+func (a *ExternalAPICall) Validate(path *field.Path) (errs field.ErrorList) {
+	if a.URLPath == "" && a.Service == nil {
+		errs = append(errs, field.Required(path, "either urlPath or service must be specified"))
+	}
+	if a.URLPath != "" && a.Service != nil {
+		errs = append(errs, field.Forbidden(path, "only one of urlPath or service can be specified"))
+	}
+	if a.RefreshInterval != nil && a.RefreshInterval.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("refreshInterval"), a.RefreshInterval.Duration.String(), "must be greater than 0"))
+	}
+	return errs
+}
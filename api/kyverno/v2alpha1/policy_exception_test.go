@@ -0,0 +1,68 @@
+package v2alpha1
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_PolicyException_Validate(t *testing.T) {
+	subject := PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-exception",
+			Namespace: "default",
+		},
+		Spec: PolicyExceptionSpec{
+			Match: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds: []string{"Pod"},
+					},
+				}},
+			},
+			Exceptions: []Exception{{}},
+		},
+	}
+	errs := subject.Validate()
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.exceptions[0].policyName")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeRequired)
+	assert.Equal(t, errs[1].Field, "spec.exceptions[0].ruleNames")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeRequired)
+}
+
+func Test_PolicyException_Validate_Empty(t *testing.T) {
+	subject := PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-exception",
+			Namespace: "default",
+		},
+	}
+	errs := subject.Validate()
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.match")
+	assert.Equal(t, errs[1].Field, "spec.exceptions")
+}
+
+func Test_PolicyException_Contains(t *testing.T) {
+	subject := PolicyException{
+		Spec: PolicyExceptionSpec{
+			Exceptions: []Exception{{
+				PolicyName: "disallow-host-path",
+				RuleNames:  []string{"host-path", "autogen-*"},
+			}, {
+				PolicyName: "team-a/require-labels",
+				RuleNames:  []string{"check-team"},
+			}},
+		},
+	}
+	assert.Assert(t, subject.Contains("disallow-host-path", "host-path"))
+	assert.Assert(t, subject.Contains("disallow-host-path", "autogen-host-path"))
+	assert.Assert(t, !subject.Contains("disallow-host-path", "other"))
+	assert.Assert(t, subject.Contains("team-a/require-labels", "check-team"))
+	assert.Assert(t, !subject.Contains("require-labels", "check-team"))
+}
@@ -0,0 +1,22 @@
+package cleanup
+
+import (
+	"github.com/go-logr/logr"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/engine/common"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/pkg/errors"
+)
+
+func checkAnyAllConditions(logger logr.Logger, ctx enginecontext.Interface, conditions kyvernov2beta1.AnyAllConditions) (bool, error) {
+	substituted, err := variables.SubstituteAllInPreconditions(logger, ctx, conditions)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to substitute variables in conditions")
+	}
+	typeConditions, err := common.TransformConditions(substituted)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse conditions")
+	}
+	return variables.EvaluateConditions(logger, ctx, typeConditions), nil
+}
@@ -0,0 +1,58 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+)
+
+func Test_checkAnyAllConditions(t *testing.T) {
+	resource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "test",
+			"namespace": "default",
+			"creationTimestamp": "2022-01-01T00:00:00Z"
+		}
+	}`)
+	testCases := []struct {
+		name       string
+		conditions []byte
+		want       bool
+	}{{
+		name:       "all passes",
+		conditions: []byte(`{"all":[{"key":"{{ target.metadata.name }}","operator":"Equals","value":"test"}]}`),
+		want:       true,
+	}, {
+		name:       "all fails",
+		conditions: []byte(`{"all":[{"key":"{{ target.metadata.name }}","operator":"Equals","value":"foo"}]}`),
+		want:       false,
+	}, {
+		name:       "any passes",
+		conditions: []byte(`{"any":[{"key":"{{ target.metadata.name }}","operator":"Equals","value":"foo"},{"key":"{{ request.namespace }}","operator":"Equals","value":"default"}]}`),
+		want:       true,
+	}, {
+		name:       "age",
+		conditions: []byte(`{"all":[{"key":"{{ time_since('', '{{ target.metadata.creationTimestamp }}', '') }}","operator":"DurationGreaterThan","value":"24h"}]}`),
+		want:       true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var object map[string]interface{}
+			assert.NilError(t, json.Unmarshal(resource, &object))
+			var conditions kyvernov2beta1.AnyAllConditions
+			assert.NilError(t, json.Unmarshal(tc.conditions, &conditions))
+			ctx := enginecontext.NewContext()
+			assert.NilError(t, ctx.AddTargetResource(object))
+			assert.NilError(t, ctx.AddNamespace("default"))
+			got, err := checkAnyAllConditions(logging.GlobalLogger(), ctx, conditions)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -6,37 +6,69 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// maxDryRunResources is the maximum number of resources reported in the policy status in dry run mode
+	maxDryRunResources = 100
+	// listPageSize is the number of resources fetched per list call
+	listPageSize = 500
 )
 
 type handlers struct {
-	client     dclient.Interface
-	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister
-	polLister  kyvernov2alpha1listers.CleanupPolicyLister
-	nsLister   corev1listers.NamespaceLister
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	cpolLister    kyvernov2alpha1listers.ClusterCleanupPolicyLister
+	polLister     kyvernov2alpha1listers.CleanupPolicyLister
+	nsLister      corev1listers.NamespaceLister
+	cmResolver    resolvers.ConfigmapResolver
+	rclient       registryclient.Client
+	eventGen      event.Interface
+	metrics       cleanupMetrics
 }
 
 func New(
+	logger logr.Logger,
 	client dclient.Interface,
+	kyvernoClient versioned.Interface,
 	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister,
 	polLister kyvernov2alpha1listers.CleanupPolicyLister,
 	nsLister corev1listers.NamespaceLister,
+	cmResolver resolvers.ConfigmapResolver,
+	rclient registryclient.Client,
+	eventGen event.Interface,
 ) *handlers {
 	return &handlers{
-		client:     client,
-		cpolLister: cpolLister,
-		polLister:  polLister,
-		nsLister:   nsLister,
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolLister,
+		polLister:     polLister,
+		nsLister:      nsLister,
+		cmResolver:    cmResolver,
+		rclient:       rclient,
+		eventGen:      eventGen,
+		metrics:       newCleanupMetrics(logger),
 	}
 }
 
-func (h *handlers) Cleanup(ctx context.Context, logger logr.Logger, name string, _ time.Time) error {
+func (h *handlers) Cleanup(ctx context.Context, logger logr.Logger, name string, now time.Time) error {
 	logger.Info("cleaning up...")
 	namespace, name, err := cache.SplitMetaNamespaceKey(name)
 	if err != nil {
@@ -46,7 +78,7 @@ func (h *handlers) Cleanup(ctx context.Context, logger logr.Logger, name string,
 	if err != nil {
 		return err
 	}
-	return h.executePolicy(ctx, logger, policy)
+	return h.executePolicy(ctx, logger, policy, now)
 }
 
 func (h *handlers) lookupPolicy(namespace, name string) (kyvernov2alpha1.CleanupPolicyInterface, error) {
@@ -57,60 +89,187 @@ func (h *handlers) lookupPolicy(namespace, name string) (kyvernov2alpha1.Cleanup
 	}
 }
 
-func (h *handlers) executePolicy(ctx context.Context, logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface) error {
+func (h *handlers) executePolicy(ctx context.Context, logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface, now time.Time) error {
 	spec := policy.GetSpec()
 	kinds := sets.NewString(spec.MatchResources.GetKinds()...)
+	deleteOptions := metav1.DeleteOptions{PropagationPolicy: spec.DeletionPropagationPolicy}
+	var limiter flowcontrol.RateLimiter
+	if spec.DeletionsPerSecond > 0 {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(float32(spec.DeletionsPerSecond), 1)
+	}
 	var errs []error
-	for kind := range kinds {
+	var results []kyvernov2alpha1.CleanupResult
+	var dryRunResources []corev1.ObjectReference
+	// count of resources deleted (or that would be deleted in dry run mode)
+	var count int
+	limitReached := func() bool {
+		return spec.MaxDeletionsPerRun > 0 && count >= spec.MaxDeletionsPerRun
+	}
+	for _, kind := range kinds.List() {
+		if limitReached() {
+			logger.V(3).Info("max deletions per run reached, skipping remaining kinds", "maxDeletionsPerRun", spec.MaxDeletionsPerRun)
+			break
+		}
 		logger := logger.WithValues("kind", kind)
 		logger.V(5).Info("processing...")
-		list, err := h.client.ListResource(ctx, "", kind, policy.GetNamespace(), nil)
-		if err != nil {
-			logger.Error(err, "failed to list resources")
-			errs = append(errs, err)
-		} else {
+		result := kyvernov2alpha1.CleanupResult{Kind: kind}
+		listOptions := metav1.ListOptions{Limit: listPageSize}
+		for {
+			list, err := h.client.ListResourceWithOptions(ctx, "", kind, policy.GetNamespace(), listOptions)
+			if err != nil {
+				logger.Error(err, "failed to list resources")
+				errs = append(errs, err)
+				h.metrics.recordError(ctx, policy, kind, "")
+				break
+			}
 			for i := range list.Items {
+				if limitReached() {
+					break
+				}
 				resource := list.Items[i]
 				namespace := resource.GetNamespace()
 				name := resource.GetName()
 				logger := logger.WithValues("name", name, "namespace", namespace)
-				if !controllerutils.IsManagedByKyverno(&resource) {
-					var nsLabels map[string]string
-					if namespace != "" {
-						ns, err := h.nsLister.Get(namespace)
-						if err != nil {
-							logger.Error(err, "failed to get namespace labels")
-							errs = append(errs, err)
-						}
-						nsLabels = ns.GetLabels()
-					}
-					// match namespaces
-					if err := checkNamespace(policy.GetNamespace(), resource); err != nil {
-						logger.V(5).Info("resource namespace didn't match policy namespace", "result", err)
-					}
-					// match resource with match/exclude clause
-					matched := checkMatchesResources(resource, spec.MatchResources, nsLabels)
-					if matched != nil {
-						logger.V(5).Info("resource/match didn't match", "result", matched)
-						continue
-					}
-					if spec.ExcludeResources != nil {
-						excluded := checkMatchesResources(resource, *spec.ExcludeResources, nsLabels)
-						if excluded == nil {
-							logger.V(5).Info("resource/exclude matched")
-							continue
-						} else {
-							logger.V(5).Info("resource/exclude didn't match", "result", excluded)
-						}
+				matched, err := h.matchResource(logger, policy, resource)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if !matched {
+					continue
+				}
+				count++
+				if spec.DryRun {
+					logger.V(5).Info("resource matched, it would be deleted (dry run)...")
+					h.eventGen.Add(event.NewCleanupDryRunEvent(policy, resource))
+					h.metrics.recordDryRun(ctx, policy, kind, namespace)
+					if len(dryRunResources) < maxDryRunResources {
+						dryRunResources = append(dryRunResources, corev1.ObjectReference{
+							APIVersion: resource.GetAPIVersion(),
+							Kind:       resource.GetKind(),
+							Namespace:  namespace,
+							Name:       name,
+							UID:        resource.GetUID(),
+						})
 					}
-					logger.V(5).Info("resource matched, it will be deleted...")
-					if err := h.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, false); err != nil {
-						logger.Error(err, "failed to delete resource")
+					continue
+				}
+				if limiter != nil {
+					if err := limiter.Wait(ctx); err != nil {
+						logger.Error(err, "failed to wait for rate limiter")
 						errs = append(errs, err)
+						break
 					}
 				}
+				logger.V(5).Info("resource matched, it will be deleted...")
+				if err := h.client.DeleteResourceWithOptions(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, deleteOptions); err != nil {
+					logger.Error(err, "failed to delete resource")
+					errs = append(errs, err)
+					result.Failed++
+					h.metrics.recordError(ctx, policy, kind, namespace)
+				} else {
+					result.Deleted++
+					h.metrics.recordDeleted(ctx, policy, kind, namespace)
+				}
 			}
+			if list.GetContinue() == "" || limitReached() || ctx.Err() != nil {
+				break
+			}
+			listOptions.Continue = list.GetContinue()
+		}
+		results = append(results, result)
+	}
+	err := multierr.Combine(errs...)
+	if statusErr := h.updateStatus(ctx, policy, func(status *kyvernov2alpha1.CleanupPolicyStatus) {
+		status.LastExecutionTime = &metav1.Time{Time: now}
+		status.NextExecutionTime = nextExecutionTime(spec.Schedule, now)
+		status.LastExecutionResults = results
+		status.LastError = ""
+		if err != nil {
+			status.LastError = err.Error()
+		}
+		status.DryRunResources = dryRunResources
+	}); statusErr != nil {
+		logger.Error(statusErr, "failed to update policy status")
+		return multierr.Append(err, statusErr)
+	}
+	return err
+}
+
+// matchResource returns true if the resource is selected by the policy and has to be deleted
+func (h *handlers) matchResource(logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured) (bool, error) {
+	namespace := resource.GetNamespace()
+	var nsLabels map[string]string
+	if namespace != "" {
+		ns, err := h.nsLister.Get(namespace)
+		if err != nil {
+			logger.Error(err, "failed to get namespace labels")
+			return false, err
+		}
+		nsLabels = ns.GetLabels()
+	}
+	policyContext := engine.NewPolicyContextWithJsonContext(enginecontext.NewContext()).
+		WithClient(h.client).
+		WithInformerCacheResolver(h.cmResolver)
+	return MatchResource(logger, h.rclient, policy, resource, nsLabels, policyContext)
+}
+
+// MatchResource returns true if the resource is selected by the policy and has to be deleted.
+// The conditions are evaluated in the JSON context of the policy context, after loading the
+// context entries of the policy with the clients of the policy context.
+func MatchResource(logger logr.Logger, rclient registryclient.Client, policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, nsLabels map[string]string, policyContext *engine.PolicyContext) (bool, error) {
+	spec := policy.GetSpec()
+	if controllerutils.IsManagedByKyverno(&resource) {
+		return false, nil
+	}
+	// match namespaces
+	if err := checkNamespace(policy.GetNamespace(), resource); err != nil {
+		logger.V(5).Info("resource namespace didn't match policy namespace", "result", err)
+		return false, nil
+	}
+	// match resource with match/exclude clause
+	matched := checkMatchesResources(resource, spec.MatchResources, nsLabels)
+	if matched != nil {
+		logger.V(5).Info("resource/match didn't match", "result", matched)
+		return false, nil
+	}
+	if spec.ExcludeResources != nil {
+		excluded := checkMatchesResources(resource, *spec.ExcludeResources, nsLabels)
+		if excluded == nil {
+			logger.V(5).Info("resource/exclude matched")
+			return false, nil
+		} else {
+			logger.V(5).Info("resource/exclude didn't match", "result", excluded)
+		}
+	}
+	// check conditions
+	if spec.Conditions != nil {
+		enginectx := policyContext.JSONContext()
+		if err := enginectx.AddTargetResource(resource.Object); err != nil {
+			logger.Error(err, "failed to add resource in context")
+			return false, err
+		}
+		if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
+			logger.Error(err, "failed to add namespace in context")
+			return false, err
+		}
+		if err := enginectx.AddImageInfos(&resource); err != nil {
+			logger.Error(err, "failed to add image infos in context")
+			return false, err
+		}
+		if err := engine.LoadContext(logger, rclient, spec.Context, policyContext, ""); err != nil {
+			logger.Error(err, "failed to load context")
+			return false, err
+		}
+		passed, err := checkAnyAllConditions(logger, enginectx, *spec.Conditions)
+		if err != nil {
+			logger.Error(err, "failed to check condition")
+			return false, err
+		}
+		if !passed {
+			logger.V(5).Info("conditions did not pass")
+			return false, nil
 		}
 	}
-	return multierr.Combine(errs...)
+	return true, nil
 }
@@ -0,0 +1,162 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	kyvernofake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/logging"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newTestHandlers(t *testing.T, policy *kyvernov2alpha1.ClusterCleanupPolicy) (*handlers, dclient.Interface) {
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+	}
+	objects := []runtime.Object{
+		kubeutils.NewUnstructured("v1", "Namespace", "", "ns-1"),
+		kubeutils.NewUnstructured("v1", "Namespace", "", "ns-2"),
+		kubeutils.NewUnstructured("v1", "Namespace", "", "ns-3"),
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	return &handlers{
+		client:        client,
+		kyvernoClient: kyvernofake.NewSimpleClientset(policy),
+		eventGen:      event.NewFake(),
+	}, client
+}
+
+func newTestPolicy(spec kyvernov2alpha1.CleanupPolicySpec) *kyvernov2alpha1.ClusterCleanupPolicy {
+	spec.Schedule = "* * * * *"
+	spec.MatchResources = kyvernov2beta1.MatchResources{
+		Any: kyvernov1.ResourceFilters{{
+			ResourceDescription: kyvernov1.ResourceDescription{
+				Kinds: []string{"Namespace"},
+			},
+		}},
+	}
+	return &kyvernov2alpha1.ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: spec,
+	}
+}
+
+func Test_executePolicy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      kyvernov2alpha1.CleanupPolicySpec
+		remaining int
+		deleted   int
+		dryRun    int
+	}{{
+		name:      "delete all",
+		spec:      kyvernov2alpha1.CleanupPolicySpec{},
+		remaining: 0,
+		deleted:   3,
+	}, {
+		name: "max deletions per run",
+		spec: kyvernov2alpha1.CleanupPolicySpec{
+			MaxDeletionsPerRun: 2,
+		},
+		remaining: 1,
+		deleted:   2,
+	}, {
+		name: "rate limited",
+		spec: kyvernov2alpha1.CleanupPolicySpec{
+			DeletionsPerSecond: 100,
+		},
+		remaining: 0,
+		deleted:   3,
+	}, {
+		name: "dry run",
+		spec: kyvernov2alpha1.CleanupPolicySpec{
+			DryRun: true,
+		},
+		remaining: 3,
+		dryRun:    3,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+			policy := newTestPolicy(tc.spec)
+			h, client := newTestHandlers(t, policy)
+			err := h.executePolicy(ctx, logging.GlobalLogger(), policy, time.Now())
+			assert.NilError(t, err)
+			list, err := client.ListResource(ctx, "", "Namespace", "", nil)
+			assert.NilError(t, err)
+			assert.Equal(t, len(list.Items), tc.remaining)
+			updated, err := h.kyvernoClient.KyvernoV2alpha1().ClusterCleanupPolicies().Get(ctx, policy.GetName(), metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Assert(t, updated.Status.LastExecutionTime != nil)
+			assert.Equal(t, len(updated.Status.LastExecutionResults), 1)
+			assert.Equal(t, updated.Status.LastExecutionResults[0].Deleted, tc.deleted)
+			assert.Equal(t, len(updated.Status.DryRunResources), tc.dryRun)
+		})
+	}
+}
+
+func Test_MatchResource(t *testing.T) {
+	newPod := func(namespace string, labels map[string]string) unstructured.Unstructured {
+		pod := kubeutils.NewUnstructured("v1", "Pod", namespace, "pod")
+		pod.SetLabels(labels)
+		return *pod
+	}
+	policy := &kyvernov2alpha1.CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "ns-1",
+		},
+		Spec: kyvernov2alpha1.CleanupPolicySpec{
+			Schedule: "* * * * *",
+			MatchResources: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds: []string{"Pod"},
+					},
+				}},
+			},
+		},
+	}
+	testCases := []struct {
+		name     string
+		resource unstructured.Unstructured
+		want     bool
+	}{{
+		name:     "policy namespace",
+		resource: newPod("ns-1", nil),
+		want:     true,
+	}, {
+		name:     "other namespace",
+		resource: newPod("ns-2", nil),
+		want:     false,
+	}, {
+		name:     "managed by kyverno",
+		resource: newPod("ns-1", map[string]string{kyvernov1.LabelAppManagedBy: kyvernov1.ValueKyvernoApp}),
+		want:     false,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policyContext := engine.NewPolicyContextWithJsonContext(enginecontext.NewContext())
+			matched, err := MatchResource(logging.GlobalLogger(), nil, policy, tc.resource, nil, policyContext)
+			assert.NilError(t, err)
+			assert.Equal(t, matched, tc.want)
+		})
+	}
+}
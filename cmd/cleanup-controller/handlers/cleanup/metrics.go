@@ -0,0 +1,81 @@
+package cleanup
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+type cleanupMetrics struct {
+	deletedObjects syncint64.Counter
+	errors         syncint64.Counter
+	dryRunObjects  syncint64.Counter
+}
+
+func newCleanupMetrics(logger logr.Logger) cleanupMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	deletedObjects, err := meter.SyncInt64().Counter(
+		"kyverno_cleanup_controller_deleted_objects",
+		instrument.WithDescription("can be used to track number of objects deleted by cleanup policies"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_cleanup_controller_deleted_objects")
+	}
+	errors, err := meter.SyncInt64().Counter(
+		"kyverno_cleanup_controller_errors",
+		instrument.WithDescription("can be used to track number of errors encountered while executing cleanup policies"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_cleanup_controller_errors")
+	}
+	dryRunObjects, err := meter.SyncInt64().Counter(
+		"kyverno_cleanup_controller_dry_run_objects",
+		instrument.WithDescription("can be used to track number of objects that would have been deleted by cleanup policies in dry run mode"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_cleanup_controller_dry_run_objects")
+	}
+	return cleanupMetrics{
+		deletedObjects: deletedObjects,
+		errors:         errors,
+		dryRunObjects:  dryRunObjects,
+	}
+}
+
+func metricAttributes(policy kyvernov2alpha1.CleanupPolicyInterface, kind, namespace string) []attribute.KeyValue {
+	policyType := "cluster"
+	if policy.GetNamespace() != "" {
+		policyType = "namespaced"
+	}
+	return []attribute.KeyValue{
+		attribute.String("policy_type", policyType),
+		attribute.String("policy_namespace", policy.GetNamespace()),
+		attribute.String("policy_name", policy.GetName()),
+		attribute.String("resource_kind", kind),
+		attribute.String("resource_namespace", namespace),
+	}
+}
+
+func (m cleanupMetrics) recordDeleted(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, kind, namespace string) {
+	if m.deletedObjects != nil {
+		m.deletedObjects.Add(ctx, 1, metricAttributes(policy, kind, namespace)...)
+	}
+}
+
+func (m cleanupMetrics) recordError(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, kind, namespace string) {
+	if m.errors != nil {
+		m.errors.Add(ctx, 1, metricAttributes(policy, kind, namespace)...)
+	}
+}
+
+func (m cleanupMetrics) recordDryRun(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, kind, namespace string) {
+	if m.dryRunObjects != nil {
+		m.dryRunObjects.Add(ctx, 1, metricAttributes(policy, kind, namespace)...)
+	}
+}
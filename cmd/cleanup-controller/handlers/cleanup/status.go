@@ -0,0 +1,45 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (h *handlers) updateStatus(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, update func(*kyvernov2alpha1.CleanupPolicyStatus)) error {
+	if policy.GetNamespace() == "" {
+		_, err := controllerutils.UpdateStatus(
+			ctx,
+			policy.(*kyvernov2alpha1.ClusterCleanupPolicy),
+			h.kyvernoClient.KyvernoV2alpha1().ClusterCleanupPolicies(),
+			func(policy *kyvernov2alpha1.ClusterCleanupPolicy) error {
+				update(&policy.Status)
+				return nil
+			},
+		)
+		return err
+	} else {
+		_, err := controllerutils.UpdateStatus(
+			ctx,
+			policy.(*kyvernov2alpha1.CleanupPolicy),
+			h.kyvernoClient.KyvernoV2alpha1().CleanupPolicies(policy.GetNamespace()),
+			func(policy *kyvernov2alpha1.CleanupPolicy) error {
+				update(&policy.Status)
+				return nil
+			},
+		)
+		return err
+	}
+}
+
+func nextExecutionTime(schedule string, now time.Time) *metav1.Time {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: sched.Next(now)}
+}
@@ -0,0 +1,39 @@
+package cleanup
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_nextExecutionTime(t *testing.T) {
+	now := time.Date(2022, 12, 1, 10, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		schedule string
+		want     time.Time
+	}{{
+		name:     "hourly",
+		schedule: "0 * * * *",
+		want:     time.Date(2022, 12, 1, 11, 0, 0, 0, time.UTC),
+	}, {
+		name:     "daily",
+		schedule: "0 0 * * *",
+		want:     time.Date(2022, 12, 2, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:     "invalid",
+		schedule: "invalid",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := nextExecutionTime(tc.schedule, now)
+			if tc.want.IsZero() {
+				assert.Assert(t, got == nil)
+			} else {
+				assert.Assert(t, got != nil)
+				assert.Equal(t, got.Time, tc.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package apply
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -64,7 +65,10 @@ type ApplyCommandConfig struct {
 	ResourcePaths   []string
 	PolicyPaths     []string
 	GitBranch       string
+	OutputFormat    string
 	warnExitCode    int
+	// resourceFiles maps the keys of the resources loaded from local paths to their file
+	resourceFiles map[string]string
 }
 
 var applyHelp = `
@@ -82,6 +86,9 @@ To apply policies from a gitSourceURL on a cluster:
 	Example: Taking github.com as a gitSourceURL here. Some other standards  gitSourceURL are: gitlab.com , bitbucket.org , etc.
 		kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster
 
+To print the results in a machine readable format (json, junit or sarif):
+        kyverno apply /path/to/policy.yaml --resource /path/to/resources/ --output-format sarif > results.sarif
+
 To apply policy with variables:
 
 	1. To apply single policy with variable on single resource use flag "set".
@@ -152,12 +159,20 @@ func Command() *cobra.Command {
 				}
 			}()
 			applyCommandConfig.PolicyPaths = policyPaths
-			rc, resources, skipInvalidPolicies, pvInfos, err := applyCommandConfig.applyCommandHelper()
+			if err := validateOutputFormat(applyCommandConfig.OutputFormat); err != nil {
+				return sanitizederror.NewWithError("invalid output format", err)
+			}
+			var out io.Writer = os.Stdout
+			if applyCommandConfig.OutputFormat != "" {
+				// stdout is reserved for the formatted results, other messages are printed on stderr
+				out = os.Stderr
+			}
+			rc, resources, skipInvalidPolicies, pvInfos, err := applyCommandConfig.applyCommandHelper(out)
 			if err != nil {
 				return err
 			}
 
-			PrintReportOrViolation(applyCommandConfig.PolicyReport, rc, applyCommandConfig.ResourcePaths, len(resources), skipInvalidPolicies, applyCommandConfig.Stdin, pvInfos, applyCommandConfig.warnExitCode)
+			PrintReportOrViolation(out, applyCommandConfig.PolicyReport, applyCommandConfig.OutputFormat, rc, applyCommandConfig.resourceFiles, len(resources), skipInvalidPolicies, applyCommandConfig.Stdin, pvInfos, applyCommandConfig.warnExitCode)
 			return nil
 		},
 	}
@@ -176,15 +191,17 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&applyCommandConfig.Context, "context", "", "", "The name of the kubeconfig context to use")
 	cmd.Flags().StringVarP(&applyCommandConfig.GitBranch, "git-branch", "b", "", "test git repository branch")
 	cmd.Flags().BoolVarP(&applyCommandConfig.AuditWarn, "audit-warn", "", false, "If set to true, will flag audit policies as warnings instead of failures")
+	cmd.Flags().StringVar(&applyCommandConfig.OutputFormat, "output-format", "", "Prints the results in the given format, one of json, junit or sarif")
 	cmd.Flags().IntVar(&applyCommandConfig.warnExitCode, "warn-exit-code", 0, "Set the exit code for warnings; if failures or errors are found, will exit 1")
 	return cmd
 }
 
-func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, resources []*unstructured.Unstructured, skipInvalidPolicies SkippedInvalidPolicies, pvInfos []common.Info, err error) {
-	store.SetMock(true)
-	store.SetRegistryAccess(c.RegistryAccess)
+func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (rc *common.ResultCounts, resources []*unstructured.Unstructured, skipInvalidPolicies SkippedInvalidPolicies, pvInfos []common.Info, err error) {
+	cliStore := &store.Store{}
+	cliStore.SetMock(true)
+	cliStore.SetRegistryAccess(c.RegistryAccess)
 	if c.Cluster {
-		store.AllowApiCall(true)
+		cliStore.AllowApiCall(true)
 	}
 	fs := memfs.New()
 
@@ -192,7 +209,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("pass the values either using set flag or values_file flag", err)
 	}
 
-	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(c.VariablesString, c.ValuesFile, fs, false, "")
+	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(cliStore, out, c.VariablesString, c.ValuesFile, fs, false, "")
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
 			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to decode yaml", err)
@@ -237,14 +254,14 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	var policies []kyvernov1.PolicyInterface
 	gitSourceURL, err := url.Parse(c.PolicyPaths[0])
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
 	pathElems := strings.Split(gitSourceURL.Path[1:], "/")
 	if len(pathElems) <= 1 {
 		err := fmt.Errorf("invalid URL path %s - expected https://<any_git_source_domain>/:owner/:repository/:branch (without --git-branch flag) OR https://<any_git_source_domain>/:owner/:repository/:directory (with --git-branch flag)", gitSourceURL.Path)
-		fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -255,7 +272,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		c.GitBranch, gitPathToYamls = common.GetGitBranchOrPolicyPaths(c.GitBranch, repoURL, c.PolicyPaths)
 		_, cloneErr := gitutils.Clone(repoURL, fs, c.GitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
@@ -266,9 +283,9 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		c.PolicyPaths = policyYamls
 		sort.Strings(policyYamls)
 	}
-	policies, err = common.GetPoliciesFromPaths(fs, c.PolicyPaths, isGit, "")
+	policies, err = common.GetPoliciesFromPaths(fs, out, c.PolicyPaths, isGit, "")
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
 		os.Exit(1)
 	}
 
@@ -303,11 +320,13 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to marsal mutated policy", err)
 	}
 
-	resources, err = common.GetResourceAccordingToResourcePath(fs, c.ResourcePaths, c.Cluster, policies, dClient, c.Namespace, c.PolicyReport, false, "")
+	var files common.ResourceFiles
+	resources, files, err = common.GetResourceAccordingToResourcePath(fs, out, c.ResourcePaths, c.Cluster, policies, dClient, c.Namespace, c.PolicyReport, false, "")
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
 		os.Exit(1)
 	}
+	c.resourceFiles = resourceFileKeys(files)
 
 	if (len(resources) > 1 || len(policies) > 1) && c.VariablesString != "" {
 		return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("currently `set` flag supports variable for single policy applied on single resource ", nil)
@@ -317,16 +336,16 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	var userInfo v1beta1.RequestInfo
 	var subjectInfo store.Subject
 	if c.UserInfoPath != "" {
-		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, c.UserInfoPath, false, "")
+		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, out, c.UserInfoPath, false, "")
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
 			os.Exit(1)
 		}
-		store.SetSubjects(subjectInfo)
+		cliStore.SetSubjects(subjectInfo)
 	}
 
 	if c.VariablesString != "" {
-		variables = common.SetInStoreContext(policies, variables)
+		variables = common.SetInStoreContext(cliStore, policies, variables)
 	}
 
 	var policyRulesCount, mutatedPolicyRulesCount int
@@ -355,9 +374,9 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	if len(policies) > 0 && len(resources) > 0 {
 		if !c.Stdin {
 			if mutatedPolicyRulesCount > policyRulesCount {
-				fmt.Printf("\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(out, "\nauto-generated pod policies\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			} else {
-				fmt.Printf("\nApplying %s to %s...\n", msgPolicyRules, msgResources)
+				fmt.Fprintf(out, "\nApplying %s to %s...\n", msgPolicyRules, msgResources)
 			}
 		}
 	}
@@ -394,7 +413,7 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 		kindOnwhichPolicyIsApplied := common.GetKindsFromPolicy(policy)
 
 		for _, resource := range resources {
-			thisPolicyResourceValues, err := common.CheckVariableForPolicy(valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
+			thisPolicyResourceValues, err := common.CheckVariableForPolicy(cliStore, valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
 			if err != nil {
 				return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
 			}
@@ -412,6 +431,8 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 				PrintPatchResource:   true,
 				Client:               dClient,
 				AuditWarn:            c.AuditWarn,
+				Store:                cliStore,
+				Out:                  out,
 			}
 			_, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -446,43 +467,51 @@ func checkMutateLogPath(mutateLogPath string) (mutateLogPathIsDir bool, err erro
 	return mutateLogPathIsDir, err
 }
 
-// PrintReportOrViolation - printing policy report/violations
-func PrintReportOrViolation(policyReport bool, rc *common.ResultCounts, resourcePaths []string, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []common.Info, warnExitCode int) {
+// PrintReportOrViolation - printing policy report/violations, human readable messages are printed to out
+// and formatted results to stdout
+func PrintReportOrViolation(out io.Writer, policyReport bool, outputFormat string, rc *common.ResultCounts, resourceFiles map[string]string, resourcesLen int, skipInvalidPolicies SkippedInvalidPolicies, stdin bool, pvInfos []common.Info, warnExitCode int) {
 	divider := "----------------------------------------------------------------------"
 
 	if len(skipInvalidPolicies.skipped) > 0 {
-		fmt.Println(divider)
-		fmt.Println("Policies Skipped (as required variables are not provided by the user):")
+		fmt.Fprintln(out, divider)
+		fmt.Fprintln(out, "Policies Skipped (as required variables are not provided by the user):")
 		for i, policyName := range skipInvalidPolicies.skipped {
-			fmt.Printf("%d. %s\n", i+1, policyName)
+			fmt.Fprintf(out, "%d. %s\n", i+1, policyName)
 		}
-		fmt.Println(divider)
+		fmt.Fprintln(out, divider)
 	}
 	if len(skipInvalidPolicies.invalid) > 0 {
-		fmt.Println(divider)
-		fmt.Println("Invalid Policies:")
+		fmt.Fprintln(out, divider)
+		fmt.Fprintln(out, "Invalid Policies:")
 		for i, policyName := range skipInvalidPolicies.invalid {
-			fmt.Printf("%d. %s\n", i+1, policyName)
+			fmt.Fprintf(out, "%d. %s\n", i+1, policyName)
 		}
-		fmt.Println(divider)
+		fmt.Fprintln(out, divider)
 	}
 
-	if policyReport {
+	if outputFormat != "" {
+		output, err := formatResults(outputFormat, pvInfos, resourceFiles)
+		if err != nil {
+			fmt.Fprintf(out, "Error: failed to format results\nCause: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stdout, string(output))
+	} else if policyReport {
 		resps := buildPolicyReports(pvInfos)
 		if len(resps) > 0 || resourcesLen == 0 {
-			fmt.Println(divider)
-			fmt.Println("POLICY REPORT:")
-			fmt.Println(divider)
+			fmt.Fprintln(out, divider)
+			fmt.Fprintln(out, "POLICY REPORT:")
+			fmt.Fprintln(out, divider)
 			report, _ := generateCLIRaw(resps)
 			yamlReport, _ := yaml1.Marshal(report)
-			fmt.Println(string(yamlReport))
+			fmt.Fprintln(out, string(yamlReport))
 		} else {
-			fmt.Println(divider)
-			fmt.Println("POLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
+			fmt.Fprintln(out, divider)
+			fmt.Fprintln(out, "POLICY REPORT: skip generating policy report (no validate policy found/resource skipped)")
 		}
 	} else {
 		if !stdin {
-			fmt.Printf("\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
+			fmt.Fprintf(out, "\npass: %d, fail: %d, warn: %d, error: %d, skip: %d \n",
 				rc.Pass, rc.Fail, rc.Warn, rc.Error, rc.Skip)
 		}
 	}
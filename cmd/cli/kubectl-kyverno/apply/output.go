@@ -0,0 +1,302 @@
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/junit"
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+const (
+	outputFormatJSON  = "json"
+	outputFormatJUnit = "junit"
+	outputFormatSARIF = "sarif"
+)
+
+var outputFormats = []string{outputFormatJSON, outputFormatJUnit, outputFormatSARIF}
+
+// validateOutputFormat checks the output format is empty (human readable output) or supported
+func validateOutputFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, f := range outputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %s, supported formats are %s", format, strings.Join(outputFormats, ", "))
+}
+
+// formatResults renders the results of the validation rules in the given output format, resourceFiles
+// maps the keys of the resources loaded from local paths to their file
+func formatResults(format string, pvInfos []common.Info, resourceFiles map[string]string) ([]byte, error) {
+	results := buildResultsList(pvInfos)
+	switch format {
+	case outputFormatJSON:
+		return formatJSON(results)
+	case outputFormatJUnit:
+		return formatJUnit(results)
+	case outputFormatSARIF:
+		return formatSARIF(results, resourceFiles)
+	}
+	return nil, validateOutputFormat(format)
+}
+
+type jsonResource struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+type jsonResult struct {
+	Policy   string       `json:"policy"`
+	Rule     string       `json:"rule"`
+	Resource jsonResource `json:"resource"`
+	Result   string       `json:"result"`
+	Message  string       `json:"message,omitempty"`
+	Severity string       `json:"severity,omitempty"`
+	Category string       `json:"category,omitempty"`
+}
+
+type jsonOutput struct {
+	Summary policyreportv1alpha2.PolicyReportSummary `json:"summary"`
+	Results []jsonResult                             `json:"results"`
+}
+
+func formatJSON(results []policyreportv1alpha2.PolicyReportResult) ([]byte, error) {
+	output := jsonOutput{
+		Summary: calculateSummary(results),
+		Results: []jsonResult{},
+	}
+	for _, result := range results {
+		for _, resource := range result.Resources {
+			output.Results = append(output.Results, jsonResult{
+				Policy: result.Policy,
+				Rule:   result.Rule,
+				Resource: jsonResource{
+					APIVersion: resource.APIVersion,
+					Kind:       resource.Kind,
+					Namespace:  resource.Namespace,
+					Name:       resource.Name,
+				},
+				Result:   string(result.Result),
+				Message:  result.Message,
+				Severity: string(result.Severity),
+				Category: result.Category,
+			})
+		}
+	}
+	return json.MarshalIndent(output, "", "  ")
+}
+
+// resourceKey returns a human readable identifier of a resource
+func resourceKey(kind, namespace, name string) string {
+	if namespace == "" {
+		return kind + "/" + name
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// resourceFileKeys returns the files of the resources by resource key
+func resourceFileKeys(files common.ResourceFiles) map[string]string {
+	keys := map[string]string{}
+	for resource, file := range files {
+		keys[resourceKey(resource.GetKind(), resource.GetNamespace(), resource.GetName())] = file
+	}
+	return keys
+}
+
+func formatJUnit(results []policyreportv1alpha2.PolicyReportResult) ([]byte, error) {
+	report := junit.TestSuites{Name: "kyverno apply"}
+	suites := map[string]*junit.TestSuite{}
+	var order []string
+	for _, result := range results {
+		suite := suites[result.Policy]
+		if suite == nil {
+			suite = &junit.TestSuite{Name: result.Policy}
+			if result.Category != "" {
+				suite.AddProperty("category", result.Category)
+			}
+			if result.Severity != "" {
+				suite.AddProperty("severity", string(result.Severity))
+			}
+			suites[result.Policy] = suite
+			order = append(order, result.Policy)
+		}
+		for _, resource := range result.Resources {
+			testCase := junit.TestCase{
+				Name:      result.Rule + " " + resourceKey(resource.Kind, resource.Namespace, resource.Name),
+				ClassName: result.Policy,
+			}
+			outcome := &junit.Result{Message: result.Message, Type: string(result.Result), Text: result.Message}
+			switch string(result.Result) {
+			case policyreportv1alpha2.StatusFail:
+				testCase.Failure = outcome
+			case policyreportv1alpha2.StatusError:
+				testCase.Error = outcome
+			case policyreportv1alpha2.StatusSkip:
+				testCase.Skipped = outcome
+			default:
+				testCase.SystemOut = result.Message
+			}
+			suite.AddCase(testCase)
+		}
+	}
+	for _, name := range order {
+		report.AddSuite(*suites[name])
+	}
+	return report.Marshal()
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	ShortDescription sarifMessage           `json:"shortDescription"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifSecuritySeverity maps policy severities to the scores used by code scanning tools
+var sarifSecuritySeverity = map[policyreportv1alpha2.PolicySeverity]string{
+	policyreportv1alpha2.SeverityHigh:   "8.0",
+	policyreportv1alpha2.SeverityMedium: "5.5",
+	policyreportv1alpha2.SeverityLow:    "2.0",
+}
+
+// formatSARIF renders failed, warned and errored results as a SARIF log.
+// Results are located in the file their resource was loaded from, if any.
+func formatSARIF(results []policyreportv1alpha2.PolicyReportResult, resourceFiles map[string]string) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "kyverno",
+				InformationURI: "https://kyverno.io",
+				Version:        version.BuildVersion,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+	ruleIndexes := map[string]int{}
+	for _, result := range results {
+		var level string
+		switch string(result.Result) {
+		case policyreportv1alpha2.StatusFail, policyreportv1alpha2.StatusError:
+			level = "error"
+		case policyreportv1alpha2.StatusWarn:
+			level = "warning"
+		default:
+			continue
+		}
+		ruleID := result.Policy + "/" + result.Rule
+		index, ok := ruleIndexes[ruleID]
+		if !ok {
+			rule := sarifRule{
+				ID:               ruleID,
+				Name:             result.Rule,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("policy %s rule %s", result.Policy, result.Rule)},
+			}
+			properties := map[string]interface{}{}
+			if result.Category != "" {
+				properties["category"] = result.Category
+				properties["tags"] = []string{result.Category}
+			}
+			if result.Severity != "" {
+				properties["severity"] = string(result.Severity)
+				properties["security-severity"] = sarifSecuritySeverity[result.Severity]
+			}
+			if len(properties) > 0 {
+				rule.Properties = properties
+			}
+			index = len(run.Tool.Driver.Rules)
+			ruleIndexes[ruleID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		}
+		for _, resource := range result.Resources {
+			key := resourceKey(resource.Kind, resource.Namespace, resource.Name)
+			var physicalLocation *sarifPhysicalLocation
+			if file, ok := resourceFiles[key]; ok {
+				physicalLocation = &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(file)},
+				}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    ruleID,
+				RuleIndex: index,
+				Level:     level,
+				Message:   sarifMessage{Text: result.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: physicalLocation,
+					LogicalLocations: []sarifLogicalLocation{{
+						FullyQualifiedName: key,
+						Kind:               "resource",
+					}},
+				}},
+			})
+		}
+	}
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
@@ -0,0 +1,178 @@
+package apply
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/junit"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"gotest.tools/assert"
+)
+
+func buildOutputTestInfos(t *testing.T) []common.Info {
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	policy.SetAnnotations(map[string]string{
+		kyverno.AnnotationPolicyCategory: "Best Practices",
+		kyverno.AnnotationPolicySeverity: "medium",
+	})
+	var er response.EngineResponse
+	assert.NilError(t, json.Unmarshal(rawEngRes, &er))
+	er.Policy = &policy
+	info := common.ProcessValidateEngineResponse(io.Discard, &policy, &er, "", &common.ResultCounts{}, true, false)
+	return []common.Info{info}
+}
+
+func Test_validateOutputFormat(t *testing.T) {
+	assert.NilError(t, validateOutputFormat(""))
+	assert.NilError(t, validateOutputFormat("json"))
+	assert.NilError(t, validateOutputFormat("junit"))
+	assert.NilError(t, validateOutputFormat("sarif"))
+	assert.Error(t, validateOutputFormat("yaml"), "invalid output format yaml, supported formats are json, junit, sarif")
+}
+
+func Test_formatResults_JSON(t *testing.T) {
+	data, err := formatResults("json", buildOutputTestInfos(t), nil)
+	assert.NilError(t, err)
+	var output jsonOutput
+	assert.NilError(t, json.Unmarshal(data, &output))
+	assert.Equal(t, output.Summary.Pass, 1)
+	assert.Equal(t, output.Summary.Fail, 1)
+	assert.Equal(t, output.Summary.Skip, 4)
+	assert.Equal(t, len(output.Results), 6)
+	for _, result := range output.Results {
+		assert.Equal(t, result.Policy, "pod-requirements")
+		assert.Equal(t, result.Resource.Kind, "Pod")
+		assert.Equal(t, result.Resource.Name, "nginx1")
+		assert.Equal(t, result.Severity, "medium")
+		assert.Equal(t, result.Category, "Best Practices")
+		switch result.Rule {
+		case "pods-require-account":
+			assert.Equal(t, result.Result, "fail")
+			assert.Assert(t, result.Message != "")
+		case "pods-require-limits":
+			assert.Equal(t, result.Result, "pass")
+		default:
+			assert.Equal(t, result.Result, "skip")
+		}
+	}
+}
+
+func Test_formatResults_JUnit(t *testing.T) {
+	data, err := formatResults("junit", buildOutputTestInfos(t), nil)
+	assert.NilError(t, err)
+	var report junit.TestSuites
+	assert.NilError(t, xml.Unmarshal(data, &report))
+	assert.Equal(t, report.Tests, 6)
+	assert.Equal(t, report.Failures, 1)
+	assert.Equal(t, report.Skipped, 4)
+	assert.Equal(t, len(report.Suites), 1)
+	suite := report.Suites[0]
+	assert.Equal(t, suite.Name, "pod-requirements")
+	assert.DeepEqual(t, suite.Properties.Properties, []junit.Property{
+		{Name: "category", Value: "Best Practices"},
+		{Name: "severity", Value: "medium"},
+	})
+	for _, testCase := range suite.Cases {
+		switch testCase.Name {
+		case "pods-require-account Pod/default/nginx1":
+			assert.Assert(t, testCase.Failure != nil)
+			assert.Equal(t, testCase.Failure.Type, "fail")
+		case "pods-require-limits Pod/default/nginx1":
+			assert.Assert(t, testCase.Failure == nil)
+			assert.Assert(t, testCase.Skipped == nil)
+		default:
+			assert.Assert(t, testCase.Skipped != nil)
+		}
+	}
+}
+
+func Test_formatResults_SARIF(t *testing.T) {
+	data, err := formatResults("sarif", buildOutputTestInfos(t), map[string]string{"Pod/default/nginx1": "resources/pod.yaml"})
+	assert.NilError(t, err)
+	var log sarifLog
+	assert.NilError(t, json.Unmarshal(data, &log))
+	assert.Equal(t, log.Version, "2.1.0")
+	assert.Equal(t, len(log.Runs), 1)
+	run := log.Runs[0]
+	assert.Equal(t, run.Tool.Driver.Name, "kyverno")
+	assert.Equal(t, len(run.Tool.Driver.Rules), 1)
+	rule := run.Tool.Driver.Rules[0]
+	assert.Equal(t, rule.ID, "pod-requirements/pods-require-account")
+	assert.Equal(t, rule.Properties["security-severity"], "5.5")
+	// passed results are not reported
+	assert.Equal(t, len(run.Results), 1)
+	result := run.Results[0]
+	assert.Equal(t, result.RuleID, rule.ID)
+	assert.Equal(t, result.Level, "error")
+	assert.Equal(t, result.Locations[0].PhysicalLocation.ArtifactLocation.URI, "resources/pod.yaml")
+	assert.Equal(t, result.Locations[0].LogicalLocations[0].FullyQualifiedName, "Pod/default/nginx1")
+}
+
+func Test_formatResults_SARIFMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	pods := filepath.Join(dir, "pods.yaml")
+	deployment := filepath.Join(dir, "deployment.yaml")
+	assert.NilError(t, os.WriteFile(pods, []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx2
+`), 0o600))
+	assert.NilError(t, os.WriteFile(deployment, []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+  namespace: apps
+`), 0o600))
+	resources, files, err := common.GetResourceAccordingToResourcePath(nil, io.Discard, []string{pods, deployment}, false, nil, nil, "", false, false, "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 3)
+	var infos []common.Info
+	for _, resource := range resources {
+		infos = append(infos, common.Info{
+			PolicyName: "require-labels",
+			Results: []common.EngineResponseResult{{
+				Resource: response.ResourceSpec{Kind: resource.GetKind(), Namespace: resource.GetNamespace(), Name: resource.GetName()},
+				Rules:    []kyverno.ViolatedRule{{Name: "check-labels", Type: string(response.Validation), Status: "fail", Message: "labels are required"}},
+			}},
+		})
+	}
+	// the Pod of an unknown file has no physical location
+	infos = append(infos, common.Info{
+		PolicyName: "require-labels",
+		Results: []common.EngineResponseResult{{
+			Resource: response.ResourceSpec{Kind: "Pod", Namespace: "default", Name: "cluster"},
+			Rules:    []kyverno.ViolatedRule{{Name: "check-labels", Type: string(response.Validation), Status: "fail", Message: "labels are required"}},
+		}},
+	})
+	data, err := formatResults("sarif", infos, resourceFileKeys(files))
+	assert.NilError(t, err)
+	var log sarifLog
+	assert.NilError(t, json.Unmarshal(data, &log))
+	locations := map[string]string{}
+	for _, result := range log.Runs[0].Results {
+		location := result.Locations[0]
+		if location.PhysicalLocation == nil {
+			locations[location.LogicalLocations[0].FullyQualifiedName] = ""
+		} else {
+			locations[location.LogicalLocations[0].FullyQualifiedName] = location.PhysicalLocation.ArtifactLocation.URI
+		}
+	}
+	assert.DeepEqual(t, locations, map[string]string{
+		"Pod/default/nginx1":    filepath.ToSlash(pods),
+		"Pod/default/nginx2":    filepath.ToSlash(pods),
+		"Deployment/apps/nginx": filepath.ToSlash(deployment),
+		"Pod/default/cluster":   "",
+	})
+}
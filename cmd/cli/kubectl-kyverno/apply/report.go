@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -87,36 +88,55 @@ func buildPolicyResults(infos []common.Info) map[string][]policyreportv1alpha2.P
 			appname = clusterpolicyreport
 		}
 
-		for _, infoResult := range info.Results {
-			for _, rule := range infoResult.Rules {
-				if rule.Type != string(response.Validation) {
-					continue
-				}
-
-				result := policyreportv1alpha2.PolicyReportResult{
-					Policy: info.PolicyName,
-					Resources: []corev1.ObjectReference{
-						{
-							Kind:       infoResult.Resource.Kind,
-							Namespace:  infoResult.Resource.Namespace,
-							APIVersion: infoResult.Resource.APIVersion,
-							Name:       infoResult.Resource.Name,
-							UID:        types.UID(infoResult.Resource.UID),
-						},
+		results[appname] = append(results[appname], buildInfoResults(info, now)...)
+	}
+
+	return results
+}
+
+// buildResultsList returns the PolicyReportResults of all infos, in order
+func buildResultsList(infos []common.Info) []policyreportv1alpha2.PolicyReportResult {
+	var results []policyreportv1alpha2.PolicyReportResult
+	now := metav1.Timestamp{Seconds: time.Now().Unix()}
+	for _, info := range infos {
+		results = append(results, buildInfoResults(info, now)...)
+	}
+	return results
+}
+
+// buildInfoResults returns the PolicyReportResults of the validation rules of an info
+func buildInfoResults(info common.Info, now metav1.Timestamp) []policyreportv1alpha2.PolicyReportResult {
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, infoResult := range info.Results {
+		for _, rule := range infoResult.Rules {
+			if rule.Type != string(response.Validation) {
+				continue
+			}
+
+			result := policyreportv1alpha2.PolicyReportResult{
+				Policy: info.PolicyName,
+				Resources: []corev1.ObjectReference{
+					{
+						Kind:       infoResult.Resource.Kind,
+						Namespace:  infoResult.Resource.Namespace,
+						APIVersion: infoResult.Resource.APIVersion,
+						Name:       infoResult.Resource.Name,
+						UID:        types.UID(infoResult.Resource.UID),
 					},
-					Scored: true,
-				}
-
-				result.Rule = rule.Name
-				result.Message = rule.Message
-				result.Result = policyreportv1alpha2.PolicyResult(rule.Status)
-				result.Source = kyvernov1.ValueKyvernoApp
-				result.Timestamp = now
-				results[appname] = append(results[appname], result)
+				},
+				Scored: true,
 			}
+
+			result.Rule = rule.Name
+			result.Message = rule.Message
+			result.Result = policyreportv1alpha2.PolicyResult(rule.Status)
+			result.Source = kyvernov1.ValueKyvernoApp
+			result.Timestamp = now
+			result.Category = info.Annotations[kyvernov1.AnnotationPolicyCategory]
+			result.Severity = reportutils.SeverityFromString(info.Annotations[kyvernov1.AnnotationPolicySeverity])
+			results = append(results, result)
 		}
 	}
-
 	return results
 }
 
@@ -0,0 +1,59 @@
+package create
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type cleanupPolicyOptions struct {
+	Name      string
+	Namespace string
+	Kinds     []string
+	Schedule  string
+	OlderThan string
+}
+
+// Kind returns CleanupPolicy for a namespaced policy, ClusterCleanupPolicy otherwise
+func (o cleanupPolicyOptions) Kind() string {
+	if o.Namespace != "" {
+		return "CleanupPolicy"
+	}
+	return "ClusterCleanupPolicy"
+}
+
+func (o cleanupPolicyOptions) validate() error {
+	if o.OlderThan != "" {
+		if _, err := time.ParseDuration(o.OlderThan); err != nil {
+			return fmt.Errorf("invalid duration %s: %v", o.OlderThan, err)
+		}
+	}
+	return nil
+}
+
+func cleanupPolicyCommand() *cobra.Command {
+	var options cleanupPolicyOptions
+	var output string
+	cmd := &cobra.Command{
+		Use:   "cleanup-policy <name>",
+		Short: "Creates a cleanup policy",
+		Long:  "Creates a cleanup policy, a namespaced CleanupPolicy when a namespace is given and a ClusterCleanupPolicy otherwise.",
+		Example: `# Create a cluster cleanup policy deleting pods older than a day, every hour
+kyverno create cleanup-policy cleanup-pods --schedule "0 * * * *" --older-than 24h`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Name = args[0]
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return writeOutput(cmd, output, "cleanup-policy.yaml", options)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the cleanup policy, a cluster cleanup policy is created when empty")
+	cmd.Flags().StringSliceVar(&options.Kinds, "kind", []string{"Pod"}, "kinds of the resources matched by the cleanup policy")
+	cmd.Flags().StringVar(&options.Schedule, "schedule", "*/5 * * * *", "schedule of the cleanup policy in cron format")
+	cmd.Flags().StringVar(&options.OlderThan, "older-than", "", "only delete resources created more than this duration ago, e.g. 24h")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file the cleanup policy is written to, the standard output when empty")
+	return cmd
+}
@@ -0,0 +1,104 @@
+package create
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var funcs = template.FuncMap{
+	"quote": strconv.Quote,
+}
+
+// Command returns the create command
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Creates policies, tests and the files they use",
+		Long:  "Creates well-formed policies, cleanup policies, tests, values files and user info files from flags, to be completed and edited afterwards.",
+		Example: `# Create a cluster policy with an enforced validate rule for pods and deployments
+kyverno create policy require-labels --kind Pod --kind Deployment --validation-failure-action Enforce
+
+# Create a test for existing policies and resources, with an expected result for each rule and matching resource
+kyverno create test --policy policy.yaml --resource resources.yaml --output kyverno-test.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(
+		policyCommand(),
+		cleanupPolicyCommand(),
+		testCommand(),
+		valuesCommand(),
+		userInfoCommand(),
+	)
+	return cmd
+}
+
+// render executes a template with the given data, leading blank lines left by optional sections are removed
+func render(out io.Writer, name string, data interface{}) error {
+	tpl, err := template.New(name).Funcs(funcs).ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := tpl.Execute(&buffer, data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, strings.TrimLeft(buffer.String(), "\n"))
+	return err
+}
+
+// writeOutput renders a template to the output file, or to the standard output when the output is empty
+func writeOutput(cmd *cobra.Command, output, name string, data interface{}) error {
+	if output == "" {
+		return render(cmd.OutOrStdout(), name, data)
+	}
+	file, err := os.Create(filepath.Clean(output))
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", output, err)
+	}
+	if err := render(file, name, data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+type keyValue struct {
+	Key   string
+	Value string
+}
+
+// parseKeyValues parses key=value pairs, in the order they are given
+func parseKeyValues(pairs []string) ([]keyValue, error) {
+	var keyValues []keyValue
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid key value pair %s, the format is key=value", pair)
+		}
+		keyValues = append(keyValues, keyValue{Key: key, Value: value})
+	}
+	return keyValues, nil
+}
+
+func checkOneOf(flag, value string, values ...string) error {
+	for _, v := range values {
+		if value == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s %s, supported values are %s", flag, value, strings.Join(values, ", "))
+}
@@ -0,0 +1,134 @@
+package create
+
+import (
+	"bytes"
+	"testing"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+func Test_renderPolicy(t *testing.T) {
+	testCases := []struct {
+		ruleType  string
+		namespace string
+		kind      string
+	}{
+		{ruleType: ruleTypeValidate, kind: "ClusterPolicy"},
+		{ruleType: ruleTypeMutate, namespace: "team-a", kind: "Policy"},
+		{ruleType: ruleTypeGenerate, kind: "ClusterPolicy"},
+		{ruleType: ruleTypeVerifyImages, kind: "ClusterPolicy"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ruleType, func(t *testing.T) {
+			options := policyOptions{
+				Name:                    "test-policy",
+				Namespace:               tc.namespace,
+				RuleName:                "test-rule",
+				RuleType:                tc.ruleType,
+				Kinds:                   []string{"Pod", "Deployment"},
+				ValidationFailureAction: "Enforce",
+				Background:              false,
+			}
+			assert.NilError(t, options.validate())
+			var out bytes.Buffer
+			assert.NilError(t, render(&out, "policy.yaml", options))
+			policies, err := yamlutils.GetPolicy(out.Bytes())
+			assert.NilError(t, err)
+			assert.Equal(t, len(policies), 1)
+			policy := policies[0]
+			assert.Equal(t, policy.GetKind(), tc.kind)
+			assert.Equal(t, policy.GetNamespace(), tc.namespace)
+			spec := policy.GetSpec()
+			assert.Equal(t, spec.ValidationFailureAction.Enforce(), true)
+			assert.Equal(t, *spec.Background, false)
+			assert.Equal(t, len(spec.Rules), 1)
+			rule := spec.Rules[0]
+			assert.Equal(t, rule.Name, "test-rule")
+			assert.DeepEqual(t, rule.MatchResources.Any[0].Kinds, []string{"Pod", "Deployment"})
+			assert.Equal(t, ruleType(rule), tc.ruleType)
+		})
+	}
+}
+
+func Test_policyOptionsValidate(t *testing.T) {
+	options := policyOptions{RuleType: "audit", ValidationFailureAction: "Audit"}
+	assert.Error(t, options.validate(), "invalid rule type audit, supported values are validate, mutate, generate, verify-images")
+	options = policyOptions{RuleType: ruleTypeValidate, ValidationFailureAction: "block"}
+	assert.Error(t, options.validate(), "invalid validation failure action block, supported values are Audit, Enforce")
+}
+
+func Test_renderCleanupPolicy(t *testing.T) {
+	options := cleanupPolicyOptions{
+		Name:      "cleanup-pods",
+		Namespace: "team-a",
+		Kinds:     []string{"Pod"},
+		Schedule:  "*/5 * * * *",
+		OlderThan: "24h",
+	}
+	assert.NilError(t, options.validate())
+	var out bytes.Buffer
+	assert.NilError(t, render(&out, "cleanup-policy.yaml", options))
+	policies, err := yamlutils.GetCleanupPolicy(out.Bytes())
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	policy := policies[0]
+	assert.Equal(t, policy.GetKind(), "CleanupPolicy")
+	assert.Equal(t, policy.GetNamespace(), "team-a")
+	assert.Equal(t, len(policy.Validate(sets.NewString())), 0)
+	assert.Equal(t, policy.GetSpec().Schedule, "*/5 * * * *")
+	assert.Equal(t, len(policy.GetSpec().Conditions.AllConditions), 1)
+}
+
+func Test_renderValues(t *testing.T) {
+	options, err := newValuesOptions(
+		[]string{"request.operation=CREATE"},
+		[]string{"prod,environment=production"},
+		[]string{"require-labels,check-team,team=platform"},
+		[]string{"require-labels,nginx,team=web,tier=frontend"},
+	)
+	assert.NilError(t, err)
+	var out bytes.Buffer
+	assert.NilError(t, render(&out, "values.yaml", options))
+	var values common.Values
+	assert.NilError(t, yaml.Unmarshal(out.Bytes(), &values))
+	assert.DeepEqual(t, values, common.Values{
+		GlobalValues: map[string]string{"request.operation": "CREATE"},
+		NamespaceSelectors: []common.NamespaceSelector{
+			{Name: "prod", Labels: map[string]string{"environment": "production"}},
+		},
+		Policies: []common.Policy{{
+			Name:      "require-labels",
+			Rules:     []common.Rule{{Name: "check-team", Values: map[string]interface{}{"team": "platform"}}},
+			Resources: []common.Resource{{Name: "nginx", Values: map[string]interface{}{"team": "web", "tier": "frontend"}}},
+		}},
+	})
+}
+
+func Test_newValuesOptionsErrors(t *testing.T) {
+	_, err := newValuesOptions([]string{"operation"}, nil, nil, nil)
+	assert.Error(t, err, "invalid key value pair operation, the format is key=value")
+	_, err = newValuesOptions(nil, nil, []string{"require-labels,team=platform"}, nil)
+	assert.Error(t, err, "invalid rule values require-labels,team=platform")
+}
+
+func Test_renderUserInfo(t *testing.T) {
+	options := userInfoOptions{
+		Username:     "molybdenum@somecorp.com",
+		Groups:       []string{"system:authenticated"},
+		Roles:        []string{"team-a:developer"},
+		ClusterRoles: []string{"cluster-admin"},
+	}
+	var out bytes.Buffer
+	assert.NilError(t, render(&out, "user-info.yaml", options))
+	var userInfo kyvernov1beta1.RequestInfo
+	assert.NilError(t, yaml.Unmarshal(out.Bytes(), &userInfo))
+	assert.Equal(t, userInfo.AdmissionUserInfo.Username, "molybdenum@somecorp.com")
+	assert.DeepEqual(t, userInfo.AdmissionUserInfo.Groups, []string{"system:authenticated"})
+	assert.DeepEqual(t, userInfo.Roles, []string{"team-a:developer"})
+	assert.DeepEqual(t, userInfo.ClusterRoles, []string{"cluster-admin"})
+}
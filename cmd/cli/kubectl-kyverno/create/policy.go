@@ -0,0 +1,81 @@
+package create
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	ruleTypeValidate     = "validate"
+	ruleTypeMutate       = "mutate"
+	ruleTypeGenerate     = "generate"
+	ruleTypeVerifyImages = "verify-images"
+)
+
+type policyOptions struct {
+	Name                    string
+	Namespace               string
+	RuleName                string
+	RuleType                string
+	Kinds                   []string
+	ValidationFailureAction string
+	Background              bool
+}
+
+// Kind returns Policy for a namespaced policy, ClusterPolicy otherwise
+func (o policyOptions) Kind() string {
+	if o.Namespace != "" {
+		return "Policy"
+	}
+	return "ClusterPolicy"
+}
+
+// GenerateNamespace is the namespace of the resource generated by a generate rule,
+// the triggering namespace or the namespace of the triggering resource
+func (o policyOptions) GenerateNamespace() string {
+	if slices.Contains(o.Kinds, "Namespace") {
+		return "{{request.object.metadata.name}}"
+	}
+	return "{{request.object.metadata.namespace}}"
+}
+
+func (o policyOptions) validate() error {
+	if err := checkOneOf("rule type", o.RuleType, ruleTypeValidate, ruleTypeMutate, ruleTypeGenerate, ruleTypeVerifyImages); err != nil {
+		return err
+	}
+	return checkOneOf("validation failure action", o.ValidationFailureAction, "Audit", "Enforce")
+}
+
+func policyCommand() *cobra.Command {
+	var options policyOptions
+	var output string
+	cmd := &cobra.Command{
+		Use:   "policy <name>",
+		Short: "Creates a policy with a single rule",
+		Long:  "Creates a policy with a single rule of the given type, a namespaced Policy when a namespace is given and a ClusterPolicy otherwise.",
+		Example: `# Create a cluster policy with a validate rule for pods
+kyverno create policy require-labels
+
+# Create a namespaced policy with a mutate rule for deployments and statefulsets, written to a file
+kyverno create policy add-labels --namespace team-a --rule-type mutate --kind Deployment --kind StatefulSet --output policy.yaml`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Name = args[0]
+			if options.RuleName == "" {
+				options.RuleName = options.Name
+			}
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return writeOutput(cmd, output, "policy.yaml", options)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "namespace of the policy, a cluster policy is created when empty")
+	cmd.Flags().StringVar(&options.RuleName, "rule", "", "name of the rule, defaults to the name of the policy")
+	cmd.Flags().StringVar(&options.RuleType, "rule-type", ruleTypeValidate, "type of the rule, one of validate, mutate, generate and verify-images")
+	cmd.Flags().StringSliceVar(&options.Kinds, "kind", []string{"Pod"}, "kinds of the resources matched by the rule")
+	cmd.Flags().StringVar(&options.ValidationFailureAction, "validation-failure-action", "Audit", "validation failure action of the policy, Audit or Enforce")
+	cmd.Flags().BoolVar(&options.Background, "background", true, "apply the policy to existing resources in the background")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file the policy is written to, the standard output when empty")
+	return cmd
+}
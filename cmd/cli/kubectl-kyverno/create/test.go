@@ -0,0 +1,196 @@
+package create
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// testResult is the expected result stub of a rule for the matching resources of a kind in a namespace
+type testResult struct {
+	Policy    string
+	Rule      string
+	RuleType  string
+	Kind      string
+	Namespace string
+	Resources []string
+}
+
+type testOptions struct {
+	Name      string
+	Policies  []string
+	Resources []string
+	Values    string
+	UserInfo  string
+	Results   []*testResult
+}
+
+// ruleType returns the type of a rule, test results of mutate and generate rules need the expected resource
+func ruleType(rule kyvernov1.Rule) string {
+	switch {
+	case rule.HasMutate():
+		return ruleTypeMutate
+	case rule.HasGenerate():
+		return ruleTypeGenerate
+	case rule.HasVerifyImages():
+		return ruleTypeVerifyImages
+	}
+	return ruleTypeValidate
+}
+
+// userRuleName returns the name of a rule as written in test results, autogen rules are referred to by the name of their rule
+func userRuleName(name string) string {
+	if strings.HasPrefix(name, "autogen-cronjob-") {
+		return strings.TrimPrefix(name, "autogen-cronjob-")
+	}
+	return strings.TrimPrefix(name, "autogen-")
+}
+
+// buildTestResults returns a result stub for each rule of the policies, including autogen rules,
+// and the resources it matches. Preconditions and conditions are not evaluated.
+func buildTestResults(policies []kyvernov1.PolicyInterface, resources []*unstructured.Unstructured, userInfo kyvernov1beta1.RequestInfo) []*testResult {
+	var results []*testResult
+	index := map[string]*testResult{}
+	for _, policy := range policies {
+		policyName := policy.GetName()
+		if policy.IsNamespaced() {
+			policyName = policy.GetNamespace() + "/" + policyName
+		}
+		for _, rule := range autogen.ComputeRules(policy) {
+			for _, resource := range resources {
+				if err := engine.MatchesResourceDescription(*resource, rule, userInfo, nil, nil, policy.GetNamespace(), nil); err != nil {
+					continue
+				}
+				ruleName := userRuleName(rule.Name)
+				key := strings.Join([]string{policyName, ruleName, resource.GetKind(), resource.GetNamespace()}, "/")
+				result, ok := index[key]
+				if !ok {
+					result = &testResult{
+						Policy:    policyName,
+						Rule:      ruleName,
+						RuleType:  ruleType(rule),
+						Kind:      resource.GetKind(),
+						Namespace: resource.GetNamespace(),
+					}
+					index[key] = result
+					results = append(results, result)
+				}
+				result.Resources = append(result.Resources, resource.GetName())
+			}
+		}
+	}
+	return results
+}
+
+// loadResources loads the resources of the resource files
+func loadResources(paths []string) ([]*unstructured.Unstructured, error) {
+	var resources []*unstructured.Unstructured
+	for _, path := range paths {
+		bytes, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		fileResources, err := common.GetResource(bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load resources from %s: %v", path, err)
+		}
+		resources = append(resources, fileResources...)
+	}
+	return resources, nil
+}
+
+// relativePath returns a path relative to the directory of the test file, test files paths are relative to their directory
+func relativePath(dir, path string) string {
+	if path == "" || dir == "" {
+		return path
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func newTestOptions(name string, policyPaths, resourcePaths []string, valuesPath, userInfoPath, output string) (*testOptions, error) {
+	policies, errs := common.GetPolicies(policyPaths)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to load policies: %v", errs)
+	}
+	resources, err := loadResources(resourcePaths)
+	if err != nil {
+		return nil, err
+	}
+	var userInfo kyvernov1beta1.RequestInfo
+	if userInfoPath != "" {
+		if userInfo, _, err = common.GetUserInfoFromPath(nil, os.Stdout, userInfoPath, false, ""); err != nil {
+			return nil, err
+		}
+	}
+	dir := ""
+	if output != "" {
+		dir = filepath.Dir(output)
+	}
+	options := &testOptions{
+		Name:     name,
+		Values:   relativePath(dir, valuesPath),
+		UserInfo: relativePath(dir, userInfoPath),
+		Results:  buildTestResults(policies, resources, userInfo),
+	}
+	for _, path := range policyPaths {
+		options.Policies = append(options.Policies, relativePath(dir, path))
+	}
+	for _, path := range resourcePaths {
+		options.Resources = append(options.Resources, relativePath(dir, path))
+	}
+	return options, nil
+}
+
+func testCommand() *cobra.Command {
+	var name, valuesPath, userInfoPath, output string
+	var policyPaths, resourcePaths []string
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Creates a test file",
+		Long: `Creates a test file for the kyverno test command. The policies and resources are loaded and an expected
+result is added for each policy rule, including autogen rules, and the resources it matches. Preconditions
+and conditions are not evaluated, every expected result is pass and has to be reviewed. Paths are written
+relative to the directory of the output file.`,
+		Example: `# Create a test for a policy and its resources
+kyverno create test --name require-labels --policy policy.yaml --resource resources.yaml --output kyverno-test.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(policyPaths) == 0 {
+				return fmt.Errorf("at least one policy is required")
+			}
+			options, err := newTestOptions(name, policyPaths, resourcePaths, valuesPath, userInfoPath, output)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, output, "test.yaml", options)
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "kyverno-test", "name of the test")
+	cmd.Flags().StringSliceVarP(&policyPaths, "policy", "p", nil, "policy files or directories of the test")
+	cmd.Flags().StringSliceVarP(&resourcePaths, "resource", "r", nil, "resource files of the test")
+	cmd.Flags().StringVarP(&valuesPath, "values", "f", "", "values file of the test")
+	cmd.Flags().StringVarP(&userInfoPath, "user-info", "u", "", "user info file of the test")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file the test is written to, the standard output when empty")
+	return cmd
+}
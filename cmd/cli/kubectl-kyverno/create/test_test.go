@@ -0,0 +1,142 @@
+package create
+
+import (
+	"bytes"
+	"testing"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+	"sigs.k8s.io/yaml"
+)
+
+var testPolicies = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: check-labels
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "labels are required"
+      pattern:
+        metadata:
+          labels:
+            app: "?*"
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: add-labels
+  namespace: team-a
+  annotations:
+    pod-policies.kyverno.io/autogen-controllers: none
+spec:
+  rules:
+  - name: add-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            team: a
+`)
+
+var testResources = []byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+  namespace: team-a
+spec:
+  containers:
+  - name: nginx
+    image: nginx
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: busybox
+  namespace: team-b
+spec:
+  containers:
+  - name: busybox
+    image: busybox
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: team-b
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+`)
+
+func Test_buildTestResults(t *testing.T) {
+	policies, err := yamlutils.GetPolicy(testPolicies)
+	assert.NilError(t, err)
+	resources, err := common.GetResource(testResources)
+	assert.NilError(t, err)
+	results := buildTestResults(policies, resources, kyvernov1beta1.RequestInfo{})
+	assert.DeepEqual(t, results, []*testResult{
+		{Policy: "require-labels", Rule: "check-labels", RuleType: ruleTypeValidate, Kind: "Pod", Namespace: "team-a", Resources: []string{"nginx"}},
+		{Policy: "require-labels", Rule: "check-labels", RuleType: ruleTypeValidate, Kind: "Pod", Namespace: "team-b", Resources: []string{"busybox"}},
+		{Policy: "require-labels", Rule: "check-labels", RuleType: ruleTypeValidate, Kind: "Deployment", Namespace: "team-b", Resources: []string{"web"}},
+		{Policy: "team-a/add-labels", Rule: "add-team", RuleType: ruleTypeMutate, Kind: "Pod", Namespace: "team-a", Resources: []string{"nginx"}},
+	})
+}
+
+func Test_renderTest(t *testing.T) {
+	options := testOptions{
+		Name:      "labels",
+		Policies:  []string{"policies.yaml"},
+		Resources: []string{"resources.yaml"},
+		Values:    "values.yaml",
+		Results: []*testResult{
+			{Policy: "require-labels", Rule: "check-labels", RuleType: ruleTypeValidate, Kind: "Pod", Resources: []string{"nginx", "busybox"}},
+			{Policy: "team-a/add-labels", Rule: "add-team", RuleType: ruleTypeMutate, Kind: "Pod", Namespace: "team-a", Resources: []string{"nginx"}},
+		},
+	}
+	var out bytes.Buffer
+	assert.NilError(t, render(&out, "test.yaml", options))
+	var test api.Test
+	assert.NilError(t, yaml.Unmarshal(out.Bytes(), &test))
+	assert.Equal(t, test.Name, "labels")
+	assert.DeepEqual(t, test.Policies, []string{"policies.yaml"})
+	assert.DeepEqual(t, test.Resources, []string{"resources.yaml"})
+	assert.Equal(t, test.Variables, "values.yaml")
+	assert.Equal(t, len(test.Results), 2)
+	assert.DeepEqual(t, test.Results[0].Resources, []string{"nginx", "busybox"})
+	assert.Equal(t, string(test.Results[0].Result), "pass")
+	assert.Equal(t, test.Results[1].Policy, "team-a/add-labels")
+	assert.Equal(t, test.Results[1].Namespace, "team-a")
+	assert.Equal(t, test.Results[1].PatchedResource, "<path/to/patched/resource.yaml>")
+}
+
+func Test_relativePath(t *testing.T) {
+	assert.Equal(t, relativePath("", "policies/policy.yaml"), "policies/policy.yaml")
+	assert.Equal(t, relativePath("tests", "policies/policy.yaml"), "../policies/policy.yaml")
+	assert.Equal(t, relativePath("tests", ""), "")
+}
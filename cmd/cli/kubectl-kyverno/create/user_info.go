@@ -0,0 +1,34 @@
+package create
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type userInfoOptions struct {
+	Username     string
+	Groups       []string
+	Roles        []string
+	ClusterRoles []string
+}
+
+func userInfoCommand() *cobra.Command {
+	var options userInfoOptions
+	var output string
+	cmd := &cobra.Command{
+		Use:   "user-info",
+		Short: "Creates a user info file",
+		Long:  "Creates a user info file, the admission request user a test applies policies as.",
+		Example: `# Create the user info of a cluster admin
+kyverno create user-info --username molybdenum@somecorp.com --group system:authenticated --cluster-role cluster-admin --output user-info.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return writeOutput(cmd, output, "user-info.yaml", options)
+		},
+	}
+	cmd.Flags().StringVar(&options.Username, "username", "", "name of the user")
+	cmd.Flags().StringSliceVar(&options.Groups, "group", nil, "groups of the user")
+	cmd.Flags().StringSliceVar(&options.Roles, "role", nil, "roles of the user, in the namespace:name format")
+	cmd.Flags().StringSliceVar(&options.ClusterRoles, "cluster-role", nil, "cluster roles of the user")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file the user info is written to, the standard output when empty")
+	return cmd
+}
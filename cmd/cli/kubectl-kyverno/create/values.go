@@ -0,0 +1,118 @@
+package create
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type namedValues struct {
+	Name   string
+	Values []keyValue
+}
+
+type policyValues struct {
+	Name      string
+	Rules     []namedValues
+	Resources []namedValues
+}
+
+type namespaceSelector struct {
+	Name   string
+	Labels []keyValue
+}
+
+type valuesOptions struct {
+	GlobalValues       []keyValue
+	NamespaceSelectors []namespaceSelector
+	Policies           []*policyValues
+}
+
+func (o *valuesOptions) policy(name string) *policyValues {
+	for _, policy := range o.Policies {
+		if policy.Name == name {
+			return policy
+		}
+	}
+	policy := &policyValues{Name: name}
+	o.Policies = append(o.Policies, policy)
+	return policy
+}
+
+// parseNamed parses a comma separated list of names followed by key=value pairs, e.g. policy,rule,key=value
+func parseNamed(flag, value string, names int) ([]string, []keyValue, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) < names {
+		return nil, nil, fmt.Errorf("invalid %s %s", flag, value)
+	}
+	for _, name := range parts[:names] {
+		if name == "" || strings.Contains(name, "=") {
+			return nil, nil, fmt.Errorf("invalid %s %s", flag, value)
+		}
+	}
+	keyValues, err := parseKeyValues(parts[names:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid %s %s: %v", flag, value, err)
+	}
+	return parts[:names], keyValues, nil
+}
+
+func newValuesOptions(globalValues, namespaceSelectors, rules, resources []string) (*valuesOptions, error) {
+	var options valuesOptions
+	var err error
+	if options.GlobalValues, err = parseKeyValues(globalValues); err != nil {
+		return nil, err
+	}
+	for _, value := range namespaceSelectors {
+		names, labels, err := parseNamed("namespace selector", value, 1)
+		if err != nil {
+			return nil, err
+		}
+		options.NamespaceSelectors = append(options.NamespaceSelectors, namespaceSelector{Name: names[0], Labels: labels})
+	}
+	for _, value := range rules {
+		names, values, err := parseNamed("rule values", value, 2)
+		if err != nil {
+			return nil, err
+		}
+		policy := options.policy(names[0])
+		policy.Rules = append(policy.Rules, namedValues{Name: names[1], Values: values})
+	}
+	for _, value := range resources {
+		names, values, err := parseNamed("resource values", value, 2)
+		if err != nil {
+			return nil, err
+		}
+		policy := options.policy(names[0])
+		policy.Resources = append(policy.Resources, namedValues{Name: names[1], Values: values})
+	}
+	return &options, nil
+}
+
+func valuesCommand() *cobra.Command {
+	var globalValues, namespaceSelectors, rules, resources []string
+	var output string
+	cmd := &cobra.Command{
+		Use:   "values",
+		Short: "Creates a values file",
+		Long:  "Creates a values file, the values of the variables used by policies when they are applied by the apply and test commands.",
+		Example: `# Create a values file with a global value, the labels of a namespace, and rule and resource values
+kyverno create values --global request.operation=CREATE --namespace-selector prod,environment=production \
+  --rule require-labels,check-team,team=platform --resource require-labels,nginx,team=web --output values.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options, err := newValuesOptions(globalValues, namespaceSelectors, rules, resources)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, output, "values.yaml", options)
+		},
+	}
+	cmd.Flags().StringArrayVar(&globalValues, "global", nil, "global value, in the key=value format")
+	cmd.Flags().StringArrayVar(&namespaceSelectors, "namespace-selector", nil, "labels of a namespace, in the namespace,key=value,... format")
+	cmd.Flags().StringArrayVar(&rules, "rule", nil, "values of a policy rule, in the policy,rule,key=value,... format")
+	cmd.Flags().StringArrayVar(&resources, "resource", nil, "values of a resource for a policy, in the policy,resource,key=value,... format")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file the values are written to, the standard output when empty")
+	return cmd
+}
@@ -0,0 +1,169 @@
+package fix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+var longHelp = `
+The fix command migrates the deprecated fields of kyverno.io/v1 policies to the current schema:
+	- lowercase audit and enforce validation failure actions
+	- resources, subjects, roles and clusterRoles directly under match and exclude, moved to any
+	- bare lists of preconditions and deny conditions, moved to all
+	- the Equal and NotEqual condition operators, replaced with Equals and NotEquals
+	- image, key, issuer, subject, roots and annotations of verifyImages, moved to imageReferences and attestors
+
+Comments are preserved. Files are searched recursively in directories and only files with deprecated
+fields are written. By default the fixed files are printed to the standard output, use --save to fix
+them in place or --check to only report the deprecated fields and exit with an error if there are any.
+`
+
+// Command returns the fix command
+func Command() *cobra.Command {
+	var save, check bool
+	cmd := &cobra.Command{
+		Use:   "fix <path>...",
+		Short: "Migrates the deprecated fields of policies",
+		Long:  longHelp,
+		Example: `# Print the fixed policies of a directory
+kyverno fix policies/
+
+# Fix the policies of a directory in place
+kyverno fix policies/ --save
+
+# Fail when a policy uses deprecated fields, e.g. in a CI pipeline
+kyverno fix policies/ --check`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if save && check {
+				return errors.New("--save and --check cannot be used together")
+			}
+			cmd.SilenceUsage = true
+			deprecated, err := fixPaths(cmd.OutOrStdout(), cmd.ErrOrStderr(), args, save, check)
+			if err != nil {
+				return err
+			}
+			if check && deprecated > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "\n%d file(s) use deprecated fields, run kyverno fix --save to fix them\n", deprecated)
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&save, "save", false, "fix the files in place")
+	cmd.Flags().BoolVar(&check, "check", false, "only report the deprecated fields, exit with an error if there are any")
+	return cmd
+}
+
+// fixPaths fixes the YAML files of the paths and returns the number of files with deprecated fields.
+// The fixes are reported to out when saving or checking, and to errOut when the fixed files are printed to out.
+// Files that are not valid YAML are skipped.
+func fixPaths(out, errOut io.Writer, paths []string, save, check bool) (int, error) {
+	files, err := yamlFiles(paths)
+	if err != nil {
+		return 0, err
+	}
+	report := errOut
+	if save || check {
+		report = out
+	}
+	var deprecated int
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return deprecated, fmt.Errorf("failed to read %s: %v", file, err)
+		}
+		fixed, fixes, err := fixDocuments(content)
+		if err != nil {
+			fmt.Fprintf(errOut, "skipping %s: %v\n", file, err)
+			continue
+		}
+		if len(fixes) == 0 {
+			continue
+		}
+		deprecated++
+		for _, fix := range fixes {
+			fmt.Fprintf(report, "%s: %s\n", file, fix)
+		}
+		if check {
+			continue
+		}
+		if save {
+			info, err := os.Stat(file)
+			if err != nil {
+				return deprecated, err
+			}
+			if err := os.WriteFile(file, fixed, info.Mode()); err != nil {
+				return deprecated, fmt.Errorf("failed to write %s: %v", file, err)
+			}
+		} else {
+			fmt.Fprintf(out, "---\n%s", fixed)
+		}
+	}
+	return deprecated, nil
+}
+
+// yamlFiles returns the YAML files of the paths, directories are searched recursively
+func yamlFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			// files given explicitly are always fixed
+			if ext := filepath.Ext(file); file == path || ext == ".yaml" || ext == ".yml" {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// fixDocuments fixes the policies of a multi-document YAML file, other documents are kept unchanged
+func fixDocuments(content []byte) ([]byte, []string, error) {
+	nodes, err := (&kio.ByteReader{
+		Reader:            bytes.NewReader(content),
+		PreserveSeqIndent: true,
+	}).Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	var fixes []string
+	for _, node := range nodes {
+		for _, fix := range fixPolicy(node) {
+			fixes = append(fixes, fmt.Sprintf("%s %s: %s", node.GetKind(), policyName(node), fix))
+		}
+	}
+	if len(fixes) == 0 {
+		return content, nil, nil
+	}
+	var fixed bytes.Buffer
+	if err := (kio.ByteWriter{Writer: &fixed}).Write(nodes); err != nil {
+		return nil, nil, err
+	}
+	return fixed.Bytes(), fixes, nil
+}
+
+func policyName(node *yaml.RNode) string {
+	if namespace := node.GetNamespace(); namespace != "" {
+		return namespace + "/" + node.GetName()
+	}
+	return node.GetName()
+}
@@ -0,0 +1,172 @@
+package fix
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const deprecatedPolicy = `# image verification with deprecated fields
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-images
+spec:
+  validationFailureAction: enforce
+  rules:
+  - name: check-key
+    match:
+      resources:
+        kinds:
+        - Pod
+    # only for new pods
+    preconditions:
+    - key: "{{ request.operation }}"
+      operator: Equal
+      value: CREATE
+    verifyImages:
+    - image: "ghcr.io/kyverno/*"
+      key: |-
+        -----BEGIN PUBLIC KEY-----
+        MFkw
+        -----END PUBLIC KEY-----
+      annotations:
+        env: prod
+  - name: check-keyless
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    verifyImages:
+    - image: "ghcr.io/kyverno/*"
+      issuer: https://token.actions.githubusercontent.com
+      subject: https://github.com/kyverno/*
+      attestations:
+      - predicateType: https://slsa.dev/provenance/v0.2
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+`
+
+const fixedPolicy = `# image verification with deprecated fields
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-images
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: check-key
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    # only for new pods
+    preconditions:
+      all:
+      - key: "{{ request.operation }}"
+        operator: Equals
+        value: CREATE
+    verifyImages:
+    - imageReferences:
+      - "ghcr.io/kyverno/*"
+      attestors:
+      - entries:
+        - keys:
+            publicKeys: |-
+              -----BEGIN PUBLIC KEY-----
+              MFkw
+              -----END PUBLIC KEY-----
+          annotations:
+            env: prod
+  - name: check-keyless
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    verifyImages:
+    - attestations:
+      - predicateType: https://slsa.dev/provenance/v0.2
+        attestors:
+        - entries:
+          - keyless:
+              issuer: https://token.actions.githubusercontent.com
+              subject: https://github.com/kyverno/*
+              rekor:
+                url: https://rekor.sigstore.dev
+      imageReferences:
+      - "ghcr.io/kyverno/*"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+`
+
+func Test_fixDocuments(t *testing.T) {
+	fixed, fixes, err := fixDocuments([]byte(deprecatedPolicy))
+	assert.NilError(t, err)
+	assert.Equal(t, string(fixed), fixedPolicy)
+	assert.DeepEqual(t, fixes, []string{
+		"ClusterPolicy check-images: validationFailureAction enforce replaced with Enforce",
+		"ClusterPolicy check-images: rule check-key: match moved to match.any",
+		"ClusterPolicy check-images: rule check-key: preconditions list moved to preconditions.all",
+		"ClusterPolicy check-images: rule check-key: preconditions.all[0] operator Equal replaced with Equals",
+		"ClusterPolicy check-images: rule check-key: verifyImages[0].image moved to verifyImages[0].imageReferences",
+		"ClusterPolicy check-images: rule check-key: verifyImages[0].key moved to an attestor",
+		"ClusterPolicy check-images: rule check-key: verifyImages[0].annotations moved to an attestor",
+		"ClusterPolicy check-images: rule check-keyless: verifyImages[0].image moved to verifyImages[0].imageReferences",
+		"ClusterPolicy check-images: rule check-keyless: verifyImages[0].issuer, subject and roots moved to a keyless attestor",
+	})
+	// fixed policies have nothing left to fix
+	_, fixes, err = fixDocuments(fixed)
+	assert.NilError(t, err)
+	assert.Equal(t, len(fixes), 0)
+}
+
+func Test_fixPaths(t *testing.T) {
+	dir := t.TempDir()
+	deprecated := filepath.Join(dir, "deprecated.yaml")
+	current := filepath.Join(dir, "current.yml")
+	assert.NilError(t, os.WriteFile(deprecated, []byte(deprecatedPolicy), 0o600))
+	assert.NilError(t, os.WriteFile(current, []byte(fixedPolicy), 0o600))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# policies"), 0o600))
+
+	// check only reports
+	var out, errOut bytes.Buffer
+	count, err := fixPaths(&out, &errOut, []string{dir}, false, true)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+	assert.Assert(t, bytes.Contains(out.Bytes(), []byte(deprecated+": ClusterPolicy check-images: validationFailureAction enforce replaced with Enforce")))
+	content, err := os.ReadFile(deprecated)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), deprecatedPolicy)
+
+	// the fixed files are printed by default
+	out.Reset()
+	errOut.Reset()
+	count, err = fixPaths(&out, &errOut, []string{dir}, false, false)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+	assert.Equal(t, out.String(), "---\n"+fixedPolicy)
+
+	// save fixes in place
+	out.Reset()
+	count, err = fixPaths(&out, &errOut, []string{dir}, true, false)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+	content, err = os.ReadFile(deprecated)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), fixedPolicy)
+	count, err = fixPaths(&out, &errOut, []string{dir}, false, true)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
@@ -0,0 +1,242 @@
+package fix
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// deprecated condition operators and their replacement
+var conditionOperators = map[string]string{
+	"Equal":    "Equals",
+	"NotEqual": "NotEquals",
+}
+
+// deprecated validation failure actions and their replacement
+var validationFailureActions = map[string]string{
+	"audit":   "Audit",
+	"enforce": "Enforce",
+}
+
+// isPolicy returns true for kyverno.io/v1 policies, the only version with deprecated fields
+func isPolicy(node *yaml.RNode) bool {
+	kind := node.GetKind()
+	return node.GetApiVersion() == "kyverno.io/v1" && (kind == "ClusterPolicy" || kind == "Policy")
+}
+
+// fixPolicy migrates the deprecated fields of a policy in place and returns a description of each fix
+func fixPolicy(policy *yaml.RNode) []string {
+	if !isPolicy(policy) {
+		return nil
+	}
+	spec := fieldValue(policy, "spec")
+	if spec == nil {
+		return nil
+	}
+	var fixes []string
+	if action := fieldValue(spec, "validationFailureAction"); action != nil {
+		if replacement, ok := validationFailureActions[action.YNode().Value]; ok {
+			fixes = append(fixes, fmt.Sprintf("validationFailureAction %s replaced with %s", action.YNode().Value, replacement))
+			action.YNode().Value = replacement
+		}
+	}
+	for _, rule := range elements(fieldValue(spec, "rules")) {
+		name := ""
+		if n := fieldValue(rule, "name"); n != nil {
+			name = n.YNode().Value
+		}
+		for _, fix := range fixRule(rule) {
+			fixes = append(fixes, fmt.Sprintf("rule %s: %s", name, fix))
+		}
+	}
+	return fixes
+}
+
+func fixRule(rule *yaml.RNode) []string {
+	var fixes []string
+	fixes = append(fixes, fixResourceFilters(rule, "match")...)
+	fixes = append(fixes, fixResourceFilters(rule, "exclude")...)
+	fixes = append(fixes, fixConditions(fieldValue(rule, "preconditions"), "preconditions")...)
+	if mutate := fieldValue(rule, "mutate"); mutate != nil {
+		fixes = append(fixes, fixForeach(fieldValue(mutate, "foreach"), "mutate.foreach")...)
+	}
+	if validate := fieldValue(rule, "validate"); validate != nil {
+		fixes = append(fixes, fixDeny(validate, "validate.deny")...)
+		fixes = append(fixes, fixForeach(fieldValue(validate, "foreach"), "validate.foreach")...)
+	}
+	for i, imageVerification := range elements(fieldValue(rule, "verifyImages")) {
+		fixes = append(fixes, fixImageVerification(imageVerification, fmt.Sprintf("verifyImages[%d]", i))...)
+	}
+	return fixes
+}
+
+// fixResourceFilters moves the resource description and user info declared directly under match or exclude to any
+func fixResourceFilters(rule *yaml.RNode, field string) []string {
+	filters := fieldValue(rule, field)
+	if filters == nil || fieldValue(filters, "any") != nil || fieldValue(filters, "all") != nil {
+		return nil
+	}
+	filter := yaml.NewMapRNode(nil)
+	for _, name := range []string{"resources", "subjects", "roles", "clusterRoles"} {
+		moveField(filters, name, filter, name)
+	}
+	if len(filter.Content()) == 0 {
+		return nil
+	}
+	appendElement(filters, "any", filter)
+	return []string{fmt.Sprintf("%s moved to %s.any", field, field)}
+}
+
+func fixForeach(foreach *yaml.RNode, path string) []string {
+	var fixes []string
+	for i, element := range elements(foreach) {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		fixes = append(fixes, fixConditions(fieldValue(element, "preconditions"), elementPath+".preconditions")...)
+		fixes = append(fixes, fixDeny(element, elementPath+".deny")...)
+		fixes = append(fixes, fixForeach(fieldValue(element, "foreach"), elementPath+".foreach")...)
+	}
+	return fixes
+}
+
+func fixDeny(parent *yaml.RNode, path string) []string {
+	deny := fieldValue(parent, "deny")
+	if deny == nil {
+		return nil
+	}
+	return fixConditions(fieldValue(deny, "conditions"), path+".conditions")
+}
+
+// fixConditions wraps a bare list of conditions in all, which is how the list is evaluated,
+// and replaces the deprecated operators
+func fixConditions(conditions *yaml.RNode, path string) []string {
+	if conditions == nil {
+		return nil
+	}
+	var fixes []string
+	if conditions.YNode().Kind == yaml.SequenceNode {
+		list := *conditions.YNode()
+		*conditions.YNode() = yaml.Node{
+			Kind:    yaml.MappingNode,
+			Tag:     yaml.NodeTagMap,
+			Content: []*yaml.Node{{Kind: yaml.ScalarNode, Tag: yaml.NodeTagString, Value: "all"}, &list},
+		}
+		fixes = append(fixes, fmt.Sprintf("%s list moved to %s.all", path, path))
+	}
+	for _, group := range []string{"any", "all"} {
+		for i, condition := range elements(fieldValue(conditions, group)) {
+			operator := fieldValue(condition, "operator")
+			if operator == nil {
+				continue
+			}
+			if replacement, ok := conditionOperators[operator.YNode().Value]; ok {
+				fixes = append(fixes, fmt.Sprintf("%s.%s[%d] operator %s replaced with %s", path, group, i, operator.YNode().Value, replacement))
+				operator.YNode().Value = replacement
+			}
+		}
+	}
+	return fixes
+}
+
+// fixImageVerification migrates the deprecated image, key, keyless and annotations fields
+// the same way they are converted when the policy is applied
+func fixImageVerification(imageVerification *yaml.RNode, path string) []string {
+	var fixes []string
+	if image := fieldValue(imageVerification, "image"); image != nil {
+		if fieldValue(imageVerification, "imageReferences") == nil {
+			_ = imageVerification.PipeE(yaml.SetField("imageReferences", yaml.NewListRNode()))
+		}
+		references := fieldValue(imageVerification, "imageReferences")
+		references.YNode().Content = append(references.YNode().Content, image.YNode())
+		_ = imageVerification.PipeE(yaml.Clear("image"))
+		fixes = append(fixes, fmt.Sprintf("%s.image moved to %s.imageReferences", path, path))
+	}
+	key := fieldValue(imageVerification, "key")
+	issuer := fieldValue(imageVerification, "issuer")
+	annotations := fieldValue(imageVerification, "annotations")
+	if key == nil && issuer == nil && annotations == nil {
+		return fixes
+	}
+	attestor := yaml.NewMapRNode(nil)
+	if key != nil {
+		keys := yaml.NewMapRNode(nil)
+		moveField(imageVerification, "key", keys, "publicKeys")
+		_ = attestor.PipeE(yaml.SetField("keys", keys))
+		fixes = append(fixes, fmt.Sprintf("%s.key moved to an attestor", path))
+	} else if issuer != nil {
+		keyless := yaml.NewMapRNode(nil)
+		for _, name := range []string{"issuer", "subject", "roots", "additionalExtensions"} {
+			moveField(imageVerification, name, keyless, name)
+		}
+		if fieldValue(keyless, "roots") == nil {
+			rekor := yaml.NewMapRNode(&map[string]string{"url": "https://rekor.sigstore.dev"})
+			_ = keyless.PipeE(yaml.SetField("rekor", rekor))
+		}
+		_ = attestor.PipeE(yaml.SetField("keyless", keyless))
+		fixes = append(fixes, fmt.Sprintf("%s.issuer, subject and roots moved to a keyless attestor", path))
+	}
+	if annotations != nil {
+		moveField(imageVerification, "annotations", attestor, "annotations")
+		fixes = append(fixes, fmt.Sprintf("%s.annotations moved to an attestor", path))
+	}
+	// the remaining keyless fields are ignored when a key is set or there is no issuer
+	for _, name := range []string{"issuer", "subject", "roots"} {
+		if fieldValue(imageVerification, name) != nil {
+			_ = imageVerification.PipeE(yaml.Clear(name))
+			fixes = append(fixes, fmt.Sprintf("%s.%s removed, it is ignored", path, name))
+		}
+	}
+	attestorSet := yaml.NewMapRNode(nil)
+	appendElement(attestorSet, "entries", attestor)
+	if attestations := elements(fieldValue(imageVerification, "attestations")); len(attestations) > 0 {
+		for _, attestation := range attestations {
+			appendElement(attestation, "attestors", attestorSet.Copy())
+		}
+	} else {
+		appendElement(imageVerification, "attestors", attestorSet)
+	}
+	return fixes
+}
+
+// fieldValue returns the value of a field of a mapping, nil if the node is not a mapping or has no such field
+func fieldValue(node *yaml.RNode, name string) *yaml.RNode {
+	if node == nil || node.YNode().Kind != yaml.MappingNode {
+		return nil
+	}
+	field := node.Field(name)
+	if field == nil || field.Value.IsNil() {
+		return nil
+	}
+	return field.Value
+}
+
+// elements returns the elements of a sequence, nil if the node is not a sequence
+func elements(node *yaml.RNode) []*yaml.RNode {
+	if node == nil || node.YNode().Kind != yaml.SequenceNode {
+		return nil
+	}
+	var elements []*yaml.RNode
+	for _, element := range node.YNode().Content {
+		elements = append(elements, yaml.NewRNode(element))
+	}
+	return elements
+}
+
+// moveField moves a field to another mapping, keeping its value node and comments
+func moveField(from *yaml.RNode, name string, to *yaml.RNode, newName string) {
+	field := from.Field(name)
+	if field == nil {
+		return
+	}
+	_ = from.PipeE(yaml.Clear(name))
+	field.Key.YNode().Value = newName
+	to.YNode().Content = append(to.YNode().Content, field.Key.YNode(), field.Value.YNode())
+}
+
+// appendElement appends an element to a sequence field of a mapping, the field is created if needed
+func appendElement(node *yaml.RNode, name string, element *yaml.RNode) {
+	if fieldValue(node, name) == nil {
+		_ = node.PipeE(yaml.SetField(name, yaml.NewListRNode()))
+	}
+	list := fieldValue(node, name)
+	list.YNode().Content = append(list.YNode().Content, element.YNode())
+}
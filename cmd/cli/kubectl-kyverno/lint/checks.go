@@ -0,0 +1,320 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	policyvalidation "github.com/kyverno/kyverno/pkg/policy"
+)
+
+type severity string
+
+const (
+	severityError   severity = "error"
+	severityWarning severity = "warning"
+	severityInfo    severity = "info"
+)
+
+// level orders severities, the higher the more severe
+func (s severity) level() int {
+	switch s {
+	case severityError:
+		return 3
+	case severityWarning:
+		return 2
+	case severityInfo:
+		return 1
+	}
+	return 0
+}
+
+// problem is a problem found by a check in a policy, rule is empty for problems of the whole policy
+type problem struct {
+	rule    string
+	message string
+}
+
+// check is a lint check, new checks are added to the list returned by defaultChecks
+type check struct {
+	ID          string   `json:"id"`
+	Severity    severity `json:"severity"`
+	Description string   `json:"description"`
+	run         func(policy kyvernov1.PolicyInterface) []problem
+}
+
+// policyValidator runs the policy validation of the admission controller, the checks
+// run one policy after the other and the result of the last policy is shared by them
+type policyValidator struct {
+	openApiManager openapi.Manager
+	policy         kyvernov1.PolicyInterface
+	warnings       []string
+	err            error
+}
+
+func (v *policyValidator) validate(policy kyvernov1.PolicyInterface) ([]string, error) {
+	if v.policy != policy {
+		v.policy = policy
+		v.warnings, v.err = policyvalidation.Validate(policy, nil, true, v.openApiManager)
+	}
+	return v.warnings, v.err
+}
+
+// defaultChecks returns the lint checks, in the order they run
+func defaultChecks(openApiManager openapi.Manager) []check {
+	validator := &policyValidator{openApiManager: openApiManager}
+	return []check{
+		{
+			ID:          "invalid-policy",
+			Severity:    severityError,
+			Description: "The policy is rejected by the policy validation of the admission controller.",
+			run:         invalidPolicy(validator),
+		},
+		{
+			ID:          "validation-warning",
+			Severity:    severityWarning,
+			Description: "The policy validation of the admission controller warns about the policy, e.g. it uses deprecated fields.",
+			run:         validationWarnings(validator),
+		},
+		{
+			ID:          "background-user-info",
+			Severity:    severityWarning,
+			Description: "A rule of a policy with background processing enabled uses admission request user information, which is not available when existing resources are processed. Policies rejected by the policy validation are only reported by invalid-policy.",
+			run:         validPolicies(validator, backgroundUserInfo),
+		},
+		{
+			ID:          "enforce-failure-policy-ignore",
+			Severity:    severityWarning,
+			Description: "An enforce policy has failurePolicy set to Ignore, resources are admitted without being validated when the policy cannot be applied.",
+			run:         enforceFailurePolicyIgnore,
+		},
+		{
+			ID:          "wildcard-kinds",
+			Severity:    severityWarning,
+			Description: "A rule matches all kinds, it is applied to every admission request.",
+			run:         wildcardKinds,
+		},
+		{
+			ID:          "foreach-api-call",
+			Severity:    severityWarning,
+			Description: "An apiCall context entry is declared in a foreach, the call is made for every element of the list.",
+			run:         foreachAPICall,
+		},
+		{
+			ID:          "missing-message",
+			Severity:    severityWarning,
+			Description: "A validate rule has no message, users are not told why their resource is rejected.",
+			run:         missingMessage,
+		},
+		{
+			ID:          "unused-context-entry",
+			Severity:    severityInfo,
+			Description: "A context entry is not used by its rule, it is loaded for nothing.",
+			run:         unusedContextEntries,
+		},
+	}
+}
+
+func invalidPolicy(validator *policyValidator) func(policy kyvernov1.PolicyInterface) []problem {
+	return func(policy kyvernov1.PolicyInterface) []problem {
+		if _, err := validator.validate(policy); err != nil {
+			return []problem{{message: err.Error()}}
+		}
+		return nil
+	}
+}
+
+func validationWarnings(validator *policyValidator) func(policy kyvernov1.PolicyInterface) []problem {
+	return func(policy kyvernov1.PolicyInterface) []problem {
+		warnings, _ := validator.validate(policy)
+		var problems []problem
+		for _, warning := range warnings {
+			problems = append(problems, problem{message: warning})
+		}
+		return problems
+	}
+}
+
+// validPolicies runs the check on the policies accepted by the policy validation, the problems
+// of the other policies overlap with the invalid-policy error
+func validPolicies(validator *policyValidator, run func(policy kyvernov1.PolicyInterface) []problem) func(policy kyvernov1.PolicyInterface) []problem {
+	return func(policy kyvernov1.PolicyInterface) []problem {
+		if _, err := validator.validate(policy); err != nil {
+			return nil
+		}
+		return run(policy)
+	}
+}
+
+var regexUserInfoVariables = regexp.MustCompile(`request\.(userInfo|roles|clusterRoles)|serviceAccountName|serviceAccountNamespace`)
+
+func backgroundUserInfo(policy kyvernov1.PolicyInterface) []problem {
+	if !policy.GetSpec().BackgroundProcessingEnabled() {
+		return nil
+	}
+	var problems []problem
+	for _, rule := range policy.GetSpec().Rules {
+		if hasUserInfoFilter(rule.MatchResources) || hasUserInfoFilter(rule.ExcludeResources) {
+			problems = append(problems, problem{rule: rule.Name, message: "match or exclude uses subjects, roles or clusterRoles, set spec.background to false"})
+		}
+		for _, variable := range ruleVariables(rule) {
+			if regexUserInfoVariables.MatchString(variable) {
+				problems = append(problems, problem{rule: rule.Name, message: fmt.Sprintf("variable %s uses admission request user information, set spec.background to false", variable)})
+			}
+		}
+	}
+	return problems
+}
+
+func hasUserInfoFilter(filters kyvernov1.MatchResources) bool {
+	hasUserInfo := func(userInfo kyvernov1.UserInfo) bool {
+		return len(userInfo.Subjects) > 0 || len(userInfo.Roles) > 0 || len(userInfo.ClusterRoles) > 0
+	}
+	if hasUserInfo(filters.UserInfo) {
+		return true
+	}
+	for _, filter := range resourceFilters(filters) {
+		if hasUserInfo(filter.UserInfo) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceFilters returns the any and all resource filters
+func resourceFilters(filters kyvernov1.MatchResources) []kyvernov1.ResourceFilter {
+	var all []kyvernov1.ResourceFilter
+	all = append(all, filters.Any...)
+	return append(all, filters.All...)
+}
+
+func enforceFailurePolicyIgnore(policy kyvernov1.PolicyInterface) []problem {
+	spec := policy.GetSpec()
+	if !spec.ValidationFailureAction.Enforce() || spec.FailurePolicy == nil || *spec.FailurePolicy != kyvernov1.Ignore {
+		return nil
+	}
+	return []problem{{message: "validationFailureAction is Enforce and failurePolicy is Ignore, resources are admitted when the policy fails to be applied"}}
+}
+
+func wildcardKinds(policy kyvernov1.PolicyInterface) []problem {
+	var problems []problem
+	for _, rule := range policy.GetSpec().Rules {
+		filters := rule.MatchResources
+		descriptions := []kyvernov1.ResourceDescription{filters.ResourceDescription}
+		for _, filter := range resourceFilters(filters) {
+			descriptions = append(descriptions, filter.ResourceDescription)
+		}
+		for _, description := range descriptions {
+			for _, kind := range description.Kinds {
+				if kind == "*" || kind == "*/*" {
+					problems = append(problems, problem{rule: rule.Name, message: fmt.Sprintf("kind %s matches all kinds", kind)})
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func foreachAPICall(policy kyvernov1.PolicyInterface) []problem {
+	var problems []problem
+	for _, rule := range policy.GetSpec().Rules {
+		var contexts [][]kyvernov1.ContextEntry
+		for _, foreach := range rule.Validation.ForEachValidation {
+			contexts = append(contexts, foreach.Context)
+		}
+		for _, foreach := range rule.Mutation.ForEachMutation {
+			contexts = append(contexts, foreach.Context)
+		}
+		for _, context := range contexts {
+			for _, entry := range context {
+				if entry.APICall != nil {
+					problems = append(problems, problem{rule: rule.Name, message: fmt.Sprintf("apiCall context entry %s is called for every foreach element, declare it in the rule context if it does not depend on the element", entry.Name)})
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func missingMessage(policy kyvernov1.PolicyInterface) []problem {
+	var problems []problem
+	for _, rule := range policy.GetSpec().Rules {
+		if rule.HasValidate() && rule.Validation.Message == "" {
+			problems = append(problems, problem{rule: rule.Name, message: "the validate rule has no message"})
+		}
+	}
+	return problems
+}
+
+func unusedContextEntries(policy kyvernov1.PolicyInterface) []problem {
+	var problems []problem
+	for _, rule := range policy.GetSpec().Rules {
+		var entries []kyvernov1.ContextEntry
+		entries = append(entries, rule.Context...)
+		for _, foreach := range rule.Validation.ForEachValidation {
+			entries = append(entries, foreach.Context...)
+		}
+		for _, foreach := range rule.Mutation.ForEachMutation {
+			entries = append(entries, foreach.Context...)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		expressions := append(ruleVariables(rule), ruleJMESPaths(rule)...)
+		for _, entry := range entries {
+			if !isReferenced(entry.Name, expressions) {
+				problems = append(problems, problem{rule: rule.Name, message: fmt.Sprintf("context entry %s is not used", entry.Name)})
+			}
+		}
+	}
+	return problems
+}
+
+// ruleVariables returns the variables of a rule, e.g. {{ request.object.metadata.name }}
+func ruleVariables(rule kyvernov1.Rule) []string {
+	raw, err := json.Marshal(rule)
+	if err != nil {
+		return nil
+	}
+	var vars []string
+	for _, match := range variables.RegexVariables.FindAllStringSubmatch(string(raw), -1) {
+		vars = append(vars, match[1])
+	}
+	return vars
+}
+
+// ruleJMESPaths returns the JMESPath expressions of a rule evaluated against the context without braces
+func ruleJMESPaths(rule kyvernov1.Rule) []string {
+	var expressions []string
+	addEntries := func(entries []kyvernov1.ContextEntry) {
+		for _, entry := range entries {
+			if entry.Variable != nil {
+				expressions = append(expressions, entry.Variable.JMESPath)
+			}
+		}
+	}
+	addEntries(rule.Context)
+	for _, foreach := range rule.Validation.ForEachValidation {
+		expressions = append(expressions, foreach.List)
+		addEntries(foreach.Context)
+	}
+	for _, foreach := range rule.Mutation.ForEachMutation {
+		expressions = append(expressions, foreach.List)
+		addEntries(foreach.Context)
+	}
+	return expressions
+}
+
+// isReferenced returns true when an expression refers to the name, as a whole identifier
+func isReferenced(name string, expressions []string) bool {
+	regex := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(name) + `(\W|$)`)
+	for _, expression := range expressions {
+		if regex.MatchString(expression) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+var riskyPolicy = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: risky
+spec:
+  validationFailureAction: Enforce
+  failurePolicy: Ignore
+  rules:
+  - name: check-user
+    match:
+      any:
+      - resources:
+          kinds:
+          - "*"
+        clusterRoles:
+        - cluster-admin
+    context:
+    - name: unused
+      configMap:
+        name: config
+        namespace: default
+    - name: team
+      variable:
+        value: platform
+    - name: owner
+      variable:
+        jmesPath: team
+    validate:
+      deny:
+        conditions:
+          any:
+          - key: "{{ request.userInfo.username }}"
+            operator: Equals
+            value: "{{ owner }}"
+  - name: check-images
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: "images must exist"
+      foreach:
+      - list: "request.object.spec.containers"
+        context:
+        - name: image
+          apiCall:
+            urlPath: "/api/v1/namespaces/{{ element.name }}"
+        deny:
+          conditions:
+            any:
+            - key: "{{ image }}"
+              operator: Equals
+              value: ""
+`)
+
+func loadPolicy(t *testing.T, content []byte) kyvernov1.PolicyInterface {
+	policies, err := yamlutils.GetPolicy(content)
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	return policies[0]
+}
+
+func Test_checks(t *testing.T) {
+	policy := loadPolicy(t, riskyPolicy)
+	testCases := []struct {
+		name     string
+		run      func(policy kyvernov1.PolicyInterface) []problem
+		problems []problem
+	}{{
+		name: "background-user-info",
+		run:  backgroundUserInfo,
+		problems: []problem{
+			{rule: "check-user", message: "match or exclude uses subjects, roles or clusterRoles, set spec.background to false"},
+			{rule: "check-user", message: "variable {{ request.userInfo.username }} uses admission request user information, set spec.background to false"},
+		},
+	}, {
+		name:     "enforce-failure-policy-ignore",
+		run:      enforceFailurePolicyIgnore,
+		problems: []problem{{message: "validationFailureAction is Enforce and failurePolicy is Ignore, resources are admitted when the policy fails to be applied"}},
+	}, {
+		name:     "wildcard-kinds",
+		run:      wildcardKinds,
+		problems: []problem{{rule: "check-user", message: "kind * matches all kinds"}},
+	}, {
+		name:     "foreach-api-call",
+		run:      foreachAPICall,
+		problems: []problem{{rule: "check-images", message: "apiCall context entry image is called for every foreach element, declare it in the rule context if it does not depend on the element"}},
+	}, {
+		name:     "missing-message",
+		run:      missingMessage,
+		problems: []problem{{rule: "check-user", message: "the validate rule has no message"}},
+	}, {
+		name:     "unused-context-entry",
+		run:      unusedContextEntries,
+		problems: []problem{{rule: "check-user", message: "context entry unused is not used"}},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := tc.run(policy)
+			assert.Assert(t, reflect.DeepEqual(problems, tc.problems), "%v", problems)
+		})
+	}
+}
+
+func Test_checksNoProblems(t *testing.T) {
+	policy := loadPolicy(t, []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: safe
+spec:
+  validationFailureAction: Enforce
+  background: false
+  rules:
+  - name: check-user
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+        clusterRoles:
+        - cluster-admin
+    validate:
+      message: "{{ request.userInfo.username }} cannot create pods"
+      deny: {}
+`))
+	for _, run := range []func(policy kyvernov1.PolicyInterface) []problem{
+		backgroundUserInfo, enforceFailurePolicyIgnore, wildcardKinds, foreachAPICall, missingMessage, unusedContextEntries,
+	} {
+		assert.Equal(t, len(run(policy)), 0)
+	}
+}
+
+func Test_isReferenced(t *testing.T) {
+	assert.Assert(t, isReferenced("team", []string{"{{ team }}"}))
+	assert.Assert(t, isReferenced("team", []string{"{{ team.name }}"}))
+	assert.Assert(t, isReferenced("team", []string{"to_upper(team)"}))
+	assert.Assert(t, !isReferenced("team", []string{"{{ teams }}"}))
+	assert.Assert(t, !isReferenced("team", []string{"{{ request.object.team }}"}))
+}
+
+func Test_defaultChecksInvalidPolicy(t *testing.T) {
+	openApiManager, err := openapi.NewManager()
+	assert.NilError(t, err)
+	policy := loadPolicy(t, riskyPolicy)
+	checks := map[string]int{}
+	for _, finding := range lintPolicy(defaultChecks(openApiManager), "policies.yaml", policy) {
+		checks[finding.Check]++
+	}
+	// user information in background mode is rejected by the policy validation
+	assert.Equal(t, checks["invalid-policy"], 1)
+	assert.Equal(t, checks["background-user-info"], 0)
+	assert.Equal(t, checks["wildcard-kinds"], 1)
+}
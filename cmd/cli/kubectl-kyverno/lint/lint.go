@@ -0,0 +1,281 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+var longHelp = `
+The lint command checks policies for problems the policy validation does not catch, policies that are
+valid but risky or inefficient. Each finding has the ID of the check that reported it and a severity,
+error, warning or info. The command exits with an error when there are findings at or above the
+--fail-on severity, use --list-checks to print the checks and --disable to turn checks off.
+`
+
+// finding is a problem reported by a check
+type finding struct {
+	File     string   `json:"file"`
+	Kind     string   `json:"kind,omitempty"`
+	Policy   string   `json:"policy,omitempty"`
+	Rule     string   `json:"rule,omitempty"`
+	Check    string   `json:"check"`
+	Severity severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (f finding) String() string {
+	location := f.File
+	if f.Policy != "" {
+		location += ": " + f.Kind + " " + f.Policy
+	}
+	if f.Rule != "" {
+		location += ": rule " + f.Rule
+	}
+	return fmt.Sprintf("%s: %s %s: %s", location, f.Severity, f.Check, f.Message)
+}
+
+type summary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+}
+
+type jsonOutput struct {
+	Summary  summary   `json:"summary"`
+	Findings []finding `json:"findings"`
+}
+
+// Command returns the lint command
+func Command() *cobra.Command {
+	var outputFormat, failOn string
+	var disabled []string
+	var listChecks bool
+	cmd := &cobra.Command{
+		Use:   "lint <path>...",
+		Short: "Checks policies for risky or inefficient declarations",
+		Long:  longHelp,
+		Example: `# Lint the policies of a directory
+kyverno lint policies/
+
+# Gate a pull request on warnings, with a machine readable output
+kyverno lint policies/ --fail-on warning --output json
+
+# Lint without the unused context entries check
+kyverno lint policies/ --disable unused-context-entry`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+				return fmt.Errorf("invalid output format %s, supported formats are %s, %s", outputFormat, outputFormatText, outputFormatJSON)
+			}
+			threshold := severity(failOn)
+			if failOn != "none" && threshold.level() == 0 {
+				return fmt.Errorf("invalid severity %s, supported values are error, warning, info and none", failOn)
+			}
+			openApiManager, err := openapi.NewManager()
+			if err != nil {
+				return fmt.Errorf("failed to initialize openAPIController: %v", err)
+			}
+			checks, err := enabledChecks(defaultChecks(openApiManager), disabled)
+			if err != nil {
+				return err
+			}
+			if listChecks {
+				return printChecks(cmd.OutOrStdout(), checks, outputFormat)
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("at least one path is required")
+			}
+			cmd.SilenceUsage = true
+			findings, err := lintPaths(checks, args)
+			if err != nil {
+				return err
+			}
+			if err := printFindings(cmd.OutOrStdout(), findings, outputFormat); err != nil {
+				return err
+			}
+			for _, finding := range findings {
+				if finding.Severity.level() >= threshold.level() && threshold.level() > 0 {
+					os.Exit(1)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", outputFormatText, "output format, text or json")
+	cmd.Flags().StringVar(&failOn, "fail-on", string(severityError), "exit with an error when there are findings with this severity or above, error, warning, info or none")
+	cmd.Flags().StringSliceVar(&disabled, "disable", nil, "IDs of the checks to disable")
+	cmd.Flags().BoolVar(&listChecks, "list-checks", false, "print the checks and exit")
+	return cmd
+}
+
+// enabledChecks removes the disabled checks, unknown check IDs are an error
+func enabledChecks(checks []check, disabled []string) ([]check, error) {
+	ids := map[string]bool{}
+	for _, check := range checks {
+		ids[check.ID] = true
+	}
+	skip := map[string]bool{}
+	for _, id := range disabled {
+		if !ids[id] {
+			return nil, fmt.Errorf("unknown check %s", id)
+		}
+		skip[id] = true
+	}
+	var enabled []check
+	for _, check := range checks {
+		if !skip[check.ID] {
+			enabled = append(enabled, check)
+		}
+	}
+	return enabled, nil
+}
+
+// lintPaths lints the policies of the YAML files of the paths, directories are searched recursively
+// and documents that are not policies are ignored
+func lintPaths(checks []check, paths []string) ([]finding, error) {
+	var findings []finding
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			// files given explicitly are always linted
+			if ext := filepath.Ext(file); file != path && ext != ".yaml" && ext != ".yml" {
+				return nil
+			}
+			content, err := os.ReadFile(filepath.Clean(file))
+			if err != nil {
+				return err
+			}
+			findings = append(findings, lintFile(checks, file, content)...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return findings, nil
+}
+
+// lintFile lints the policies of a file, policies that cannot be decoded are reported as invalid
+func lintFile(checks []check, file string, content []byte) []finding {
+	invalid := func(err error) finding {
+		return finding{File: file, Check: "invalid-policy", Severity: severityError, Message: err.Error()}
+	}
+	documents, err := yamlutils.SplitDocuments(content)
+	if err != nil {
+		return []finding{invalid(err)}
+	}
+	var findings []finding
+	for _, document := range documents {
+		var object unstructured.Unstructured
+		if err := yaml.Unmarshal(document, &object.Object); err != nil || object.Object == nil {
+			continue
+		}
+		if kind := object.GetKind(); kind != "ClusterPolicy" && kind != "Policy" {
+			continue
+		}
+		policies, err := yamlutils.GetPolicy(document)
+		if err != nil {
+			findings = append(findings, invalid(err))
+			continue
+		}
+		for _, policy := range policies {
+			findings = append(findings, lintPolicy(checks, file, policy)...)
+		}
+	}
+	return findings
+}
+
+// lintPolicy runs the checks against a policy
+func lintPolicy(checks []check, file string, policy kyvernov1.PolicyInterface) []finding {
+	name := policy.GetName()
+	if policy.IsNamespaced() {
+		name = policy.GetNamespace() + "/" + name
+	}
+	var findings []finding
+	for _, check := range checks {
+		for _, problem := range check.run(policy) {
+			findings = append(findings, finding{
+				File:     file,
+				Kind:     policy.GetKind(),
+				Policy:   name,
+				Rule:     problem.rule,
+				Check:    check.ID,
+				Severity: check.Severity,
+				Message:  problem.message,
+			})
+		}
+	}
+	return findings
+}
+
+func summarize(findings []finding) summary {
+	var s summary
+	for _, finding := range findings {
+		switch finding.Severity {
+		case severityError:
+			s.Errors++
+		case severityWarning:
+			s.Warnings++
+		case severityInfo:
+			s.Infos++
+		}
+	}
+	return s
+}
+
+func printFindings(out io.Writer, findings []finding, format string) error {
+	s := summarize(findings)
+	if format == outputFormatJSON {
+		output := jsonOutput{Summary: s, Findings: findings}
+		if output.Findings == nil {
+			output.Findings = []finding{}
+		}
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	for _, finding := range findings {
+		fmt.Fprintln(out, finding)
+	}
+	fmt.Fprintf(out, "\n%d findings: %d errors, %d warnings, %d infos\n", len(findings), s.Errors, s.Warnings, s.Infos)
+	return nil
+}
+
+func printChecks(out io.Writer, checks []check, format string) error {
+	if format == outputFormatJSON {
+		data, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	for _, check := range checks {
+		fmt.Fprintf(out, "%s (%s)\n    %s\n", check.ID, check.Severity, strings.TrimSpace(check.Description))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var testChecks = []check{{
+	ID:       "missing-message",
+	Severity: severityWarning,
+	run:      missingMessage,
+}, {
+	ID:       "wildcard-kinds",
+	Severity: severityWarning,
+	run:      wildcardKinds,
+}}
+
+func Test_lintFile(t *testing.T) {
+	content := []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-policy
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: no-message
+  namespace: team-a
+spec:
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      pattern:
+        metadata:
+          name: "?*"
+---
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: invalid
+spec:
+  rules: invalid
+`)
+	findings := lintFile(testChecks, "policies.yaml", content)
+	assert.Equal(t, len(findings), 2)
+	assert.DeepEqual(t, findings[0], finding{
+		File:     "policies.yaml",
+		Kind:     "Policy",
+		Policy:   "team-a/no-message",
+		Rule:     "check",
+		Check:    "missing-message",
+		Severity: severityWarning,
+		Message:  "the validate rule has no message",
+	})
+	assert.Equal(t, findings[0].String(), "policies.yaml: Policy team-a/no-message: rule check: warning missing-message: the validate rule has no message")
+	assert.Equal(t, findings[1].Check, "invalid-policy")
+	assert.Equal(t, findings[1].Severity, severityError)
+}
+
+func Test_enabledChecks(t *testing.T) {
+	checks, err := enabledChecks(testChecks, []string{"missing-message"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(checks), 1)
+	assert.Equal(t, checks[0].ID, "wildcard-kinds")
+	_, err = enabledChecks(testChecks, []string{"unknown"})
+	assert.Error(t, err, "unknown check unknown")
+}
+
+func Test_printFindings(t *testing.T) {
+	findings := []finding{
+		{File: "a.yaml", Kind: "ClusterPolicy", Policy: "a", Check: "invalid-policy", Severity: severityError, Message: "invalid"},
+		{File: "a.yaml", Kind: "ClusterPolicy", Policy: "a", Rule: "r", Check: "missing-message", Severity: severityWarning, Message: "no message"},
+	}
+	var out bytes.Buffer
+	assert.NilError(t, printFindings(&out, findings, outputFormatText))
+	assert.Equal(t, out.String(), `a.yaml: ClusterPolicy a: error invalid-policy: invalid
+a.yaml: ClusterPolicy a: rule r: warning missing-message: no message
+
+2 findings: 1 errors, 1 warnings, 0 infos
+`)
+	out.Reset()
+	assert.NilError(t, printFindings(&out, findings, outputFormatJSON))
+	var output jsonOutput
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.DeepEqual(t, output, jsonOutput{Summary: summary{Errors: 1, Warnings: 1}, Findings: findings})
+}
@@ -3,6 +3,8 @@ package api
 import (
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Test struct {
@@ -12,6 +14,69 @@ type Test struct {
 	Variables string        `json:"variables"`
 	UserInfo  string        `json:"userinfo"`
 	Results   []TestResults `json:"results"`
+	// CleanupPolicies are the files of the cleanup policies of the test.
+	// A resource that would be deleted by a cleanup policy has a pass result,
+	// a resource that would not be deleted has a skip result.
+	CleanupPolicies []string `json:"cleanupPolicies,omitempty"`
+	// Clock is the simulated current time the conditions of cleanup policies
+	// are evaluated at, it defaults to the current time.
+	Clock *metav1.Time `json:"clock,omitempty"`
+	// Fixtures provides the external data of context entries, loaded
+	// through the same context loaders as in the cluster.
+	Fixtures *Fixtures `json:"fixtures,omitempty"`
+}
+
+// Fixtures provides the external data of apiCall, configMap, imageRegistry and
+// globalReference context entries. When fixtures of a kind are provided, context
+// entries of that kind are loaded from them instead of the values file.
+type Fixtures struct {
+	// APICalls are the responses of the Kubernetes API server.
+	APICalls []APICallFixture `json:"apiCalls,omitempty"`
+	// Services are the responses of the services called by apiCall context entries.
+	Services []ServiceCallFixture `json:"services,omitempty"`
+	// GlobalContextEntries are the data of the global context entries referenced by
+	// globalReference context entries.
+	GlobalContextEntries []GlobalContextEntryFixture `json:"globalContextEntries,omitempty"`
+	// ConfigMaps are the config maps referenced by configMap context entries.
+	ConfigMaps []corev1.ConfigMap `json:"configMaps,omitempty"`
+	// Images are the metadata of the images referenced by imageRegistry context entries.
+	Images []ImageFixture `json:"images,omitempty"`
+}
+
+// APICallFixture is the raw response of the Kubernetes API server for an url path.
+type APICallFixture struct {
+	// URLPath is the url path of the call, after variable substitution.
+	URLPath string `json:"urlPath"`
+	// Response is the raw JSON response, the jmesPath of the context entry is applied to it.
+	Response apiextv1.JSON `json:"response"`
+}
+
+// ServiceCallFixture is the response of a service for an url.
+type ServiceCallFixture struct {
+	// URL is the url of the service call, after variable substitution.
+	URL string `json:"url"`
+	// Response is the raw JSON response, the jmesPath of the context entry is applied to it.
+	Response apiextv1.JSON `json:"response"`
+}
+
+// GlobalContextEntryFixture is the data of a global context entry.
+type GlobalContextEntryFixture struct {
+	// Name is the name of the global context entry.
+	Name string `json:"name"`
+	// Data is the data of the entry, the jmesPath of the referencing context entry is applied to it.
+	Data apiextv1.JSON `json:"data"`
+}
+
+// ImageFixture is the registry metadata of an image.
+type ImageFixture struct {
+	// Reference is the image reference, after variable substitution.
+	Reference string `json:"reference"`
+	// Digest is the digest the reference resolves to (OPTIONAL).
+	Digest string `json:"digest,omitempty"`
+	// Manifest is the image manifest.
+	Manifest *apiextv1.JSON `json:"manifest,omitempty"`
+	// ConfigData is the image config file.
+	ConfigData *apiextv1.JSON `json:"configData,omitempty"`
 }
 
 type TestResults struct {
@@ -36,17 +101,39 @@ type TestResults struct {
 	// PatchedResource takes a resource configuration file in yaml format from
 	// the user to compare it against the Kyverno mutated resource configuration.
 	PatchedResource string `json:"patchedResource"`
+	// PatchedResourceAssertions are partial assertions on the Kyverno mutated
+	// resource, checked in addition to or instead of PatchedResource.
+	PatchedResourceAssertions *ResourceAssertions `json:"patchedResourceAssertions,omitempty"`
 	// AutoGeneratedRule is internally set by the CLI command. It takes values either
 	// autogen or autogen-cronjob.
 	AutoGeneratedRule string `json:"auto_generated_rule"`
 	// GeneratedResource takes a resource configuration file in yaml format from
 	// the user to compare it against the Kyverno generated resource configuration.
 	GeneratedResource string `json:"generatedResource"`
+	// GeneratedResourceAssertions are partial assertions on the Kyverno generated
+	// resource, checked in addition to or instead of GeneratedResource.
+	GeneratedResourceAssertions *ResourceAssertions `json:"generatedResourceAssertions,omitempty"`
 	// CloneSourceResource takes the resource configuration file in yaml format
 	// from the user which is meant to be cloned by the generate rule.
 	CloneSourceResource string `json:"cloneSourceResource"`
 }
 
+// ResourceAssertions are partial assertions on a resource, all of them must hold.
+type ResourceAssertions struct {
+	// JMESPath are JMESPath expressions evaluated against the resource, with their expected value.
+	JMESPath []JMESPathAssertion `json:"jmesPath,omitempty"`
+	// Pattern is a validation pattern the resource must match, anchors are supported.
+	Pattern *apiextv1.JSON `json:"pattern,omitempty"`
+}
+
+// JMESPathAssertion is a JMESPath expression and its expected value.
+type JMESPathAssertion struct {
+	// Expression is the JMESPath expression, Kyverno JMESPath functions are supported.
+	Expression string `json:"expression"`
+	// Value is the expected result of the expression.
+	Value apiextv1.JSON `json:"value"`
+}
+
 type ReportResult struct {
 	TestResults
 	Resources []*corev1.ObjectReference `json:"resources"`
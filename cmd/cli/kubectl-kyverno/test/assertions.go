@@ -0,0 +1,98 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/validate"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+// resourceExpectation is what a test expects of a mutated or generated resource,
+// a complete resource file, partial assertions, or both
+type resourceExpectation struct {
+	path       string
+	assertions *api.ResourceAssertions
+}
+
+// checkAssertions checks the assertions against a resource, the error describes every failed assertion
+func checkAssertions(assertions *api.ResourceAssertions, resource unstructured.Unstructured) error {
+	var failures []string
+	for _, assertion := range assertions.JMESPath {
+		if err := checkJMESPathAssertion(assertion, resource.UnstructuredContent()); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if assertions.Pattern != nil {
+		var pattern interface{}
+		if err := json.Unmarshal(assertions.Pattern.Raw, &pattern); err != nil {
+			failures = append(failures, fmt.Sprintf("failed to decode pattern: %v", err))
+		} else if err := validate.MatchPattern(log.Log, resource.UnstructuredContent(), pattern); err != nil {
+			failures = append(failures, fmt.Sprintf("pattern does not match: %v", err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+func checkJMESPathAssertion(assertion api.JMESPathAssertion, resource map[string]interface{}) error {
+	jp, err := jmespath.New(assertion.Expression)
+	if err != nil {
+		return fmt.Errorf("invalid JMESPath expression `%s`: %v", assertion.Expression, err)
+	}
+	result, err := jp.Search(resource)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate JMESPath expression `%s`: %v", assertion.Expression, err)
+	}
+	var expected interface{}
+	if len(assertion.Value.Raw) > 0 {
+		if err := json.Unmarshal(assertion.Value.Raw, &expected); err != nil {
+			return fmt.Errorf("failed to decode the expected value of `%s`: %v", assertion.Expression, err)
+		}
+	}
+	// normalize the result the same way as the expected value, e.g. numbers to float64
+	var actual interface{}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode the result of `%s`: %v", assertion.Expression, err)
+	}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		return fmt.Errorf("failed to decode the result of `%s`: %v", assertion.Expression, err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		return fmt.Errorf("`%s` is %s, expected %s", assertion.Expression, data, assertion.Value.Raw)
+	}
+	return nil
+}
+
+// resourceDiff returns a unified diff of the YAML representations of the expected and actual resources
+func resourceDiff(expected, actual unstructured.Unstructured) string {
+	expectedYAML, err := yaml.Marshal(expected.UnstructuredContent())
+	if err != nil {
+		return ""
+	}
+	actualYAML, err := yaml.Marshal(actual.UnstructuredContent())
+	if err != nil {
+		return ""
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expectedYAML)),
+		B:        difflib.SplitLines(string(actualYAML)),
+		FromFile: "expected",
+		ToFile:   "actual",
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func assertionsPod() unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "nginx",
+			"namespace": "default",
+			"labels":    map[string]interface{}{"team": "platform"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx:1.23", "imagePullPolicy": "IfNotPresent"},
+				map[string]interface{}{"name": "sidecar", "image": "busybox:1.36"},
+			},
+		},
+	}}
+}
+
+func jmesPathAssertion(expression, value string) api.JMESPathAssertion {
+	return api.JMESPathAssertion{Expression: expression, Value: apiextv1.JSON{Raw: []byte(value)}}
+}
+
+func Test_checkAssertions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		assertions api.ResourceAssertions
+		errors     []string
+	}{{
+		name: "jmesPath",
+		assertions: api.ResourceAssertions{JMESPath: []api.JMESPathAssertion{
+			jmesPathAssertion("metadata.labels.team", `"platform"`),
+			jmesPathAssertion("length(spec.containers)", `2`),
+			jmesPathAssertion("spec.containers[].name", `["nginx","sidecar"]`),
+		}},
+	}, {
+		name: "jmesPath mismatch",
+		assertions: api.ResourceAssertions{JMESPath: []api.JMESPathAssertion{
+			jmesPathAssertion("metadata.labels.team", `"infra"`),
+			jmesPathAssertion("length(spec.containers)", `3`),
+		}},
+		errors: []string{"`metadata.labels.team` is \"platform\", expected \"infra\"", "`length(spec.containers)` is 2, expected 3"},
+	}, {
+		name: "invalid jmesPath",
+		assertions: api.ResourceAssertions{JMESPath: []api.JMESPathAssertion{
+			jmesPathAssertion("metadata.[", `"platform"`),
+		}},
+		errors: []string{"invalid JMESPath expression `metadata.[`"},
+	}, {
+		name:       "pattern with anchors",
+		assertions: api.ResourceAssertions{Pattern: &apiextv1.JSON{Raw: []byte(`{"spec":{"containers":[{"(name)":"nginx","imagePullPolicy":"IfNotPresent"}]}}`)}},
+	}, {
+		name:       "pattern mismatch",
+		assertions: api.ResourceAssertions{Pattern: &apiextv1.JSON{Raw: []byte(`{"spec":{"containers":[{"imagePullPolicy":"IfNotPresent"}]}}`)}},
+		errors:     []string{"pattern does not match"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkAssertions(&tc.assertions, assertionsPod())
+			if len(tc.errors) == 0 {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Assert(t, err != nil)
+			for _, expected := range tc.errors {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func Test_resourceDiff(t *testing.T) {
+	expected := assertionsPod()
+	expected.SetLabels(map[string]string{"team": "infra"})
+	diff := resourceDiff(expected, assertionsPod())
+	assert.Assert(t, strings.Contains(diff, "--- expected\n+++ actual\n"), diff)
+	assert.Assert(t, strings.Contains(diff, "-    team: infra\n+    team: platform\n"), diff)
+	assert.Equal(t, resourceDiff(assertionsPod(), assertionsPod()), "")
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cleanup-controller/handlers/cleanup"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/engine"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// validCleanupPolicies returns the valid cleanup policies, invalid ones are skipped
+func validCleanupPolicies(policies []kyvernov2alpha1.CleanupPolicyInterface) []kyvernov2alpha1.CleanupPolicyInterface {
+	var valid []kyvernov2alpha1.CleanupPolicyInterface
+	for _, policy := range policies {
+		if errs := policy.Validate(sets.NewString()); len(errs) > 0 {
+			log.Log.Error(errs.ToAggregate(), "skipping invalid cleanup policy", "name", policy.GetName())
+			continue
+		}
+		valid = append(valid, policy)
+	}
+	return valid
+}
+
+// testClock returns the simulated clock of a test, or the actual time when the test has no clock
+func testClock(clock *metav1.Time) jmespath.Clock {
+	if clock == nil {
+		return time.Now
+	}
+	return func() time.Time {
+		return clock.Time
+	}
+}
+
+// findCleanupPolicy returns the cleanup policy a test result refers to, nil if there is none
+func findCleanupPolicy(policies []kyvernov2alpha1.CleanupPolicyInterface, policyName string) kyvernov2alpha1.CleanupPolicyInterface {
+	namespace, name := getUserDefinedPolicyNameAndNamespace(policyName)
+	for _, policy := range policies {
+		if policy.GetName() == name && policy.GetNamespace() == namespace {
+			return policy
+		}
+	}
+	return nil
+}
+
+// findTestResource returns the resource a test result refers to, nil if there is none
+func findTestResource(resources []*unstructured.Unstructured, kind, namespace, name string) *unstructured.Unstructured {
+	for _, resource := range resources {
+		if resource.GetName() == name && resource.GetKind() == kind && (namespace == "" || resource.GetNamespace() == namespace) {
+			return resource
+		}
+	}
+	return nil
+}
+
+// namespaceLabels returns the labels of a namespace, from the namespace selector values
+// first, then from the namespaces in the resource files of the test
+func namespaceLabels(namespace string, resources []*unstructured.Unstructured, namespaceSelectorMap map[string]map[string]string) map[string]string {
+	if namespace == "" {
+		return nil
+	}
+	if labels, ok := namespaceSelectorMap[namespace]; ok {
+		return labels
+	}
+	if ns := findTestResource(resources, "Namespace", "", namespace); ns != nil {
+		return ns.GetLabels()
+	}
+	return nil
+}
+
+// applyCleanupPolicies evaluates the cleanup policies the test results refer to against their resources,
+// at the time of the clock. A resource that would be deleted has a pass result, a resource that would
+// not be deleted has a skip result. Results are added to the results, keyed like the test results.
+func applyCleanupPolicies(policies []kyvernov2alpha1.CleanupPolicyInterface, resources, allResources []*unstructured.Unstructured, testResults []api.TestResults, namespaceSelectorMap map[string]map[string]string, cliStore *store.Store, clock jmespath.Clock, results map[string]policyreportv1alpha2.PolicyReportResult) {
+	now := metav1.Timestamp{Seconds: clock().Unix()}
+	for _, test := range testResults {
+		policy := findCleanupPolicy(policies, test.Policy)
+		if policy == nil {
+			continue
+		}
+		resourceNames := test.Resources
+		if test.Resource != "" {
+			resourceNames = append(resourceNames, test.Resource)
+		}
+		policyNamespace, policyName := getUserDefinedPolicyNameAndNamespace(test.Policy)
+		for _, resourceName := range resourceNames {
+			resource := findTestResource(resources, test.Kind, test.Namespace, resourceName)
+			if resource == nil {
+				continue
+			}
+			result := policyreportv1alpha2.PolicyReportResult{
+				Policy: policyName,
+				Rule:   test.Rule,
+				Resources: []corev1.ObjectReference{{
+					Kind:      resource.GetKind(),
+					Namespace: resource.GetNamespace(),
+					Name:      resource.GetName(),
+				}},
+				Result:    policyreportv1alpha2.StatusSkip,
+				Message:   "resource would not be deleted",
+				Timestamp: now,
+			}
+			policyContext := engine.NewPolicyContextWithJsonContext(enginecontext.NewContextWithClock(clock)).WithCLIStore(cliStore)
+			nsLabels := namespaceLabels(resource.GetNamespace(), allResources, namespaceSelectorMap)
+			matched, err := cleanup.MatchResource(log.Log, cliStore.GetRegistryClient(), policy, *resource, nsLabels, policyContext)
+			if err != nil {
+				result.Result = policyreportv1alpha2.StatusError
+				result.Message = err.Error()
+			} else if matched {
+				result.Result = policyreportv1alpha2.StatusPass
+				result.Message = "resource would be deleted"
+			}
+			// keyed the same way as when printing the test results
+			keyNamespace := policyNamespace
+			if found, _ := isNamespacedPolicy(test.Policy); !found {
+				keyNamespace = ""
+			}
+			key := GetResultKeyAccordingToTestResults(keyNamespace, policyName, test.Rule, test.Namespace, test.Kind, resourceName)
+			results[key] = result
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var cleanupPolicy = []byte(`
+apiVersion: kyverno.io/v2alpha1
+kind: ClusterCleanupPolicy
+metadata:
+  name: cleanup-old-pods
+spec:
+  schedule: "*/10 * * * *"
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+  conditions:
+    all:
+    - key: "{{ time_since('', '{{ target.metadata.creationTimestamp }}', '') }}"
+      operator: DurationGreaterThan
+      value: 24h
+`)
+
+func cleanupPod(name, creationTimestamp string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":              name,
+			"namespace":         "default",
+			"creationTimestamp": creationTimestamp,
+		},
+	}}
+}
+
+func Test_testClock(t *testing.T) {
+	assert.Assert(t, testClock(nil)().After(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+	clock := metav1.NewTime(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, testClock(&clock)(), clock.Time)
+}
+
+func Test_applyCleanupPolicies(t *testing.T) {
+	policies, err := yamlutils.GetCleanupPolicy(cleanupPolicy)
+	assert.NilError(t, err)
+	resources := []*unstructured.Unstructured{
+		cleanupPod("old", "2023-01-01T00:00:00Z"),
+		cleanupPod("recent", "2023-01-09T12:00:00Z"),
+	}
+	testResults := []api.TestResults{{
+		Policy:    "cleanup-old-pods",
+		Kind:      "Pod",
+		Namespace: "default",
+		Resources: []string{"old", "recent", "missing"},
+	}, {
+		Policy:   "unknown-policy",
+		Kind:     "Pod",
+		Resource: "old",
+	}}
+	clock := metav1.NewTime(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC))
+	results := map[string]policyreportv1alpha2.PolicyReportResult{}
+	applyCleanupPolicies(policies, resources, resources, testResults, nil, &store.Store{}, testClock(&clock), results)
+	assert.Equal(t, len(results), 2)
+	old := results[GetResultKeyAccordingToTestResults("", "cleanup-old-pods", "", "default", "Pod", "old")]
+	assert.Equal(t, old.Result, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusPass))
+	assert.Equal(t, old.Message, "resource would be deleted")
+	recent := results[GetResultKeyAccordingToTestResults("", "cleanup-old-pods", "", "default", "Pod", "recent")]
+	assert.Equal(t, recent.Result, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusSkip))
+	assert.Equal(t, recent.Message, "resource would not be deleted")
+}
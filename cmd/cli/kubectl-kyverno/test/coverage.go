@@ -0,0 +1,213 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/pkg/autogen"
+)
+
+const (
+	coverageFormatTable = "table"
+	coverageFormatJSON  = "json"
+)
+
+var coverageFormats = []string{coverageFormatTable, coverageFormatJSON}
+
+// ruleCoverage tells which outcomes of a policy rule are expected by at least one test
+type ruleCoverage struct {
+	Policy string `json:"policy"`
+	Rule   string `json:"rule"`
+	Pass   bool   `json:"pass"`
+	Fail   bool   `json:"fail"`
+	Skip   bool   `json:"skip"`
+}
+
+func (r *ruleCoverage) tested() bool {
+	return r.Pass || r.Fail || r.Skip
+}
+
+// coverage is the coverage of the rules of the loaded policies, in load order
+type coverage struct {
+	rules []*ruleCoverage
+	index map[string]*ruleCoverage
+}
+
+func coverageKey(policy, rule string) string {
+	return policy + "/" + rule
+}
+
+// policyCoverageName returns the name a policy is referred to by in test results
+func policyCoverageName(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+// addPolicy adds the rules of a policy, including the autogen rules
+func (c *coverage) addPolicy(policy kyvernov1.PolicyInterface) {
+	name := policyCoverageName(policy)
+	for _, rule := range autogen.ComputeRules(policy) {
+		c.addRule(&ruleCoverage{Policy: name, Rule: rule.Name})
+	}
+}
+
+func (c *coverage) addRule(rule *ruleCoverage) *ruleCoverage {
+	key := coverageKey(rule.Policy, rule.Rule)
+	if existing, ok := c.index[key]; ok {
+		return existing
+	}
+	if c.index == nil {
+		c.index = map[string]*ruleCoverage{}
+	}
+	c.index[key] = rule
+	c.rules = append(c.rules, rule)
+	return rule
+}
+
+// addResult marks the outcome expected by a test result as covered, results of unknown rules are ignored
+func (c *coverage) addResult(result api.TestResults) {
+	ruleName := result.Rule
+	if result.AutoGeneratedRule != "" {
+		ruleName = result.AutoGeneratedRule + "-" + result.Rule
+	}
+	rule, ok := c.index[coverageKey(result.Policy, ruleName)]
+	if !ok {
+		return
+	}
+	expected := result.Result
+	if expected == "" {
+		expected = result.Status
+	}
+	switch expected {
+	case policyreportv1alpha2.StatusPass:
+		rule.Pass = true
+	case policyreportv1alpha2.StatusFail:
+		rule.Fail = true
+	case policyreportv1alpha2.StatusSkip:
+		rule.Skip = true
+	}
+}
+
+// merge adds the rules of another coverage, outcomes covered in either are covered
+func (c *coverage) merge(other *coverage) {
+	for _, rule := range other.rules {
+		r := *rule
+		existing := c.addRule(&r)
+		existing.Pass = existing.Pass || rule.Pass
+		existing.Fail = existing.Fail || rule.Fail
+		existing.Skip = existing.Skip || rule.Skip
+	}
+}
+
+// percentage returns the percentage of rules with at least one tested outcome
+func (c *coverage) percentage() float64 {
+	if len(c.rules) == 0 {
+		return 100
+	}
+	return float64(c.tested()) * 100 / float64(len(c.rules))
+}
+
+func (c *coverage) tested() int {
+	var tested int
+	for _, rule := range c.rules {
+		if rule.tested() {
+			tested++
+		}
+	}
+	return tested
+}
+
+// validateCoverageFormat checks the coverage format is supported
+func validateCoverageFormat(format string) error {
+	for _, f := range coverageFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid coverage format %s, supported formats are %s", format, strings.Join(coverageFormats, ", "))
+}
+
+type coverageTable struct {
+	ID     int    `header:"#"`
+	Policy string `header:"policy"`
+	Rule   string `header:"rule"`
+	Pass   string `header:"pass"`
+	Fail   string `header:"fail"`
+	Skip   string `header:"skip"`
+}
+
+type coverageSummary struct {
+	Rules      int     `json:"rules"`
+	Tested     int     `json:"tested"`
+	Percentage float64 `json:"percentage"`
+}
+
+type coverageOutput struct {
+	Summary coverageSummary `json:"summary"`
+	Rules   []*ruleCoverage `json:"rules"`
+}
+
+// newCoverageOutput returns the JSON document of the coverage
+func newCoverageOutput(c *coverage) *coverageOutput {
+	output := &coverageOutput{
+		Summary: coverageSummary{
+			Rules:      len(c.rules),
+			Tested:     c.tested(),
+			Percentage: c.percentage(),
+		},
+		Rules: c.rules,
+	}
+	if output.Rules == nil {
+		output.Rules = []*ruleCoverage{}
+	}
+	return output
+}
+
+// validateCoverageOptions checks the coverage can be printed along with the output format,
+// stdout only holds a single machine readable document
+func validateCoverageOptions(options coverageOptions, outputFormat string) error {
+	if options.enabled && options.format == coverageFormatJSON && options.file == "" && outputFormat == outputFormatJUnit {
+		return fmt.Errorf("json coverage can't be printed along with %s output, use --coverage-file", outputFormat)
+	}
+	return nil
+}
+
+// printCoverage prints the coverage in the given format
+func printCoverage(out io.Writer, c *coverage, format string, removeColor bool) error {
+	if format == coverageFormatJSON {
+		data, err := json.MarshalIndent(newCoverageOutput(c), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	outcome := func(tested bool) string {
+		if tested {
+			return colorize(removeColor, boldGreen, "Yes")
+		}
+		return colorize(removeColor, boldRed, "No")
+	}
+	table := []coverageTable{}
+	for i, rule := range c.rules {
+		table = append(table, coverageTable{
+			ID:     i + 1,
+			Policy: colorize(removeColor, boldFgCyan, rule.Policy),
+			Rule:   colorize(removeColor, boldFgCyan, rule.Rule),
+			Pass:   outcome(rule.Pass),
+			Fail:   outcome(rule.Fail),
+			Skip:   outcome(rule.Skip),
+		})
+	}
+	fmt.Fprintf(out, "Policy Rule Coverage : \n")
+	newTablePrinter(out, removeColor).Print(table)
+	fmt.Fprintf(out, "\nCoverage Summary: %d out of %d rules tested (%.2f%%)\n\n", c.tested(), len(c.rules), c.percentage())
+	return nil
+}
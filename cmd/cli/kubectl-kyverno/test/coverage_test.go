@@ -0,0 +1,187 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/junit"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+var coveragePolicy = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: check-labels
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: labels are required
+      pattern:
+        metadata:
+          labels:
+            app: "?*"
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Namespace
+    validate:
+      message: team label is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`)
+
+func newCoverage(t *testing.T) *coverage {
+	policies, err := yamlutils.GetPolicy(coveragePolicy)
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	c := &coverage{}
+	c.addPolicy(policies[0])
+	return c
+}
+
+func Test_coverage(t *testing.T) {
+	c := newCoverage(t)
+	var rules []string
+	for _, rule := range c.rules {
+		rules = append(rules, rule.Rule)
+	}
+	assert.DeepEqual(t, rules, []string{"check-labels", "check-team", "autogen-check-labels", "autogen-cronjob-check-labels"})
+	assert.Equal(t, c.tested(), 0)
+
+	c.addResult(api.TestResults{Policy: "require-labels", Rule: "check-labels", Result: policyreportv1alpha2.StatusPass})
+	c.addResult(api.TestResults{Policy: "require-labels", Rule: "check-labels", AutoGeneratedRule: "autogen", Status: policyreportv1alpha2.StatusFail})
+	c.addResult(api.TestResults{Policy: "require-labels", Rule: "unknown", Result: policyreportv1alpha2.StatusPass})
+	c.addResult(api.TestResults{Policy: "other", Rule: "check-team", Result: policyreportv1alpha2.StatusPass})
+	assert.DeepEqual(t, *c.rules[0], ruleCoverage{Policy: "require-labels", Rule: "check-labels", Pass: true})
+	assert.DeepEqual(t, *c.rules[2], ruleCoverage{Policy: "require-labels", Rule: "autogen-check-labels", Fail: true})
+	assert.Equal(t, c.tested(), 2)
+	assert.Equal(t, c.percentage(), float64(50))
+
+	other := newCoverage(t)
+	other.addResult(api.TestResults{Policy: "require-labels", Rule: "check-labels", Result: policyreportv1alpha2.StatusSkip})
+	other.addResult(api.TestResults{Policy: "require-labels", Rule: "check-team", Result: policyreportv1alpha2.StatusPass})
+	c.merge(other)
+	assert.Equal(t, len(c.rules), 4)
+	assert.DeepEqual(t, *c.rules[0], ruleCoverage{Policy: "require-labels", Rule: "check-labels", Pass: true, Skip: true})
+	assert.Equal(t, c.tested(), 3)
+	assert.Equal(t, c.percentage(), float64(75))
+	// merging does not share rules between coverages
+	assert.Equal(t, other.rules[0].Pass, false)
+
+	assert.Equal(t, (&coverage{}).percentage(), float64(100))
+}
+
+func Test_policyCoverageName(t *testing.T) {
+	policy := &kyvernov1.Policy{}
+	policy.SetName("require-labels")
+	policy.SetNamespace("test")
+	assert.Equal(t, policyCoverageName(policy), "test/require-labels")
+	assert.Equal(t, policyCoverageName(&kyvernov1.ClusterPolicy{}), "")
+}
+
+func Test_printCoverage(t *testing.T) {
+	c := newCoverage(t)
+	c.addResult(api.TestResults{Policy: "require-labels", Rule: "check-team", Result: policyreportv1alpha2.StatusFail})
+
+	var out bytes.Buffer
+	assert.NilError(t, printCoverage(&out, c, coverageFormatJSON, true))
+	var output coverageOutput
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, output.Summary, coverageSummary{Rules: 4, Tested: 1, Percentage: 25})
+	assert.Equal(t, len(output.Rules), 4)
+	assert.Equal(t, output.Rules[1].Fail, true)
+
+	out.Reset()
+	assert.NilError(t, printCoverage(&out, c, coverageFormatTable, true))
+	assert.Assert(t, bytes.Contains(out.Bytes(), []byte("autogen-cronjob-check-labels")))
+	assert.Assert(t, bytes.Contains(out.Bytes(), []byte("Coverage Summary: 1 out of 4 rules tested (25.00%)")))
+}
+
+func Test_validateCoverageFormat(t *testing.T) {
+	assert.NilError(t, validateCoverageFormat("table"))
+	assert.NilError(t, validateCoverageFormat("json"))
+	assert.ErrorContains(t, validateCoverageFormat("junit"), "invalid coverage format junit")
+}
+
+func Test_validateCoverageOptions(t *testing.T) {
+	jsonCoverage := coverageOptions{enabled: true, format: coverageFormatJSON}
+	assert.NilError(t, validateCoverageOptions(jsonCoverage, ""))
+	assert.NilError(t, validateCoverageOptions(jsonCoverage, outputFormatJSON))
+	assert.ErrorContains(t, validateCoverageOptions(jsonCoverage, outputFormatJUnit), "use --coverage-file")
+	jsonCoverage.file = "coverage.json"
+	assert.NilError(t, validateCoverageOptions(jsonCoverage, outputFormatJUnit))
+	assert.NilError(t, validateCoverageOptions(coverageOptions{enabled: true, format: coverageFormatTable}, outputFormatJUnit))
+}
+
+func Test_printResults(t *testing.T) {
+	c := newCoverage(t)
+	c.addResult(api.TestResults{Policy: "require-labels", Rule: "check-team", Result: policyreportv1alpha2.StatusFail})
+	testCases := recordTestCaseResults()
+
+	t.Run("json output with json coverage", func(t *testing.T) {
+		var stdout, out bytes.Buffer
+		_, err := printResults(&stdout, &out, outputFormatJSON, coverageOptions{enabled: true, format: coverageFormatJSON}, testCases, c, true)
+		assert.NilError(t, err)
+		var output jsonOutput
+		assert.NilError(t, json.Unmarshal(stdout.Bytes(), &output))
+		assert.Equal(t, output.Summary, jsonSummary{Tests: 3, Passed: 1, Failed: 2})
+		assert.Assert(t, output.Coverage != nil)
+		assert.Equal(t, output.Coverage.Summary, coverageSummary{Rules: 4, Tested: 1, Percentage: 25})
+	})
+
+	t.Run("json coverage", func(t *testing.T) {
+		var stdout, out bytes.Buffer
+		below, err := printResults(&stdout, &out, "", coverageOptions{enabled: true, format: coverageFormatJSON, threshold: 50}, testCases, c, true)
+		assert.NilError(t, err)
+		assert.Equal(t, below, true)
+		var output coverageOutput
+		assert.NilError(t, json.Unmarshal(stdout.Bytes(), &output))
+		assert.Equal(t, output.Summary.Tested, 1)
+		assert.Assert(t, bytes.Contains(out.Bytes(), []byte("below the threshold")))
+	})
+
+	t.Run("json output with table coverage", func(t *testing.T) {
+		var stdout, out bytes.Buffer
+		_, err := printResults(&stdout, &out, outputFormatJSON, coverageOptions{enabled: true, format: coverageFormatTable}, testCases, c, true)
+		assert.NilError(t, err)
+		var output jsonOutput
+		assert.NilError(t, json.Unmarshal(stdout.Bytes(), &output))
+		assert.Assert(t, output.Coverage == nil)
+		assert.Assert(t, bytes.Contains(out.Bytes(), []byte("Coverage Summary: 1 out of 4 rules tested (25.00%)")))
+	})
+
+	t.Run("junit output with coverage file", func(t *testing.T) {
+		var stdout, out bytes.Buffer
+		file := filepath.Join(t.TempDir(), "coverage.json")
+		_, err := printResults(&stdout, &out, outputFormatJUnit, coverageOptions{enabled: true, format: coverageFormatJSON, file: file}, testCases, c, true)
+		assert.NilError(t, err)
+		var report junit.TestSuites
+		assert.NilError(t, xml.Unmarshal(stdout.Bytes(), &report))
+		assert.Equal(t, report.Tests, 3)
+		data, err := os.ReadFile(file)
+		assert.NilError(t, err)
+		var output coverageOutput
+		assert.NilError(t, json.Unmarshal(data, &output))
+		assert.Equal(t, len(output.Rules), 4)
+		assert.Equal(t, out.Len(), 0)
+	})
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// buildFixtures converts the fixtures of a test into the fixtures served by the store
+func buildFixtures(fixtures *api.Fixtures) (store.Fixtures, error) {
+	var f store.Fixtures
+	if fixtures == nil {
+		return f, nil
+	}
+	if len(fixtures.APICalls) > 0 {
+		f.APICalls = map[string][]byte{}
+		for _, call := range fixtures.APICalls {
+			if call.URLPath == "" {
+				return f, fmt.Errorf("an urlPath is required for API call fixtures")
+			}
+			f.APICalls[call.URLPath] = call.Response.Raw
+		}
+	}
+	if len(fixtures.Services) > 0 {
+		f.ServiceCalls = map[string][]byte{}
+		for _, call := range fixtures.Services {
+			if call.URL == "" {
+				return f, fmt.Errorf("an url is required for service fixtures")
+			}
+			f.ServiceCalls[call.URL] = call.Response.Raw
+		}
+	}
+	if len(fixtures.GlobalContextEntries) > 0 {
+		f.GlobalContextEntries = map[string][]byte{}
+		for _, entry := range fixtures.GlobalContextEntries {
+			if entry.Name == "" {
+				return f, fmt.Errorf("a name is required for global context entry fixtures")
+			}
+			f.GlobalContextEntries[entry.Name] = entry.Data.Raw
+		}
+	}
+	if len(fixtures.ConfigMaps) > 0 {
+		f.ConfigMaps = map[string]*corev1.ConfigMap{}
+		for i := range fixtures.ConfigMaps {
+			cm := &fixtures.ConfigMaps[i]
+			namespace := cm.Namespace
+			if namespace == "" {
+				namespace = "default"
+			}
+			f.ConfigMaps[namespace+"/"+cm.Name] = cm
+		}
+	}
+	if len(fixtures.Images) > 0 {
+		f.Images = map[string]interface{}{}
+		for _, image := range fixtures.Images {
+			data, err := buildImageData(image)
+			if err != nil {
+				return f, err
+			}
+			f.Images[image.Reference] = data
+		}
+	}
+	return f, nil
+}
+
+// buildImageData returns the image data the same way the imageRegistry context loader does
+func buildImageData(image api.ImageFixture) (interface{}, error) {
+	ref, err := name.ParseReference(image.Reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image reference %s in fixtures: %v", image.Reference, err)
+	}
+	resolvedImage := ref.Name()
+	if image.Digest != "" {
+		resolvedImage = fmt.Sprintf("%s@%s", ref.Context().Name(), image.Digest)
+	}
+	data := map[string]interface{}{
+		"image":         image.Reference,
+		"resolvedImage": resolvedImage,
+		"registry":      ref.Context().RegistryStr(),
+		"repository":    ref.Context().RepositoryStr(),
+		"identifier":    ref.Identifier(),
+		"manifest":      image.Manifest,
+		"configData":    image.ConfigData,
+	}
+	jsonDoc, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var untyped interface{}
+	if err := json.Unmarshal(jsonDoc, &untyped); err != nil {
+		return nil, err
+	}
+	return untyped, nil
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_buildFixtures(t *testing.T) {
+	fixtures, err := buildFixtures(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(fixtures.APICalls), 0)
+
+	fixtures, err = buildFixtures(&api.Fixtures{
+		APICalls: []api.APICallFixture{{
+			URLPath:  "/api/v1/namespaces",
+			Response: apiextv1.JSON{Raw: []byte(`{"items":[]}`)},
+		}},
+		Services: []api.ServiceCallFixture{{
+			URL:      "https://approvals.kyverno.svc/namespaces/apps",
+			Response: apiextv1.JSON{Raw: []byte(`{"approved":true}`)},
+		}},
+		GlobalContextEntries: []api.GlobalContextEntryFixture{{
+			Name: "frozen-namespaces",
+			Data: apiextv1.JSON{Raw: []byte(`["apps"]`)},
+		}},
+		ConfigMaps: []corev1.ConfigMap{
+			{ObjectMeta: metav1.ObjectMeta{Name: "config"}, Data: map[string]string{"key": "value"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "kyverno"}},
+		},
+		Images: []api.ImageFixture{{
+			Reference:  "ghcr.io/kyverno/test:v1",
+			Digest:     "sha256:4ecf8e3a1e96dd6f8b1a3c7a3b7a9e4e2d6c4b5a5f8e1b6a4c3d2e1f0a9b8c7d",
+			ConfigData: &apiextv1.JSON{Raw: []byte(`{"config":{"User":"1000"}}`)},
+		}},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, string(fixtures.APICalls["/api/v1/namespaces"]), `{"items":[]}`)
+	assert.Equal(t, string(fixtures.ServiceCalls["https://approvals.kyverno.svc/namespaces/apps"]), `{"approved":true}`)
+	assert.Equal(t, string(fixtures.GlobalContextEntries["frozen-namespaces"]), `["apps"]`)
+	assert.Equal(t, fixtures.ConfigMaps["default/config"].Data["key"], "value")
+	assert.Assert(t, fixtures.ConfigMaps["kyverno/config"] != nil)
+
+	image := fixtures.Images["ghcr.io/kyverno/test:v1"].(map[string]interface{})
+	assert.Equal(t, image["registry"], "ghcr.io")
+	assert.Equal(t, image["repository"], "kyverno/test")
+	assert.Equal(t, image["identifier"], "v1")
+	assert.Equal(t, image["resolvedImage"], "ghcr.io/kyverno/test@sha256:4ecf8e3a1e96dd6f8b1a3c7a3b7a9e4e2d6c4b5a5f8e1b6a4c3d2e1f0a9b8c7d")
+	assert.Equal(t, image["configData"].(map[string]interface{})["config"].(map[string]interface{})["User"], "1000")
+	assert.Equal(t, image["manifest"], nil)
+}
+
+func Test_buildFixtures_Invalid(t *testing.T) {
+	_, err := buildFixtures(&api.Fixtures{APICalls: []api.APICallFixture{{}}})
+	assert.ErrorContains(t, err, "an urlPath is required")
+
+	_, err = buildFixtures(&api.Fixtures{Services: []api.ServiceCallFixture{{}}})
+	assert.ErrorContains(t, err, "an url is required")
+
+	_, err = buildFixtures(&api.Fixtures{GlobalContextEntries: []api.GlobalContextEntryFixture{{}}})
+	assert.ErrorContains(t, err, "a name is required")
+
+	_, err = buildFixtures(&api.Fixtures{Images: []api.ImageFixture{{Reference: "INVALID:://"}}})
+	assert.ErrorContains(t, err, "failed to parse image reference")
+}
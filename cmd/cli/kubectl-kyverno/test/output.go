@@ -1,7 +1,7 @@
 package test
 
 import (
-	"os"
+	"io"
 
 	"github.com/fatih/color"
 	"github.com/kataras/tablewriter"
@@ -22,8 +22,8 @@ func colorize(noColor bool, color *color.Color, format string, a ...interface{})
 	return color.Sprintf(format, a...)
 }
 
-func newTablePrinter(noColor bool) *tableprinter.Printer {
-	printer := tableprinter.New(os.Stdout)
+func newTablePrinter(out io.Writer, noColor bool) *tableprinter.Printer {
+	printer := tableprinter.New(out)
 	printer.BorderTop, printer.BorderBottom, printer.BorderLeft, printer.BorderRight = true, true, true, true
 	printer.CenterSeparator = "│"
 	printer.ColumnSeparator = "│"
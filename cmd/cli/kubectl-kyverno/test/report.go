@@ -0,0 +1,182 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/junit"
+)
+
+const (
+	outputFormatJSON  = "json"
+	outputFormatJUnit = "junit"
+)
+
+var outputFormats = []string{outputFormatJSON, outputFormatJUnit}
+
+const (
+	testStatusPass = "pass"
+	testStatusFail = "fail"
+)
+
+// testCaseResult is the outcome of a single expected result of a test
+type testCaseResult struct {
+	Test     string           `json:"test"`
+	Policy   string           `json:"policy"`
+	Rule     string           `json:"rule"`
+	Resource testCaseResource `json:"resource"`
+	Expected string           `json:"expected"`
+	Actual   string           `json:"actual"`
+	Status   string           `json:"status"`
+	Message  string           `json:"message,omitempty"`
+}
+
+type testCaseResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r testCaseResource) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// recordTestCaseResult records the outcome of an expected result, actual is nil when no result was found
+func (r *testReport) recordTestCaseResult(testName, policy string, v api.TestResults, resource string, actual *policyreportv1alpha2.PolicyReportResult) {
+	expected := v.Result
+	if expected == "" {
+		expected = v.Status
+	}
+	result := testCaseResult{
+		Test:   testName,
+		Policy: policy,
+		Rule:   v.Rule,
+		Resource: testCaseResource{
+			Kind:      v.Kind,
+			Namespace: v.Namespace,
+			Name:      resource,
+		},
+		Expected: string(expected),
+		Status:   testStatusFail,
+	}
+	if actual == nil {
+		result.Message = "result not found"
+	} else {
+		result.Actual = string(actual.Result)
+		result.Message = actual.Message
+		if actual.Result == expected {
+			result.Status = testStatusPass
+		}
+	}
+	r.testCases = append(r.testCases, result)
+}
+
+// validateOutputFormat checks the output format is empty (human readable output) or supported
+func validateOutputFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, f := range outputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %s, supported formats are %s", format, strings.Join(outputFormats, ", "))
+}
+
+// formatTestResults renders the test case results in the given output format, the coverage
+// is only part of the json format and can be nil
+func formatTestResults(format string, results []testCaseResult, coverage *coverageOutput) ([]byte, error) {
+	switch format {
+	case outputFormatJSON:
+		return formatJSON(results, coverage)
+	case outputFormatJUnit:
+		return formatJUnit(results)
+	}
+	return nil, validateOutputFormat(format)
+}
+
+type jsonSummary struct {
+	Tests  int `json:"tests"`
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+}
+
+type jsonOutput struct {
+	Summary  jsonSummary      `json:"summary"`
+	Results  []testCaseResult `json:"results"`
+	Coverage *coverageOutput  `json:"coverage,omitempty"`
+}
+
+func formatJSON(results []testCaseResult, coverage *coverageOutput) ([]byte, error) {
+	output := jsonOutput{
+		Results:  results,
+		Coverage: coverage,
+	}
+	if output.Results == nil {
+		output.Results = []testCaseResult{}
+	}
+	for _, result := range results {
+		output.Summary.Tests++
+		if result.Status == testStatusPass {
+			output.Summary.Passed++
+		} else {
+			output.Summary.Failed++
+		}
+	}
+	return json.MarshalIndent(output, "", "  ")
+}
+
+func formatJUnit(results []testCaseResult) ([]byte, error) {
+	report := junit.TestSuites{Name: "kyverno test"}
+	suites := map[string]*junit.TestSuite{}
+	var order []string
+	for _, result := range results {
+		suite := suites[result.Test]
+		if suite == nil {
+			suite = &junit.TestSuite{Name: result.Test}
+			suites[result.Test] = suite
+			order = append(order, result.Test)
+		}
+		testCase := junit.TestCase{
+			Name:      result.Policy + "/" + result.Rule + " " + result.Resource.String(),
+			ClassName: result.Test,
+			Properties: &junit.Properties{
+				Properties: []junit.Property{
+					{Name: "policy", Value: result.Policy},
+					{Name: "rule", Value: result.Rule},
+					{Name: "resource", Value: result.Resource.String()},
+					{Name: "expected", Value: result.Expected},
+					{Name: "actual", Value: result.Actual},
+				},
+			},
+		}
+		if result.Status == testStatusPass {
+			testCase.SystemOut = result.Message
+		} else {
+			testCase.Failure = &junit.Result{
+				Message: fmt.Sprintf("expected %s, got %s", result.Expected, actualOrNotFound(result.Actual)),
+				Type:    "mismatch",
+				Text:    result.Message,
+			}
+		}
+		suite.AddCase(testCase)
+	}
+	for _, name := range order {
+		report.AddSuite(*suites[name])
+	}
+	return report.Marshal()
+}
+
+func actualOrNotFound(actual string) string {
+	if actual == "" {
+		return "no result"
+	}
+	return actual
+}
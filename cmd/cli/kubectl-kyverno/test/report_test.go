@@ -0,0 +1,78 @@
+package test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/junit"
+	"gotest.tools/assert"
+)
+
+func recordTestCaseResults() []testCaseResult {
+	report := &testReport{}
+	expected := api.TestResults{Policy: "disallow-latest-tag", Rule: "require-image-tag", Kind: "Pod", Namespace: "default", Result: policyreportv1alpha2.StatusPass}
+	report.recordTestCaseResult("test-simple", "disallow-latest-tag", expected, "good-pod", &policyreportv1alpha2.PolicyReportResult{Result: policyreportv1alpha2.StatusPass, Message: "validation rule passed"})
+	report.recordTestCaseResult("test-simple", "disallow-latest-tag", expected, "bad-pod", &policyreportv1alpha2.PolicyReportResult{Result: policyreportv1alpha2.StatusFail, Message: "an image tag is required"})
+	deprecated := api.TestResults{Policy: "require-labels", Rule: "check-labels", Kind: "Namespace", Status: policyreportv1alpha2.StatusFail}
+	report.recordTestCaseResult("test-labels", "require-labels", deprecated, "prod", nil)
+	return report.testCases
+}
+
+func Test_recordTestCaseResult(t *testing.T) {
+	results := recordTestCaseResults()
+	assert.Equal(t, len(results), 3)
+	assert.Equal(t, results[0].Status, testStatusPass)
+	assert.Equal(t, results[0].Resource.String(), "Pod/default/good-pod")
+	assert.Equal(t, results[1].Status, testStatusFail)
+	assert.Equal(t, results[1].Expected, "pass")
+	assert.Equal(t, results[1].Actual, "fail")
+	assert.Equal(t, results[1].Message, "an image tag is required")
+	assert.Equal(t, results[2].Status, testStatusFail)
+	assert.Equal(t, results[2].Expected, "fail")
+	assert.Equal(t, results[2].Actual, "")
+	assert.Equal(t, results[2].Resource.String(), "Namespace/prod")
+}
+
+func Test_validateOutputFormat(t *testing.T) {
+	assert.NilError(t, validateOutputFormat(""))
+	assert.NilError(t, validateOutputFormat("json"))
+	assert.NilError(t, validateOutputFormat("junit"))
+	assert.ErrorContains(t, validateOutputFormat("table"), "invalid output format table")
+}
+
+func Test_formatTestResults_JSON(t *testing.T) {
+	data, err := formatTestResults(outputFormatJSON, recordTestCaseResults(), nil)
+	assert.NilError(t, err)
+	var output jsonOutput
+	assert.NilError(t, json.Unmarshal(data, &output))
+	assert.Equal(t, output.Summary, jsonSummary{Tests: 3, Passed: 1, Failed: 2})
+	assert.Equal(t, len(output.Results), 3)
+	assert.Equal(t, output.Results[1].Test, "test-simple")
+	assert.Equal(t, output.Results[1].Resource.Name, "bad-pod")
+
+	data, err = formatTestResults(outputFormatJSON, nil, nil)
+	assert.NilError(t, err)
+	assert.NilError(t, json.Unmarshal(data, &output))
+	assert.Equal(t, output.Summary, jsonSummary{})
+	assert.Equal(t, len(output.Results), 0)
+}
+
+func Test_formatTestResults_JUnit(t *testing.T) {
+	data, err := formatTestResults(outputFormatJUnit, recordTestCaseResults(), nil)
+	assert.NilError(t, err)
+	var report junit.TestSuites
+	assert.NilError(t, xml.Unmarshal(data, &report))
+	assert.Equal(t, report.Tests, 3)
+	assert.Equal(t, report.Failures, 2)
+	assert.Equal(t, len(report.Suites), 2)
+	assert.Equal(t, report.Suites[0].Name, "test-simple")
+	assert.Equal(t, report.Suites[0].Tests, 2)
+	assert.Equal(t, report.Suites[0].Cases[0].Name, "disallow-latest-tag/require-image-tag Pod/default/good-pod")
+	assert.Assert(t, report.Suites[0].Cases[0].Failure == nil)
+	assert.Equal(t, report.Suites[0].Cases[1].Failure.Message, "expected pass, got fail")
+	assert.Equal(t, report.Suites[0].Cases[1].Failure.Text, "an image tag is required")
+	assert.Equal(t, report.Suites[1].Cases[0].Failure.Message, "expected fail, got no result")
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"bytes"
+)
+
+// testFile is a test manifest to run
+type testFile struct {
+	// path is the directory containing the test manifest, policies and resources are relative to it
+	path string
+	// policyBytes is the test manifest, in JSON format
+	policyBytes []byte
+	// isGit indicates the test manifest was cloned from a git repository
+	isGit bool
+}
+
+// testReport holds the outcome of a test file run
+type testReport struct {
+	// output is the human readable output of the run
+	output    bytes.Buffer
+	rc        resultCounts
+	failed    []Table
+	testCases []testCaseResult
+	coverage  coverage
+	err       error
+	// fatal indicates err prevented the test file from running (policies, resources or
+	// request info could not be loaded), the command then exits with code 1, other errors
+	// are only printed in verbose mode
+	fatal bool
+}
+
+// runTestFiles runs the test files, up to parallel at a time. The reports are
+// passed to done in the order of the test files, regardless of the order the
+// runs complete in, so that the output stays deterministic.
+func runTestFiles(testFiles []testFile, parallel int, run func(testFile) *testReport, done func(testFile, *testReport)) {
+	if parallel < 1 {
+		parallel = 1
+	}
+	reports := make([]chan *testReport, len(testFiles))
+	for i := range reports {
+		reports[i] = make(chan *testReport, 1)
+	}
+	go func() {
+		sem := make(chan struct{}, parallel)
+		for i := range testFiles {
+			sem <- struct{}{}
+			go func(i int) {
+				defer func() { <-sem }()
+				reports[i] <- run(testFiles[i])
+			}(i)
+		}
+	}()
+	for i := range testFiles {
+		done(testFiles[i], <-reports[i])
+	}
+}
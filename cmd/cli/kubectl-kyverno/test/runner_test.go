@@ -0,0 +1,51 @@
+package test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_runTestFiles(t *testing.T) {
+	var testFiles []testFile
+	delays := map[string]time.Duration{}
+	for i := 0; i < 10; i++ {
+		path := fmt.Sprintf("test-%d", i)
+		testFiles = append(testFiles, testFile{path: path})
+		delays[path] = time.Duration(10-i) * time.Millisecond
+	}
+	for _, parallel := range []int{0, 1, 3, 20} {
+		var running, maxRunning int32
+		var done []string
+		runTestFiles(testFiles, parallel, func(f testFile) *testReport {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			// complete the first test files last
+			time.Sleep(delays[f.path])
+			atomic.AddInt32(&running, -1)
+			report := &testReport{}
+			report.output.WriteString(f.path)
+			return report
+		}, func(f testFile, report *testReport) {
+			assert.Equal(t, report.output.String(), f.path)
+			done = append(done, f.path)
+		})
+		assert.Equal(t, len(done), len(testFiles))
+		for i, path := range done {
+			assert.Equal(t, path, testFiles[i].path)
+		}
+		expectedMax := parallel
+		if expectedMax < 1 {
+			expectedMax = 1
+		}
+		assert.Assert(t, int(maxRunning) <= expectedMax, "parallel %d, max running %d", parallel, maxRunning)
+	}
+}
@@ -94,15 +94,27 @@ applying 1 policy to 1 resource...
 
 Test Summary: 1 tests passed and 0 tests failed
 
+# Test a local folder and write one test case per expected result to a JUnit report, for CI test reporters.
+kyverno test . --output junit > kyverno-test-report.xml
+
+# Run up to 4 test files at the same time, the output is printed in the same order as a sequential run.
+kyverno test . --parallel 4
+
+# Print which outcomes of every policy rule are tested and fail when less than 80% of the rules have a test.
+kyverno test . --coverage --coverage-threshold 80
+
 
 
 **TEST FILE STRUCTURE**:
 
-The kyverno-test.yaml has four parts:
-	"policies"   --> List of policies which are applied.
-	"resources"  --> List of resources on which the policies are applied.
-	"variables"  --> Variable file path containing variables referenced in the policy (OPTIONAL).
-	"results"    --> List of results expected after applying the policies to the resources.
+The kyverno-test.yaml has these parts:
+	"policies"        --> List of policies which are applied.
+	"cleanupPolicies" --> List of cleanup policies which are evaluated (OPTIONAL).
+	"clock"           --> Simulated time at which cleanup policies are evaluated, defaults to now (OPTIONAL).
+	"resources"       --> List of resources on which the policies are applied.
+	"variables"       --> Variable file path containing variables referenced in the policy (OPTIONAL).
+	"fixtures"        --> External data loaded by apiCall, configMap, imageRegistry and globalReference context entries (OPTIONAL).
+	"results"         --> List of results expected after applying the policies to the resources.
 
 ** TEST FILE FORMAT**:
 
@@ -110,10 +122,30 @@ name: <test_name>
 policies:
 - <path/to/policy1.yaml>
 - <path/to/policy2.yaml>
+cleanupPolicies: (OPTIONAL)
+- <path/to/cleanup-policy.yaml>
+clock: <RFC 3339 time, e.g. 2023-01-10T00:00:00Z> (OPTIONAL)
 resources:
 - <path/to/resource1.yaml>
 - <path/to/resource2.yaml>
 variables: <variable_file> (OPTIONAL)
+fixtures: (OPTIONAL)
+  apiCalls:
+  - urlPath: <url_path>
+    response: <raw API server response>
+  services:
+  - url: <service_url>
+    response: <raw service response>
+  globalContextEntries:
+  - name: <global_context_entry_name>
+    data: <global_context_entry_data>
+  configMaps:
+  - <config_map>
+  images:
+  - reference: <image_reference>
+    digest: <image_digest> (OPTIONAL)
+    manifest: <image_manifest> (OPTIONAL)
+    configData: <image_config> (OPTIONAL)
 results:
 - policy: <name> (For Namespaced [Policy] files, format is <policy_namespace>/<policy_name>)
   rule: <name>
@@ -121,6 +153,13 @@ results:
   namespace: <name> (OPTIONAL)
   kind: <name>
   patchedResource: <path/to/patched/resource.yaml> (For mutate policies/rules only)
+  patchedResourceAssertions: (For mutate policies/rules only, OPTIONAL)
+    jmesPath:
+    - expression: <jmespath_expression>
+      value: <expected_value>
+    pattern: <validation pattern, anchors are supported>
+  generatedResource: <path/to/generated/resource.yaml> (For generate policies/rules only)
+  generatedResourceAssertions: <same as patchedResourceAssertions> (For generate policies/rules only, OPTIONAL)
   result: <pass|fail|skip>
 
 **VARIABLES FILE FORMAT**:
@@ -147,6 +186,8 @@ pass  --> The resource is either validated by the policy or, if a mutation, equa
 fail  --> The resource fails validation or the patched resource generated by Kyverno is not equal to the input resource provided by the user.
 skip  --> The rule is not applied.
 
+For cleanup policies, pass means the resource would be deleted at the time of the clock and skip means it would not.
+
 For more information visit https://kyverno.io/docs/kyverno-cli/#test
 `
 
@@ -154,8 +195,11 @@ For more information visit https://kyverno.io/docs/kyverno-cli/#test
 func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var testCase string
-	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate bool
+	var fileName, gitBranch, outputFormat string
+	var parallel int
+	var coverageFormat, coverageFile string
+	var coverageThreshold float64
+	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate, showCoverage bool
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
 		// Args:    cobra.ExactArgs(1),
@@ -176,8 +220,22 @@ func Command() *cobra.Command {
 			} else if manifestValidate {
 				manifest.PrintValidate()
 			} else {
-				store.SetRegistryAccess(registryAccess)
-				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor)
+				if err := validateOutputFormat(outputFormat); err != nil {
+					return sanitizederror.NewWithError("invalid output format", err)
+				}
+				if err := validateCoverageFormat(coverageFormat); err != nil {
+					return sanitizederror.NewWithError("invalid coverage format", err)
+				}
+				coverageOptions := coverageOptions{
+					enabled:   showCoverage || coverageThreshold > 0 || coverageFile != "",
+					format:    coverageFormat,
+					threshold: coverageThreshold,
+					file:      coverageFile,
+				}
+				if err := validateCoverageOptions(coverageOptions, outputFormat); err != nil {
+					return sanitizederror.NewWithError("invalid coverage options", err)
+				}
+				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, outputFormat, coverageOptions, parallel, registryAccess, failOnly, removeColor)
 				if err != nil {
 					log.Log.V(3).Info("a directory is required")
 					return err
@@ -194,6 +252,12 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVarP(&failOnly, "fail-only", "", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVarP(&removeColor, "remove-color", "", false, "Remove any color from output")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Prints one test case per expected result in the given format, one of junit or json")
+	cmd.Flags().IntVarP(&parallel, "parallel", "", 1, "Number of test files run concurrently, results are printed in the same order as with a single run")
+	cmd.Flags().BoolVarP(&showCoverage, "coverage", "", false, "Prints which outcomes (pass, fail and skip) of every loaded policy rule, including autogen rules, are expected by a test")
+	cmd.Flags().StringVarP(&coverageFormat, "coverage-format", "", coverageFormatTable, "Format of the coverage report, one of table or json")
+	cmd.Flags().StringVarP(&coverageFile, "coverage-file", "", "", "Writes the coverage report to the given file instead of the command output, implies --coverage")
+	cmd.Flags().Float64VarP(&coverageThreshold, "coverage-threshold", "", 0, "Fails the run when the percentage of policy rules with at least one test is below this value, implies --coverage")
 	return cmd
 }
 
@@ -211,6 +275,14 @@ type resultCounts struct {
 	Fail int
 }
 
+type coverageOptions struct {
+	enabled   bool
+	format    string
+	threshold float64
+	// file is the file the coverage is written to, empty to print it with the command output
+	file string
+}
+
 type testFilter struct {
 	policy   string
 	rule     string
@@ -218,10 +290,20 @@ type testFilter struct {
 	enabled  bool
 }
 
-var ftable = []Table{}
+func (rc *resultCounts) add(other resultCounts) {
+	rc.Skip += other.Skip
+	rc.Pass += other.Pass
+	rc.Fail += other.Fail
+}
 
-func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
+func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, outputFormat string, coverageOptions coverageOptions, parallel int, registryAccess bool, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
 	var errors []error
+	var testFiles []testFile
+	var out io.Writer = os.Stdout
+	if outputFormat != "" || (coverageOptions.enabled && coverageOptions.format == coverageFormatJSON && coverageOptions.file == "") {
+		// stdout is reserved for the machine readable document, other messages are printed on stderr
+		out = os.Stderr
+	}
 	fs := memfs.New()
 	rc = &resultCounts{}
 	var testYamlCount int
@@ -238,7 +320,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 		for _, t := range strings.Split(testCase, ",") {
 			if !strings.Contains(t, "=") {
-				fmt.Printf("\n Invalid test-case-selector argument. Selecting all test cases. \n")
+				fmt.Fprintf(out, "\n Invalid test-case-selector argument. Selecting all test cases. \n")
 				tf.enabled = false
 				break
 			}
@@ -248,7 +330,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 			_, ok := parameters[key]
 			if !ok {
-				fmt.Printf("\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
+				fmt.Fprintf(out, "\n Invalid parameter. Parameter can only be policy, rule or resource. Selecting all test cases \n")
 				tf.enabled = false
 				break
 			}
@@ -276,7 +358,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 		pathElems := strings.Split(gitURL.Path[1:], "/")
 		if len(pathElems) <= 1 {
 			err := fmt.Errorf("invalid URL path %s - expected https://github.com/:owner/:repository/:branch (without --git-branch flag) OR https://github.com/:owner/:repository/:directory (with --git-branch flag)", gitURL.Path)
-			fmt.Printf("Error: failed to parse URL \nCause: %s\n", err)
+			fmt.Fprintf(out, "Error: failed to parse URL \nCause: %s\n", err)
 			os.Exit(1)
 		}
 
@@ -308,7 +390,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 
 		_, cloneErr := gitutils.Clone(repoURL, fs, gitBranch)
 		if cloneErr != nil {
-			fmt.Printf("Error: failed to clone repository \nCause: %s\n", cloneErr)
+			fmt.Fprintf(out, "Error: failed to clone repository \nCause: %s\n", cloneErr)
 			log.Log.V(3).Info(fmt.Sprintf("failed to clone repository  %v as it is not valid", repoURL), "error", cloneErr)
 			os.Exit(1)
 		}
@@ -340,48 +422,135 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
-					return rc, sanitizederror.NewWithError("failed to apply test command", err)
-				}
+				testFiles = append(testFiles, testFile{path: policyresoucePath, policyBytes: policyBytes, isGit: true})
 			}
 		}
 
 		if testYamlCount == 0 {
-			fmt.Printf("\n No test yamls available \n")
+			fmt.Fprintf(out, "\n No test yamls available \n")
 		}
 	} else {
-		var testFiles int
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
+		var testFilesCount int
+		errors = getLocalDirTestFiles(path, fileName, &testFiles, &testFilesCount)
+
+		if testFilesCount == 0 {
+			fmt.Fprintf(out, "\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
+		}
+	}
 
-		if testFiles == 0 {
-			fmt.Printf("\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
+	var failed []Table
+	var testCases []testCaseResult
+	var policyCoverage coverage
+	var gitErr error
+	var applyFailed bool
+	runTestFiles(testFiles, parallel, func(f testFile) *testReport {
+		report := &testReport{}
+		report.err = applyPoliciesFromPath(fs, f.policyBytes, f.isGit, f.path, report, openApiManager, tf, registryAccess, failOnly, removeColor)
+		return report
+	}, func(f testFile, report *testReport) {
+		fmt.Fprint(out, report.output.String())
+		rc.add(report.rc)
+		failed = append(failed, report.failed...)
+		testCases = append(testCases, report.testCases...)
+		policyCoverage.merge(&report.coverage)
+		if report.err != nil {
+			applyFailed = applyFailed || report.fatal
+			if f.isGit {
+				if gitErr == nil {
+					gitErr = sanitizederror.NewWithError("failed to apply test command", report.err)
+				}
+			} else {
+				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", filepath.Join(f.path, fileName)), report.err))
+			}
 		}
+	})
+	if gitErr != nil {
+		return rc, gitErr
 	}
 
 	if len(errors) > 0 && log.Log.V(1).Enabled() {
-		fmt.Printf("test errors: \n")
+		fmt.Fprintf(out, "test errors: \n")
 		for _, e := range errors {
-			fmt.Printf("    %v \n", e.Error())
+			fmt.Fprintf(out, "    %v \n", e.Error())
 		}
 	}
 
 	if !failOnly {
-		fmt.Printf("\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d tests passed and %d tests failed\n", rc.Pass+rc.Skip, rc.Fail)
 	} else {
-		fmt.Printf("\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
+		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 
 	if rc.Fail > 0 && !failOnly {
-		printFailedTestResult(removeColor)
+		printFailedTestResult(out, failed, removeColor)
+	}
+
+	belowCoverageThreshold, err := printResults(os.Stdout, out, outputFormat, coverageOptions, testCases, &policyCoverage, removeColor)
+	if err != nil {
+		return rc, err
+	}
+
+	if (rc.Fail > 0 && !failOnly) || belowCoverageThreshold || applyFailed {
 		os.Exit(1)
 	}
 	os.Exit(0)
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
+// printResults prints the coverage and the formatted test results. The machine readable document,
+// either the formatted test results with the coverage or the json coverage alone, is the only content
+// printed on stdout, the human readable output is printed on out.
+func printResults(stdout, out io.Writer, outputFormat string, coverageOptions coverageOptions, testCases []testCaseResult, policyCoverage *coverage, removeColor bool) (bool, error) {
+	var belowCoverageThreshold bool
+	var embeddedCoverage *coverageOutput
+	if coverageOptions.enabled {
+		switch {
+		case coverageOptions.file != "":
+			if err := writeCoverageFile(coverageOptions.file, policyCoverage, coverageOptions.format); err != nil {
+				return false, sanitizederror.NewWithError("failed to write coverage file", err)
+			}
+		case coverageOptions.format == coverageFormatTable:
+			if err := printCoverage(out, policyCoverage, coverageOptions.format, removeColor); err != nil {
+				return false, sanitizederror.NewWithError("failed to print coverage", err)
+			}
+		case outputFormat == outputFormatJSON:
+			embeddedCoverage = newCoverageOutput(policyCoverage)
+		default:
+			if err := printCoverage(stdout, policyCoverage, coverageOptions.format, removeColor); err != nil {
+				return false, sanitizederror.NewWithError("failed to print coverage", err)
+			}
+		}
+		if percentage := policyCoverage.percentage(); percentage < coverageOptions.threshold {
+			fmt.Fprintf(out, "Coverage %.2f%% is below the threshold of %.2f%%\n", percentage, coverageOptions.threshold)
+			belowCoverageThreshold = true
+		}
+	}
+
+	if outputFormat != "" {
+		output, err := formatTestResults(outputFormat, testCases, embeddedCoverage)
+		if err != nil {
+			return belowCoverageThreshold, sanitizederror.NewWithError("failed to format test results", err)
+		}
+		fmt.Fprintln(stdout, string(output))
+	}
+	return belowCoverageThreshold, nil
+}
+
+func writeCoverageFile(path string, c *coverage, format string) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	if err := printCoverage(f, c, format, true); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func getLocalDirTestFiles(path, fileName string, testFiles *[]testFile, testFilesCount *int) []error {
 	var errors []error
 
 	files, err := os.ReadDir(path)
@@ -390,11 +559,11 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
+			getLocalDirTestFiles(filepath.Join(path, file.Name()), fileName, testFiles, testFilesCount)
 			continue
 		}
 		if file.Name() == fileName {
-			*testFiles++
+			*testFilesCount++
 			// We accept the risk of including files here as we read the test dir only.
 			yamlFile, err := os.ReadFile(filepath.Join(path, file.Name())) // #nosec G304
 			if err != nil {
@@ -406,17 +575,17 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
-				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
-				continue
-			}
+			*testFiles = append(*testFiles, testFile{path: path, policyBytes: valuesBytes})
 		}
 	}
 	return errors
 }
 
-func buildPolicyResults(engineResponses []*response.EngineResponse, testResults []api.TestResults, infos []common.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []api.TestResults) {
+// buildPolicyResults returns the results keyed by result key, the test results with their autogen rules set,
+// and the reasons why mutated or generated resources did not match their expectations, keyed by result key
+func buildPolicyResults(out io.Writer, engineResponses []*response.EngineResponse, testResults []api.TestResults, infos []common.Info, policyResourcePath string, fs billy.Filesystem, isGit bool) (map[string]policyreportv1alpha2.PolicyReportResult, []api.TestResults, map[string]string) {
 	results := make(map[string]policyreportv1alpha2.PolicyReportResult)
+	mismatches := make(map[string]string)
 	now := metav1.Timestamp{Seconds: time.Now().Unix()}
 
 	for _, resp := range engineResponses {
@@ -441,7 +610,8 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 			Message: buildMessage(resp),
 		}
 
-		var patchedResourcePath []string
+		// the expectations of the mutated resource, keyed by result key
+		patchedResources := map[string][]resourceExpectation{}
 		for i, test := range testResults {
 			var userDefinedPolicyNamespace string
 			var userDefinedPolicyName string
@@ -489,7 +659,7 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 								}
 							}
 
-							patchedResourcePath = append(patchedResourcePath, test.PatchedResource)
+							patchedResources[resultsKey] = append(patchedResources[resultsKey], resourceExpectation{path: test.PatchedResource, assertions: test.PatchedResourceAssertions})
 							if _, ok := results[resultsKey]; !ok {
 								results[resultsKey] = result
 							}
@@ -522,7 +692,7 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 						}
 					}
 
-					patchedResourcePath = append(patchedResourcePath, test.PatchedResource)
+					patchedResources[resultsKey] = append(patchedResources[resultsKey], resourceExpectation{path: test.PatchedResource, assertions: test.PatchedResourceAssertions})
 					if _, ok := results[resultsKey]; !ok {
 						results[resultsKey] = result
 					}
@@ -533,6 +703,10 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 				if rule.Type != response.Generation || test.Rule != rule.Name {
 					continue
 				}
+				// the generated resource is only checked against the expectations of the tests of this resource
+				if test.Resource != resourceName && !slices.Contains(test.Resources, resourceName) {
+					continue
+				}
 
 				var resultsKey []string
 				var resultKey string
@@ -551,11 +725,12 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 					} else if rule.Status == response.RuleStatusError {
 						result.Result = policyreportv1alpha2.StatusError
 					} else {
-						var x string
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(test.GeneratedResource, rule.GeneratedResource, isGit, policyResourcePath, fs, true)
-						if x == "pass" {
+						expectation := resourceExpectation{path: test.GeneratedResource, assertions: test.GeneratedResourceAssertions}
+						if matched, message := getAndCompareResource(out, expectation, rule.GeneratedResource, isGit, policyResourcePath, fs, true); matched {
 							result.Result = policyreportv1alpha2.StatusPass
+						} else {
+							mismatches[resultKey] = message
 						}
 					}
 					results[resultKey] = result
@@ -585,14 +760,19 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 				} else if rule.Status == response.RuleStatusError {
 					result.Result = policyreportv1alpha2.StatusError
 				} else {
-					var x string
-					for _, path := range patchedResourcePath {
+					var messages []string
+					for _, expectation := range patchedResources[resultKey] {
 						result.Result = policyreportv1alpha2.StatusFail
-						x = getAndCompareResource(path, resp.PatchedResource, isGit, policyResourcePath, fs, false)
-						if x == "pass" {
+						matched, message := getAndCompareResource(out, expectation, resp.PatchedResource, isGit, policyResourcePath, fs, false)
+						if matched {
 							result.Result = policyreportv1alpha2.StatusPass
+							messages = nil
 							break
 						}
+						messages = append(messages, message)
+					}
+					if len(messages) > 0 {
+						mismatches[resultKey] = strings.Join(messages, "")
 					}
 				}
 
@@ -630,7 +810,7 @@ func buildPolicyResults(engineResponses []*response.EngineResponse, testResults
 		}
 	}
 
-	return results, testResults
+	return results, testResults, mismatches
 }
 
 func GetAllPossibleResultsKey(policyNamespace, policy, rule, resourceNamespace, kind, resource string) []string {
@@ -672,27 +852,36 @@ func getUserDefinedPolicyNameAndNamespace(policyName string) (string, string) {
 }
 
 // getAndCompareResource --> Get the patchedResource or generatedResource from the path provided by user
-// And compare this resource with engine generated resource.
-func getAndCompareResource(path string, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) string {
-	var status string
+// And compare this resource with engine generated resource, then check the partial assertions.
+// When the resource does not match, the returned message explains why.
+func getAndCompareResource(out io.Writer, expectation resourceExpectation, engineResource unstructured.Unstructured, isGit bool, policyResourcePath string, fs billy.Filesystem, isGenerate bool) (bool, string) {
 	resourceType := "patchedResource"
 	if isGenerate {
 		resourceType = "generatedResource"
 	}
+	resourceName := engineResource.GetKind() + "/" + engineResource.GetName()
+	if engineResource.GetNamespace() != "" {
+		resourceName = engineResource.GetKind() + "/" + engineResource.GetNamespace() + "/" + engineResource.GetName()
+	}
 
-	userResource, err := common.GetResourceFromPath(fs, path, isGit, policyResourcePath, resourceType)
-	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
-		return ""
+	if expectation.path != "" || expectation.assertions == nil {
+		userResource, err := common.GetResourceFromPath(fs, out, expectation.path, isGit, policyResourcePath, resourceType)
+		if err != nil {
+			return false, fmt.Sprintf("Error: failed to load resources\nCause: %s\n", err)
+		}
+		_, err = generate.ValidateResourceWithPattern(log.Log, engineResource.UnstructuredContent(), userResource.UnstructuredContent())
+		if err != nil {
+			log.Log.V(3).Info(resourceType+" mismatch", "error", err.Error())
+			return false, fmt.Sprintf("\n%s %s does not match %s: %s\n%s", resourceType, expectation.path, resourceName, err, resourceDiff(userResource, engineResource))
+		}
 	}
-	matched, err := generate.ValidateResourceWithPattern(log.Log, engineResource.UnstructuredContent(), userResource.UnstructuredContent())
-	if err != nil {
-		log.Log.V(3).Info(resourceType+" mismatch", "error", err.Error())
-		status = "fail"
-	} else if matched == "" {
-		status = "pass"
+	if expectation.assertions != nil {
+		if err := checkAssertions(expectation.assertions, engineResource); err != nil {
+			log.Log.V(3).Info(resourceType+" assertions failed", "error", err.Error())
+			return false, fmt.Sprintf("\n%sAssertions failed for %s:\n%s\n", resourceType, resourceName, err)
+		}
 	}
-	return status
+	return true, ""
 }
 
 func buildMessage(resp *response.EngineResponse) string {
@@ -718,15 +907,18 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
+func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, report *testReport, openApiManager openapi.Manager, tf *testFilter, registryAccess, failOnly, removeColor bool) (err error) {
 	engineResponses := make([]*response.EngineResponse, 0)
+	out := &report.output
 	var dClient dclient.Interface
 	values := &api.Test{}
 	var variablesString string
 	var pvInfos []common.Info
 	var resultCounts common.ResultCounts
 
-	store.SetMock(true)
+	cliStore := &store.Store{}
+	cliStore.SetMock(true)
+	cliStore.SetRegistryAccess(registryAccess)
 	if err := json.Unmarshal(policyBytes, values); err != nil {
 		return sanitizederror.NewWithError("failed to decode yaml", err)
 	}
@@ -744,11 +936,16 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return nil
 	}
 
-	fmt.Printf("\nExecuting %s...", values.Name)
+	fmt.Fprintf(out, "\nExecuting %s...", values.Name)
+	fixtures, err := buildFixtures(values.Fixtures)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to load fixtures", err)
+	}
+	cliStore.SetFixtures(fixtures)
 	valuesFile := values.Variables
 	userInfoFile := values.UserInfo
 
-	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(variablesString, values.Variables, fs, isGit, policyResourcePath)
+	variables, globalValMap, valuesMap, namespaceSelectorMap, err := common.GetVariable(cliStore, out, variablesString, values.Variables, fs, isGit, policyResourcePath)
 	if err != nil {
 		if !sanitizederror.IsErrorSanitized(err) {
 			return sanitizederror.NewWithError("failed to decode yaml", err)
@@ -761,12 +958,13 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	var subjectInfo store.Subject
 
 	if userInfoFile != "" {
-		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, userInfoFile, isGit, policyResourcePath)
+		userInfo, subjectInfo, err = common.GetUserInfoFromPath(fs, out, userInfoFile, isGit, policyResourcePath)
 		if err != nil {
-			fmt.Printf("Error: failed to load request info\nCause: %s\n", err)
-			os.Exit(1)
+			fmt.Fprintf(out, "Error: failed to load request info\nCause: %s\n", err)
+			report.fatal = true
+			return sanitizederror.NewWithError("failed to load request info", err)
 		}
-		store.SetSubjects(subjectInfo)
+		cliStore.SetSubjects(subjectInfo)
 	}
 
 	policyFullPath := getFullPath(values.Policies, policyResourcePath, isGit)
@@ -781,15 +979,35 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		generatedResourceFullPath := getFullPath(arrGeneratedResource, policyResourcePath, isGit)
 		CloneSourceResourceFullPath := getFullPath(arrCloneSourceResource, policyResourcePath, isGit)
 
-		values.Results[i].PatchedResource = patchedResourceFullPath[0]
-		values.Results[i].GeneratedResource = generatedResourceFullPath[0]
+		// an empty path means the resource is only checked by assertions
+		if result.PatchedResource != "" {
+			values.Results[i].PatchedResource = patchedResourceFullPath[0]
+		}
+		if result.GeneratedResource != "" {
+			values.Results[i].GeneratedResource = generatedResourceFullPath[0]
+		}
 		values.Results[i].CloneSourceResource = CloneSourceResourceFullPath[0]
 	}
 
-	policies, err := common.GetPoliciesFromPaths(fs, policyFullPath, isGit, policyResourcePath)
+	var policies []kyvernov1.PolicyInterface
+	// a test may only have cleanup policies
+	if len(values.Policies) > 0 || len(values.CleanupPolicies) == 0 {
+		policies, err = common.GetPoliciesFromPaths(fs, out, policyFullPath, isGit, policyResourcePath)
+		if err != nil {
+			fmt.Fprintf(out, "Error: failed to load policies\nCause: %s\n", err)
+			report.fatal = true
+			return sanitizederror.NewWithError("failed to load policies", err)
+		}
+	}
+
+	cleanupPolicies, err := common.GetCleanupPoliciesFromPaths(fs, getFullPath(values.CleanupPolicies, policyResourcePath, isGit), isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load policies\nCause: %s\n", err)
-		os.Exit(1)
+		return sanitizederror.NewWithError("failed to load cleanup policies", err)
+	}
+	cleanupPolicies = validCleanupPolicies(cleanupPolicies)
+
+	for _, p := range policies {
+		report.coverage.addPolicy(p)
 	}
 
 	filteredPolicies := []kyvernov1.PolicyInterface{}
@@ -813,13 +1031,13 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 					if rule.HasGenerate() {
 						ruleUnstr, err := generate.GetUnstrRule(rule.Generation.DeepCopy())
 						if err != nil {
-							fmt.Printf("Error: failed to get unstructured rule\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to get unstructured rule\nCause: %s\n", err)
 							break
 						}
 
 						genClone, _, err := unstructured.NestedMap(ruleUnstr.Object, "clone")
 						if err != nil {
-							fmt.Printf("Error: failed to read data\nCause: %s\n", err)
+							fmt.Fprintf(out, "Error: failed to read data\nCause: %s\n", err)
 							break
 						}
 
@@ -840,12 +1058,14 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return sanitizederror.NewWithError("failed to print mutated policy", err)
 	}
 
-	resources, err := common.GetResourceAccordingToResourcePath(fs, resourceFullPath, false, policies, dClient, "", false, isGit, policyResourcePath)
+	resources, _, err := common.GetResourceAccordingToResourcePath(fs, out, resourceFullPath, false, policies, dClient, "", false, isGit, policyResourcePath)
 	if err != nil {
-		fmt.Printf("Error: failed to load resources\nCause: %s\n", err)
-		os.Exit(1)
+		fmt.Fprintf(out, "Error: failed to load resources\nCause: %s\n", err)
+		report.fatal = true
+		return sanitizederror.NewWithError("failed to load resources", err)
 	}
 
+	allResources := resources
 	filteredResources := []*unstructured.Unstructured{}
 	for _, r := range resources {
 		for _, res := range values.Results {
@@ -869,7 +1089,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		for _, unique := range noDuplicateResources {
 			if resource.GetKind() == unique.GetKind() && resource.GetName() == unique.GetName() && resource.GetNamespace() == unique.GetNamespace() {
 				duplicate = true
-				fmt.Println("skipping duplicate resource, resource :", resource)
+				fmt.Fprintln(out, "skipping duplicate resource, resource :", resource)
 				break
 			}
 		}
@@ -879,8 +1099,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	}
 
 	msgPolicies := "1 policy"
-	if len(policies) > 1 {
-		msgPolicies = fmt.Sprintf("%d policies", len(policies))
+	if len(policies)+len(cleanupPolicies) > 1 {
+		msgPolicies = fmt.Sprintf("%d policies", len(policies)+len(cleanupPolicies))
 	}
 
 	msgResources := "1 resource"
@@ -888,8 +1108,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		msgResources = fmt.Sprintf("%d resources", len(noDuplicateResources))
 	}
 
-	if len(policies) > 0 && len(noDuplicateResources) > 0 {
-		fmt.Printf("\napplying %s to %s... \n", msgPolicies, msgResources)
+	if len(policies)+len(cleanupPolicies) > 0 && len(noDuplicateResources) > 0 {
+		fmt.Fprintf(out, "\napplying %s to %s... \n", msgPolicies, msgResources)
 	}
 
 	for _, policy := range policies {
@@ -906,7 +1126,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			if len(variables) == 0 {
 				// check policy in variable file
 				if valuesFile == "" || valuesMap[policy.GetName()] == nil {
-					fmt.Printf("test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
+					fmt.Fprintf(out, "test skipped for policy  %v  (as required variables are not provided by the users) \n \n", policy.GetName())
 				}
 			}
 		}
@@ -914,7 +1134,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		kindOnwhichPolicyIsApplied := common.GetKindsFromPolicy(policy)
 
 		for _, resource := range noDuplicateResources {
-			thisPolicyResourceValues, err := common.CheckVariableForPolicy(valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
+			thisPolicyResourceValues, err := common.CheckVariableForPolicy(cliStore, valuesMap, globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), variables, kindOnwhichPolicyIsApplied, variable)
 			if err != nil {
 				return sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policy.GetName(), resource.GetName()), err)
 			}
@@ -929,6 +1149,8 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 				Rc:                        &resultCounts,
 				RuleToCloneSourceResource: ruleToCloneSourceResource,
 				Client:                    dClient,
+				Store:                     cliStore,
+				Out:                       out,
 			}
 			ers, info, err := common.ApplyPolicyOnResource(applyPolicyConfig)
 			if err != nil {
@@ -938,8 +1160,13 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 			pvInfos = append(pvInfos, info)
 		}
 	}
-	resultsMap, testResults := buildPolicyResults(engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
-	resultErr := printTestResult(resultsMap, testResults, rc, failOnly, removeColor)
+	resultsMap, testResults, mismatches := buildPolicyResults(out, engineResponses, values.Results, pvInfos, policyResourcePath, fs, isGit)
+	applyCleanupPolicies(cleanupPolicies, noDuplicateResources, allResources, testResults, namespaceSelectorMap, cliStore, testClock(values.Clock), resultsMap)
+	// autogen rules of the results are only known once the policies are applied
+	for _, result := range testResults {
+		report.coverage.addResult(result)
+	}
+	resultErr := printTestResult(values.Name, resultsMap, testResults, mismatches, report, failOnly, removeColor)
 	if resultErr != nil {
 		return sanitizederror.NewWithError("failed to print test result:", resultErr)
 	}
@@ -947,11 +1174,14 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	return
 }
 
-func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, rc *resultCounts, failOnly, removeColor bool) error {
-	printer := newTablePrinter(removeColor)
+func printTestResult(testName string, resps map[string]policyreportv1alpha2.PolicyReportResult, testResults []api.TestResults, mismatches map[string]string, report *testReport, failOnly, removeColor bool) error {
+	printer := newTablePrinter(&report.output, removeColor)
+	rc := &report.rc
 	table := []Table{}
 
 	var countDeprecatedResource int
+	// details are the result keys of the failed tests of mutated and generated resources with a mismatch
+	var details []string
 	testCount := 1
 	for _, v := range testResults {
 		res := new(Table)
@@ -978,6 +1208,10 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				found, _ := isNamespacedPolicy(v.Policy)
 				var ns string
 				ns, v.Policy = getUserDefinedPolicyNameAndNamespace(v.Policy)
+				policyName := v.Policy
+				if found {
+					policyName = ns + "/" + v.Policy
+				}
 				if found && v.Namespace != "" {
 					resultKey = fmt.Sprintf("%s-%s-%s-%s-%s-%s", ns, v.Policy, ruleNameInResultKey, v.Namespace, v.Kind, resource)
 				} else if found {
@@ -993,14 +1227,17 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				if val, ok := resps[resultKey]; ok {
 					testRes = val
 				} else {
+					report.recordTestCaseResult(testName, policyName, v, resource, nil)
 					log.Log.V(2).Info("result not found", "key", resultKey)
 					res.Result = colorize(removeColor, boldYellow, "Not found")
 					rc.Fail++
 					table = append(table, *res)
-					ftable = append(ftable, *res)
+					report.failed = append(report.failed, *res)
 					continue
 				}
 
+				report.recordTestCaseResult(testName, policyName, v, resource, &testRes)
+
 				if v.Result == "" && v.Status != "" {
 					v.Result = v.Status
 				}
@@ -1015,8 +1252,11 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 				} else {
 					log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
 					res.Result = colorize(removeColor, boldRed, "Fail")
+					if _, ok := mismatches[resultKey]; ok && !slices.Contains(details, resultKey) {
+						details = append(details, resultKey)
+					}
 					rc.Fail++
-					ftable = append(ftable, *res)
+					report.failed = append(report.failed, *res)
 				}
 
 				if failOnly {
@@ -1041,6 +1281,10 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			found, _ := isNamespacedPolicy(v.Policy)
 			var ns string
 			ns, v.Policy = getUserDefinedPolicyNameAndNamespace(v.Policy)
+			policyName := v.Policy
+			if found {
+				policyName = ns + "/" + v.Policy
+			}
 			if found && v.Namespace != "" {
 				resultKey = fmt.Sprintf("%s-%s-%s-%s-%s-%s", ns, v.Policy, ruleNameInResultKey, v.Namespace, v.Kind, v.Resource)
 			} else if found {
@@ -1056,14 +1300,17 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			if val, ok := resps[resultKey]; ok {
 				testRes = val
 			} else {
+				report.recordTestCaseResult(testName, policyName, v, v.Resource, nil)
 				log.Log.V(2).Info("result not found", "key", resultKey)
 				res.Result = colorize(removeColor, boldYellow, "Not found")
 				rc.Fail++
 				table = append(table, *res)
-				ftable = append(ftable, *res)
+				report.failed = append(report.failed, *res)
 				continue
 			}
 
+			report.recordTestCaseResult(testName, policyName, v, v.Resource, &testRes)
+
 			if v.Result == "" && v.Status != "" {
 				v.Result = v.Status
 			}
@@ -1078,8 +1325,11 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			} else {
 				log.Log.V(2).Info("result mismatch", "expected", v.Result, "received", testRes.Result, "key", resultKey)
 				res.Result = colorize(removeColor, boldRed, "Fail")
+				if _, ok := mismatches[resultKey]; ok && !slices.Contains(details, resultKey) {
+					details = append(details, resultKey)
+				}
 				rc.Fail++
-				ftable = append(ftable, *res)
+				report.failed = append(report.failed, *res)
 			}
 
 			if failOnly {
@@ -1091,17 +1341,20 @@ func printTestResult(resps map[string]policyreportv1alpha2.PolicyReportResult, t
 			}
 		}
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(&report.output, "\n")
 	printer.Print(table)
+	for _, resultKey := range details {
+		fmt.Fprint(&report.output, mismatches[resultKey])
+	}
 	return nil
 }
 
-func printFailedTestResult(removeColor bool) {
-	printer := newTablePrinter(removeColor)
+func printFailedTestResult(out io.Writer, ftable []Table, removeColor bool) {
+	printer := newTablePrinter(out, removeColor)
 	for i, v := range ftable {
 		v.ID = i + 1
 	}
-	fmt.Printf("Aggregated Failed Test Cases : ")
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "Aggregated Failed Test Cases : ")
+	fmt.Fprintf(out, "\n")
 	printer.Print(ftable)
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/go-git/go-billy/v5"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
@@ -85,6 +86,16 @@ type ApplyPolicyConfig struct {
 	RuleToCloneSourceResource map[string]string
 	Client                    dclient.Interface
 	AuditWarn                 bool
+	Store                     *store.Store
+	// Out receives the human readable messages, it defaults to stdout
+	Out io.Writer
+}
+
+func (c ApplyPolicyConfig) out() io.Writer {
+	if c.Out == nil {
+		return os.Stdout
+	}
+	return c.Out
 }
 
 // HasVariables - check for variables in the policy
@@ -215,7 +226,7 @@ func RemoveDuplicateAndObjectVariables(matches [][]string) string {
 	return variableStr
 }
 
-func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit bool, policyResourcePath string) (map[string]string, map[string]string, map[string]map[string]Resource, map[string]map[string]string, error) {
+func GetVariable(s *store.Store, out io.Writer, variablesString, valuesFile string, fs billy.Filesystem, isGit bool, policyResourcePath string) (map[string]string, map[string]string, map[string]map[string]Resource, map[string]map[string]string, error) {
 	valuesMapResource := make(map[string]map[string]Resource)
 	valuesMapRule := make(map[string]map[string]Rule)
 	namespaceSelectorMap := make(map[string]map[string]string)
@@ -244,17 +255,17 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 		if isGit {
 			filep, err := fs.Open(filepath.Join(policyResourcePath, valuesFile))
 			if err != nil {
-				fmt.Printf("Unable to open variable file: %s. error: %s", valuesFile, err)
+				fmt.Fprintf(out, "Unable to open variable file: %s. error: %s", valuesFile, err)
 			}
 			yamlFile, err = io.ReadAll(filep)
 			if err != nil {
-				fmt.Printf("Unable to read variable files: %s. error: %s \n", filep, err)
+				fmt.Fprintf(out, "Unable to read variable files: %s. error: %s \n", filep, err)
 			}
 		} else {
 			// We accept the risk of including a user provided file here.
 			yamlFile, err = os.ReadFile(filepath.Join(policyResourcePath, valuesFile)) // #nosec G304
 			if err != nil {
-				fmt.Printf("\n Unable to open variable file: %s. error: %s \n", valuesFile, err)
+				fmt.Fprintf(out, "\n Unable to open variable file: %s. error: %s \n", valuesFile, err)
 			}
 		}
 
@@ -324,7 +335,7 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 	}
 
 	if reqObjVars != "" {
-		fmt.Printf(("\nNOTICE: request.object.* variables are automatically parsed from the supplied resource. Ignoring value of variables `%v`.\n"), reqObjVars)
+		fmt.Fprintf(out, "\nNOTICE: request.object.* variables are automatically parsed from the supplied resource. Ignoring value of variables `%v`.\n", reqObjVars)
 	}
 
 	if globalValMap != nil {
@@ -351,7 +362,7 @@ func GetVariable(variablesString, valuesFile string, fs billy.Filesystem, isGit
 		})
 	}
 
-	store.SetContext(store.Context{
+	s.SetContext(store.Context{
 		Policies: storePolicies,
 	})
 
@@ -456,7 +467,8 @@ OuterLoop:
 		WithNewResource(*updatedResource).
 		WithNamespaceLabels(namespaceLabels).
 		WithAdmissionInfo(c.UserInfo).
-		WithClient(c.Client)
+		WithClient(c.Client).
+		WithCLIStore(c.Store)
 
 	mutateResponse := engine.Mutate(registryclient.NewOrDie(), policyContext)
 	if mutateResponse != nil {
@@ -483,7 +495,7 @@ OuterLoop:
 	var validateResponse *response.EngineResponse
 	if policyHasValidate {
 		validateResponse = engine.Validate(registryclient.NewOrDie(), policyContext)
-		info = ProcessValidateEngineResponse(c.Policy, validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+		info = ProcessValidateEngineResponse(c.out(), c.Policy, validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
 	}
 
 	if validateResponse != nil && !validateResponse.IsEmpty() {
@@ -493,7 +505,7 @@ OuterLoop:
 	verifyImageResponse, _ := engine.VerifyAndPatchImages(registryclient.NewOrDie(), policyContext)
 	if verifyImageResponse != nil && !verifyImageResponse.IsEmpty() {
 		engineResponses = append(engineResponses, verifyImageResponse)
-		info = ProcessValidateEngineResponse(c.Policy, verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
+		info = ProcessValidateEngineResponse(c.out(), c.Policy, verifyImageResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
 	}
 
 	var policyHasGenerate bool
@@ -506,7 +518,7 @@ OuterLoop:
 	if policyHasGenerate {
 		generateResponse := engine.ApplyBackgroundChecks(registryclient.NewOrDie(), policyContext)
 		if generateResponse != nil && !generateResponse.IsEmpty() {
-			newRuleResponse, err := handleGeneratePolicy(generateResponse, *policyContext, c.RuleToCloneSourceResource)
+			newRuleResponse, err := handleGeneratePolicy(c.out(), generateResponse, *policyContext, c.RuleToCloneSourceResource)
 			if err != nil {
 				log.Log.Error(err, "failed to apply generate policy")
 			} else {
@@ -514,7 +526,7 @@ OuterLoop:
 			}
 			engineResponses = append(engineResponses, generateResponse)
 		}
-		updateResultCounts(c.Policy, generateResponse, resPath, c.Rc, c.AuditWarn)
+		updateResultCounts(c.out(), c.Policy, generateResponse, resPath, c.Rc, c.AuditWarn)
 	}
 
 	return engineResponses, info, nil
@@ -552,27 +564,27 @@ func PrintMutatedOutput(mutateLogPath string, mutateLogPathIsDir bool, yaml stri
 }
 
 // GetPoliciesFromPaths - get policies according to the resource path
-func GetPoliciesFromPaths(fs billy.Filesystem, dirPath []string, isGit bool, policyResourcePath string) (policies []kyvernov1.PolicyInterface, err error) {
+func GetPoliciesFromPaths(fs billy.Filesystem, out io.Writer, dirPath []string, isGit bool, policyResourcePath string) (policies []kyvernov1.PolicyInterface, err error) {
 	if isGit {
 		for _, pp := range dirPath {
 			filep, err := fs.Open(filepath.Join(policyResourcePath, pp))
 			if err != nil {
-				fmt.Printf("Error: file not available with path %s: %v", filep.Name(), err.Error())
+				fmt.Fprintf(out, "Error: file not available with path %s: %v", filep.Name(), err.Error())
 				continue
 			}
 			bytes, err := io.ReadAll(filep)
 			if err != nil {
-				fmt.Printf("Error: failed to read file %s: %v", filep.Name(), err.Error())
+				fmt.Fprintf(out, "Error: failed to read file %s: %v", filep.Name(), err.Error())
 				continue
 			}
 			policyBytes, err := yaml.ToJSON(bytes)
 			if err != nil {
-				fmt.Printf("failed to convert to JSON: %v", err)
+				fmt.Fprintf(out, "failed to convert to JSON: %v", err)
 				continue
 			}
 			policiesFromFile, errFromFile := yamlutils.GetPolicy(policyBytes)
 			if errFromFile != nil {
-				fmt.Printf("failed to process : %v", errFromFile.Error())
+				fmt.Fprintf(out, "failed to process : %v", errFromFile.Error())
 				continue
 			}
 			policies = append(policies, policiesFromFile...)
@@ -601,9 +613,9 @@ func GetPoliciesFromPaths(fs billy.Filesystem, dirPath []string, isGit bool, pol
 				return nil, sanitizederror.New(fmt.Sprintf("no file found in paths %v", dirPath))
 			}
 			if len(errors) > 0 && log.Log.V(1).Enabled() {
-				fmt.Printf("ignoring errors: \n")
+				fmt.Fprintf(out, "ignoring errors: \n")
 				for _, e := range errors {
-					fmt.Printf("    %v \n", e.Error())
+					fmt.Fprintf(out, "    %v \n", e.Error())
 				}
 			}
 		}
@@ -611,14 +623,42 @@ func GetPoliciesFromPaths(fs billy.Filesystem, dirPath []string, isGit bool, pol
 	return
 }
 
-// GetResourceAccordingToResourcePath - get resources according to the resource path
-func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []string,
+// GetCleanupPoliciesFromPaths loads the cleanup policies of the given files
+func GetCleanupPoliciesFromPaths(fs billy.Filesystem, paths []string, isGit bool, policyResourcePath string) ([]kyvernov2alpha1.CleanupPolicyInterface, error) {
+	var policies []kyvernov2alpha1.CleanupPolicyInterface
+	for _, path := range paths {
+		var policyBytes []byte
+		var err error
+		if isGit {
+			var file billy.File
+			file, err = fs.Open(filepath.Join(policyResourcePath, path))
+			if err == nil {
+				policyBytes, err = io.ReadAll(file)
+			}
+		} else {
+			policyBytes, err = getFileBytes(path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cleanup policy file %s: %v", path, err)
+		}
+		policiesFromFile, err := yamlutils.GetCleanupPolicy(policyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cleanup policies from %s: %v", path, err)
+		}
+		policies = append(policies, policiesFromFile...)
+	}
+	return policies, nil
+}
+
+// GetResourceAccordingToResourcePath - get resources according to the resource path, with the files of the
+// resources loaded from local paths
+func GetResourceAccordingToResourcePath(fs billy.Filesystem, out io.Writer, resourcePaths []string,
 	cluster bool, policies []kyvernov1.PolicyInterface, dClient dclient.Interface, namespace string, policyReport bool, isGit bool, policyResourcePath string,
-) (resources []*unstructured.Unstructured, err error) {
+) (resources []*unstructured.Unstructured, files ResourceFiles, err error) {
 	if isGit {
-		resources, err = GetResourcesWithTest(fs, policies, resourcePaths, isGit, policyResourcePath)
+		resources, err = GetResourcesWithTest(fs, out, policies, resourcePaths, isGit, policyResourcePath)
 		if err != nil {
-			return nil, sanitizederror.NewWithError("failed to extract the resources", err)
+			return nil, nil, sanitizederror.NewWithError("failed to extract the resources", err)
 		}
 	} else {
 		if len(resourcePaths) > 0 && resourcePaths[0] == "-" {
@@ -632,19 +672,19 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 				yamlBytes := []byte(resourceStr)
 				resources, err = GetResource(yamlBytes)
 				if err != nil {
-					return nil, sanitizederror.NewWithError("failed to extract the resources", err)
+					return nil, nil, sanitizederror.NewWithError("failed to extract the resources", err)
 				}
 			}
 		} else {
 			if len(resourcePaths) > 0 {
 				fileDesc, err := os.Stat(resourcePaths[0])
 				if err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 				if fileDesc.IsDir() {
 					files, err := os.ReadDir(resourcePaths[0])
 					if err != nil {
-						return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to parse %v", resourcePaths[0]), err)
+						return nil, nil, sanitizederror.NewWithError(fmt.Sprintf("failed to parse %v", resourcePaths[0]), err)
 					}
 					listOfFiles := make([]string, 0)
 					for _, file := range files {
@@ -657,16 +697,16 @@ func GetResourceAccordingToResourcePath(fs billy.Filesystem, resourcePaths []str
 				}
 			}
 
-			resources, err = GetResources(policies, resourcePaths, dClient, cluster, namespace, policyReport)
+			resources, files, err = GetResources(out, policies, resourcePaths, dClient, cluster, namespace, policyReport)
 			if err != nil {
-				return resources, err
+				return resources, files, err
 			}
 		}
 	}
-	return resources, err
+	return resources, files, err
 }
 
-func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool, auditWarn bool) Info {
+func ProcessValidateEngineResponse(out io.Writer, policy kyvernov1.PolicyInterface, validateResponse *response.EngineResponse, resPath string, rc *ResultCounts, policyReport bool, auditWarn bool) Info {
 	var violatedRules []kyvernov1.ViolatedRule
 
 	printCount := 0
@@ -709,14 +749,14 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 					if !policyReport {
 						if printCount < 1 {
 							if auditWarning {
-								fmt.Printf("\npolicy %s -> resource %s failed as audit warning: \n", policy.GetName(), resPath)
+								fmt.Fprintf(out, "\npolicy %s -> resource %s failed as audit warning: \n", policy.GetName(), resPath)
 							} else {
-								fmt.Printf("\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
+								fmt.Fprintf(out, "\npolicy %s -> resource %s failed: \n", policy.GetName(), resPath)
 							}
 							printCount++
 						}
 
-						fmt.Printf("%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
+						fmt.Fprintf(out, "%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
 					}
 
 				case response.RuleStatusError:
@@ -762,10 +802,13 @@ func buildPVInfo(er *response.EngineResponse, violatedRules []kyvernov1.Violated
 			},
 		},
 	}
+	if er.Policy != nil {
+		info.Annotations = er.Policy.GetAnnotations()
+	}
 	return info
 }
 
-func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
+func updateResultCounts(out io.Writer, policy kyvernov1.PolicyInterface, engineResponse *response.EngineResponse, resPath string, rc *ResultCounts, auditWarn bool) {
 	printCount := 0
 	for _, policyRule := range autogen.ComputeRules(policy) {
 		ruleFoundInEngineResponse := false
@@ -777,10 +820,10 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *respon
 					rc.Pass++
 				} else {
 					if printCount < 1 {
-						fmt.Println("\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
+						fmt.Fprintln(out, "\ninvalid resource", "policy", policy.GetName(), "resource", resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
+					fmt.Fprintf(out, "%d. %s - %s\n", i+1, ruleResponse.Name, ruleResponse.Message)
 
 					if auditWarn && engineResponse.GetValidationFailureAction().Audit() {
 						rc.Warn++
@@ -798,7 +841,7 @@ func updateResultCounts(policy kyvernov1.PolicyInterface, engineResponse *respon
 	}
 }
 
-func SetInStoreContext(mutatedPolicies []kyvernov1.PolicyInterface, variables map[string]string) map[string]string {
+func SetInStoreContext(s *store.Store, mutatedPolicies []kyvernov1.PolicyInterface, variables map[string]string) map[string]string {
 	storePolicies := make([]store.Policy, 0)
 	for _, policy := range mutatedPolicies {
 		storeRules := make([]store.Rule, 0)
@@ -825,7 +868,7 @@ func SetInStoreContext(mutatedPolicies []kyvernov1.PolicyInterface, variables ma
 		})
 	}
 
-	store.SetContext(store.Context{
+	s.SetContext(store.Context{
 		Policies: storePolicies,
 	})
 
@@ -854,17 +897,17 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 					c.Rc.Pass++
 					printMutatedRes = true
 				} else if mutateResponseRule.Status == response.RuleStatusSkip {
-					fmt.Printf("\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.out(), "\nskipped mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 					c.Rc.Skip++
 				} else if mutateResponseRule.Status == response.RuleStatusError {
-					fmt.Printf("\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
+					fmt.Fprintf(c.out(), "\nerror while applying mutate policy %s -> resource %s\nerror: %s", c.Policy.GetName(), resPath, mutateResponseRule.Message)
 					c.Rc.Error++
 				} else {
 					if printCount < 1 {
-						fmt.Printf("\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
+						fmt.Fprintf(c.out(), "\nfailed to apply mutate policy %s -> resource %s", c.Policy.GetName(), resPath)
 						printCount++
 					}
-					fmt.Printf("%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
+					fmt.Fprintf(c.out(), "%d. %s - %s \n", i+1, mutateResponseRule.Name, mutateResponseRule.Message)
 					c.Rc.Fail++
 				}
 				continue
@@ -885,16 +928,16 @@ func processMutateEngineResponse(c ApplyPolicyConfig, mutateResponse *response.E
 			mutatedResource := string(yamlEncodedResource) + string("\n---")
 			if len(strings.TrimSpace(mutatedResource)) > 0 {
 				if !c.Stdin {
-					fmt.Printf("\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
+					fmt.Fprintf(c.out(), "\nmutate policy %s applied to %s:", c.Policy.GetName(), resPath)
 				}
-				fmt.Printf("\n" + mutatedResource + "\n")
+				fmt.Fprintf(c.out(), "\n"+mutatedResource+"\n")
 			}
 		} else {
 			err := PrintMutatedOutput(c.MutateLogPath, c.MutateLogPathIsDir, string(yamlEncodedResource), c.Resource.GetName()+"-mutated")
 			if err != nil {
 				return sanitizederror.NewWithError("failed to print mutated result", err)
 			}
-			fmt.Printf("\n\nMutation:\nMutation has been applied successfully. Check the files.")
+			fmt.Fprintf(c.out(), "\n\nMutation:\nMutation has been applied successfully. Check the files.")
 		}
 	}
 
@@ -912,7 +955,7 @@ func PrintMutatedPolicy(mutatedPolicies []kyvernov1.PolicyInterface) error {
 	return nil
 }
 
-func CheckVariableForPolicy(valuesMap map[string]map[string]Resource, globalValMap map[string]string, policyName string, resourceName string, resourceKind string, variables map[string]string, kindOnwhichPolicyIsApplied map[string]struct{}, variable string) (map[string]interface{}, error) {
+func CheckVariableForPolicy(s *store.Store, valuesMap map[string]map[string]Resource, globalValMap map[string]string, policyName string, resourceName string, resourceKind string, variables map[string]string, kindOnwhichPolicyIsApplied map[string]struct{}, variable string) (map[string]interface{}, error) {
 	// get values from file for this policy resource combination
 	thisPolicyResourceValues := make(map[string]interface{})
 	if len(valuesMap[policyName]) != 0 && !reflect.DeepEqual(valuesMap[policyName][resourceName], Resource{}) {
@@ -935,7 +978,7 @@ func CheckVariableForPolicy(valuesMap map[string]map[string]Resource, globalValM
 
 	// skipping the variable check for non matching kind
 	if _, ok := kindOnwhichPolicyIsApplied[resourceKind]; ok {
-		if len(variable) > 0 && len(thisPolicyResourceValues) == 0 && len(store.GetContext().Policies) == 0 {
+		if len(variable) > 0 && len(thisPolicyResourceValues) == 0 && len(s.GetContext().Policies) == 0 {
 			return thisPolicyResourceValues, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using set/values_file flag", policyName, resourceName), nil)
 		}
 	}
@@ -956,7 +999,7 @@ func GetKindsFromPolicy(policy kyvernov1.PolicyInterface) map[string]struct{} {
 }
 
 // GetResourceFromPath - get patchedResource and generatedResource from given path
-func GetResourceFromPath(fs billy.Filesystem, path string, isGit bool, policyResourcePath string, resourceType string) (unstructured.Unstructured, error) {
+func GetResourceFromPath(fs billy.Filesystem, out io.Writer, path string, isGit bool, policyResourcePath string, resourceType string) (unstructured.Unstructured, error) {
 	var resourceBytes []byte
 	var resource unstructured.Unstructured
 	var err error
@@ -964,7 +1007,7 @@ func GetResourceFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 		if len(path) > 0 {
 			filep, fileErr := fs.Open(filepath.Join(policyResourcePath, path))
 			if fileErr != nil {
-				fmt.Printf("Unable to open %s file: %s. \nerror: %s", resourceType, path, err)
+				fmt.Fprintf(out, "Unable to open %s file: %s. \nerror: %s", resourceType, path, err)
 			}
 			resourceBytes, err = io.ReadAll(filep)
 		}
@@ -973,7 +1016,7 @@ func GetResourceFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 	}
 
 	if err != nil {
-		fmt.Printf("\n----------------------------------------------------------------------\nfailed to load %s: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourceType, path, err)
+		fmt.Fprintf(out, "\n----------------------------------------------------------------------\nfailed to load %s: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourceType, path, err)
 		return resource, err
 	}
 
@@ -986,10 +1029,10 @@ func GetResourceFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 }
 
 // initializeMockController initializes a basic Generate Controller with a fake dynamic client.
-func initializeMockController(objects []runtime.Object) (*generate.GenerateController, error) {
+func initializeMockController(out io.Writer, objects []runtime.Object) (*generate.GenerateController, error) {
 	client, err := dclient.NewFakeClient(runtime.NewScheme(), nil, objects...)
 	if err != nil {
-		fmt.Printf("Failed to mock dynamic client")
+		fmt.Fprintf(out, "Failed to mock dynamic client")
 		return nil, err
 	}
 
@@ -999,7 +1042,7 @@ func initializeMockController(objects []runtime.Object) (*generate.GenerateContr
 }
 
 // handleGeneratePolicy returns a new RuleResponse with the Kyverno generated resource configuration by applying the generate rule.
-func handleGeneratePolicy(generateResponse *response.EngineResponse, policyContext engine.PolicyContext, ruleToCloneSourceResource map[string]string) ([]response.RuleResponse, error) {
+func handleGeneratePolicy(out io.Writer, generateResponse *response.EngineResponse, policyContext engine.PolicyContext, ruleToCloneSourceResource map[string]string) ([]response.RuleResponse, error) {
 	resource := policyContext.NewResource()
 	objects := []runtime.Object{&resource}
 	resources := []*unstructured.Unstructured{}
@@ -1007,11 +1050,11 @@ func handleGeneratePolicy(generateResponse *response.EngineResponse, policyConte
 		if path, ok := ruleToCloneSourceResource[rule.Name]; ok {
 			resourceBytes, err := getFileBytes(path)
 			if err != nil {
-				fmt.Printf("failed to get resource bytes\n")
+				fmt.Fprintf(out, "failed to get resource bytes\n")
 			} else {
 				resources, err = GetResource(resourceBytes)
 				if err != nil {
-					fmt.Printf("failed to convert resource bytes to unstructured format\n")
+					fmt.Fprintf(out, "failed to convert resource bytes to unstructured format\n")
 				}
 			}
 		}
@@ -1021,9 +1064,9 @@ func handleGeneratePolicy(generateResponse *response.EngineResponse, policyConte
 		objects = append(objects, res)
 	}
 
-	c, err := initializeMockController(objects)
+	c, err := initializeMockController(out, objects)
 	if err != nil {
-		fmt.Println("error at controller")
+		fmt.Fprintln(out, "error at controller")
 		return nil, err
 	}
 
@@ -1063,33 +1106,33 @@ func handleGeneratePolicy(generateResponse *response.EngineResponse, policyConte
 }
 
 // GetUserInfoFromPath - get the request info as user info from a given path
-func GetUserInfoFromPath(fs billy.Filesystem, path string, isGit bool, policyResourcePath string) (kyvernov1beta1.RequestInfo, store.Subject, error) {
+func GetUserInfoFromPath(fs billy.Filesystem, out io.Writer, path string, isGit bool, policyResourcePath string) (kyvernov1beta1.RequestInfo, store.Subject, error) {
 	userInfo := &kyvernov1beta1.RequestInfo{}
 	subjectInfo := &store.Subject{}
 	if isGit {
 		filep, err := fs.Open(filepath.Join(policyResourcePath, path))
 		if err != nil {
-			fmt.Printf("Unable to open userInfo file: %s. \nerror: %s", path, err)
+			fmt.Fprintf(out, "Unable to open userInfo file: %s. \nerror: %s", path, err)
 		}
 		bytes, err := io.ReadAll(filep)
 		if err != nil {
-			fmt.Printf("Error: failed to read file %s: %v", filep.Name(), err.Error())
+			fmt.Fprintf(out, "Error: failed to read file %s: %v", filep.Name(), err.Error())
 		}
 		userInfoBytes, err := yaml.ToJSON(bytes)
 		if err != nil {
-			fmt.Printf("failed to convert to JSON: %v", err)
+			fmt.Fprintf(out, "failed to convert to JSON: %v", err)
 		}
 
 		if err := json.Unmarshal(userInfoBytes, userInfo); err != nil {
-			fmt.Printf("failed to decode yaml: %v", err)
+			fmt.Fprintf(out, "failed to decode yaml: %v", err)
 		}
 		subjectBytes, err := yaml.ToJSON(bytes)
 		if err != nil {
-			fmt.Printf("failed to convert to JSON: %v", err)
+			fmt.Fprintf(out, "failed to convert to JSON: %v", err)
 		}
 
 		if err := json.Unmarshal(subjectBytes, subjectInfo); err != nil {
-			fmt.Printf("failed to decode yaml: %v", err)
+			fmt.Fprintf(out, "failed to decode yaml: %v", err)
 		}
 	} else {
 		var errors []error
@@ -1113,9 +1156,9 @@ func GetUserInfoFromPath(fs billy.Filesystem, path string, isGit bool, policyRes
 		}
 
 		if len(errors) > 0 && log.Log.V(1).Enabled() {
-			fmt.Printf("ignoring errors: \n")
+			fmt.Fprintf(out, "ignoring errors: \n")
 			for _, e := range errors {
-				fmt.Printf("    %v \n", e.Error())
+				fmt.Fprintf(out, "    %v \n", e.Error())
 			}
 		}
 	}
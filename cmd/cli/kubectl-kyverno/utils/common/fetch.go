@@ -25,12 +25,17 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+// ResourceFiles maps the resources loaded from local files to the path of their file
+type ResourceFiles map[*unstructured.Unstructured]string
+
 // GetResources gets matched resources by the given policies
 // the resources are fetched from
 // - local paths to resources, if given
 // - the k8s cluster, if given
-func GetResources(policies []kyvernov1.PolicyInterface, resourcePaths []string, dClient dclient.Interface, cluster bool, namespace string, policyReport bool) ([]*unstructured.Unstructured, error) {
+// the files of the resources loaded from local paths are returned
+func GetResources(out io.Writer, policies []kyvernov1.PolicyInterface, resourcePaths []string, dClient dclient.Interface, cluster bool, namespace string, policyReport bool) ([]*unstructured.Unstructured, ResourceFiles, error) {
 	resources := make([]*unstructured.Unstructured, 0)
+	var files ResourceFiles
 	var err error
 	resourceTypesMap := make(map[string]bool)
 	var resourceTypes []string
@@ -49,20 +54,20 @@ func GetResources(policies []kyvernov1.PolicyInterface, resourcePaths []string,
 	}
 
 	if cluster && dClient != nil {
-		resources, err = whenClusterIsTrue(resourceTypes, dClient, namespace, resourcePaths, policyReport)
+		resources, err = whenClusterIsTrue(out, resourceTypes, dClient, namespace, resourcePaths, policyReport)
 		if err != nil {
-			return resources, err
+			return resources, nil, err
 		}
 	} else if len(resourcePaths) > 0 {
-		resources, err = whenClusterIsFalse(resourcePaths, policyReport)
+		resources, files, err = whenClusterIsFalse(out, resourcePaths, policyReport)
 		if err != nil {
-			return resources, err
+			return resources, files, err
 		}
 	}
-	return resources, err
+	return resources, files, err
 }
 
-func whenClusterIsTrue(resourceTypes []string, dClient dclient.Interface, namespace string, resourcePaths []string, policyReport bool) ([]*unstructured.Unstructured, error) {
+func whenClusterIsTrue(out io.Writer, resourceTypes []string, dClient dclient.Interface, namespace string, resourcePaths []string, policyReport bool) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	resourceMap, err := getResourcesOfTypeFromCluster(resourceTypes, dClient, namespace)
 	if err != nil {
@@ -87,7 +92,7 @@ func whenClusterIsTrue(resourceTypes []string, dClient dclient.Interface, namesp
 				if policyReport {
 					log.Log.V(3).Info(fmt.Sprintf("%s not found in cluster", resourcePath))
 				} else {
-					fmt.Printf("\n----------------------------------------------------------------------\nresource %s not found in cluster\n----------------------------------------------------------------------\n", resourcePath)
+					fmt.Fprintf(out, "\n----------------------------------------------------------------------\nresource %s not found in cluster\n----------------------------------------------------------------------\n", resourcePath)
 				}
 				return nil, fmt.Errorf("%s not found in cluster", resourcePath)
 			}
@@ -96,31 +101,35 @@ func whenClusterIsTrue(resourceTypes []string, dClient dclient.Interface, namesp
 	return resources, nil
 }
 
-func whenClusterIsFalse(resourcePaths []string, policyReport bool) ([]*unstructured.Unstructured, error) {
+func whenClusterIsFalse(out io.Writer, resourcePaths []string, policyReport bool) ([]*unstructured.Unstructured, ResourceFiles, error) {
 	resources := make([]*unstructured.Unstructured, 0)
+	files := ResourceFiles{}
 	for _, resourcePath := range resourcePaths {
 		resourceBytes, err := getFileBytes(resourcePath)
 		if err != nil {
 			if policyReport {
 				log.Log.V(3).Info(fmt.Sprintf("failed to load resources: %s.", resourcePath), "error", err)
 			} else {
-				fmt.Printf("\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
+				fmt.Fprintf(out, "\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
 			}
 			continue
 		}
 
 		getResources, err := GetResource(resourceBytes)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
+		for _, resource := range getResources {
+			files[resource] = resourcePath
+		}
 		resources = append(resources, getResources...)
 	}
-	return resources, nil
+	return resources, files, nil
 }
 
 // GetResourcesWithTest with gets matched resources by the given policies
-func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterface, resourcePaths []string, isGit bool, policyResourcePath string) ([]*unstructured.Unstructured, error) {
+func GetResourcesWithTest(fs billy.Filesystem, out io.Writer, policies []kyvernov1.PolicyInterface, resourcePaths []string, isGit bool, policyResourcePath string) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	resourceTypesMap := make(map[string]bool)
 	for _, policy := range policies {
@@ -137,7 +146,7 @@ func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterf
 			if isGit {
 				filep, err := fs.Open(filepath.Join(policyResourcePath, resourcePath))
 				if err != nil {
-					fmt.Printf("Unable to open resource file: %s. error: %s", resourcePath, err)
+					fmt.Fprintf(out, "Unable to open resource file: %s. error: %s", resourcePath, err)
 					continue
 				}
 				resourceBytes, _ = io.ReadAll(filep)
@@ -145,7 +154,7 @@ func GetResourcesWithTest(fs billy.Filesystem, policies []kyvernov1.PolicyInterf
 				resourceBytes, err = getFileBytes(resourcePath)
 			}
 			if err != nil {
-				fmt.Printf("\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
+				fmt.Fprintf(out, "\n----------------------------------------------------------------------\nfailed to load resources: %s. \nerror: %s\n----------------------------------------------------------------------\n", resourcePath, err)
 				continue
 			}
 
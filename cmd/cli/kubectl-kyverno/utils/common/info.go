@@ -7,10 +7,12 @@ import (
 
 // Info stores the policy application results for all matched resources
 // Namespace is set to empty "" if resource is cluster wide resource
+// Annotations are the annotations of the policy
 type Info struct {
-	PolicyName string
-	Namespace  string
-	Results    []EngineResponseResult
+	PolicyName  string
+	Namespace   string
+	Annotations map[string]string
+	Results     []EngineResponseResult
 }
 
 type EngineResponseResult struct {
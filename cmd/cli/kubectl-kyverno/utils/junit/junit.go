@@ -0,0 +1,97 @@
+package junit
+
+import (
+	"encoding/xml"
+)
+
+// TestSuites is the root element of a JUnit XML report
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr,omitempty"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+// TestSuite groups test cases
+type TestSuite struct {
+	Name       string      `xml:"name,attr"`
+	Tests      int         `xml:"tests,attr"`
+	Failures   int         `xml:"failures,attr"`
+	Errors     int         `xml:"errors,attr"`
+	Skipped    int         `xml:"skipped,attr"`
+	Properties *Properties `xml:"properties,omitempty"`
+	Cases      []TestCase  `xml:"testcase"`
+}
+
+// Properties holds the properties of a test suite or a test case
+type Properties struct {
+	Properties []Property `xml:"property"`
+}
+
+// Property is a name/value pair attached to a test suite or a test case
+type Property struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// TestCase is a single test, it passed if it has no failure, error or skipped element
+type TestCase struct {
+	Name       string      `xml:"name,attr"`
+	ClassName  string      `xml:"classname,attr"`
+	Properties *Properties `xml:"properties,omitempty"`
+	Failure    *Result     `xml:"failure,omitempty"`
+	Error      *Result     `xml:"error,omitempty"`
+	Skipped    *Result     `xml:"skipped,omitempty"`
+	SystemOut  string      `xml:"system-out,omitempty"`
+}
+
+// Result describes a failed, errored or skipped test case
+type Result struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// AddProperty adds a property to the suite
+func (s *TestSuite) AddProperty(name, value string) {
+	if s.Properties == nil {
+		s.Properties = &Properties{}
+	}
+	s.Properties.Properties = append(s.Properties.Properties, Property{Name: name, Value: value})
+}
+
+// AddCase adds a test case to the suite and updates the suite counters
+func (s *TestSuite) AddCase(testCase TestCase) {
+	s.Cases = append(s.Cases, testCase)
+	s.Tests++
+	if testCase.Failure != nil {
+		s.Failures++
+	}
+	if testCase.Error != nil {
+		s.Errors++
+	}
+	if testCase.Skipped != nil {
+		s.Skipped++
+	}
+}
+
+// AddSuite adds a test suite and updates the counters
+func (s *TestSuites) AddSuite(suite TestSuite) {
+	s.Suites = append(s.Suites, suite)
+	s.Tests += suite.Tests
+	s.Failures += suite.Failures
+	s.Errors += suite.Errors
+	s.Skipped += suite.Skipped
+}
+
+// Marshal returns the XML document of the report
+func (s *TestSuites) Marshal() ([]byte, error) {
+	data, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
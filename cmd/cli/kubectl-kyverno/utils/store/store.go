@@ -2,58 +2,66 @@ package store
 
 import (
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
 
-var (
-	Mock           bool
+// Store holds the state of a CLI run, the engine uses it to mock external data
+// when policies are applied offline. Each run has its own store so that runs can
+// be executed concurrently. A nil store is never in mock mode.
+type Store struct {
+	mock           bool
 	registryClient registryclient.Client
-	AllowApiCalls  bool
-	ContextVar     Context
-	ForeachElement int
-	Subjects       Subject
-)
+	allowApiCalls  bool
+	context        Context
+	foreachElement int
+	subjects       Subject
+	fixtures       Fixtures
+}
 
-func SetMock(mock bool) {
-	Mock = mock
+func (s *Store) SetMock(mock bool) {
+	s.mock = mock
 }
 
-func GetMock() bool {
-	return Mock
+func (s *Store) GetMock() bool {
+	return s != nil && s.mock
 }
 
-func SetForeachElement(foreachElement int) {
-	ForeachElement = foreachElement
+// SetForeachElement is a no-op on a nil store
+func (s *Store) SetForeachElement(foreachElement int) {
+	if s != nil {
+		s.foreachElement = foreachElement
+	}
 }
 
-func GetForeachElement() int {
-	return ForeachElement
+func (s *Store) GetForeachElement() int {
+	return s.foreachElement
 }
 
-func SetRegistryAccess(access bool) {
+func (s *Store) SetRegistryAccess(access bool) {
 	if access {
-		registryClient = registryclient.NewOrDie(registryclient.WithLocalKeychain())
+		s.registryClient = registryclient.NewOrDie(registryclient.WithLocalKeychain())
 	}
 }
 
-func GetRegistryAccess() bool {
-	return registryClient != nil
+func (s *Store) GetRegistryAccess() bool {
+	return s.registryClient != nil
 }
 
-func GetRegistryClient() registryclient.Client {
-	return registryClient
+func (s *Store) GetRegistryClient() registryclient.Client {
+	return s.registryClient
 }
 
-func SetContext(context Context) {
-	ContextVar = context
+func (s *Store) SetContext(context Context) {
+	s.context = context
 }
 
-func GetContext() Context {
-	return ContextVar
+func (s *Store) GetContext() Context {
+	return s.context
 }
 
-func GetPolicyFromContext(policyName string) *Policy {
-	for _, policy := range ContextVar.Policies {
+func (s *Store) GetPolicyFromContext(policyName string) *Policy {
+	for _, policy := range s.context.Policies {
 		if policy.Name == policyName {
 			return &policy
 		}
@@ -61,8 +69,8 @@ func GetPolicyFromContext(policyName string) *Policy {
 	return nil
 }
 
-func GetPolicyRuleFromContext(policyName string, ruleName string) *Rule {
-	for _, policy := range ContextVar.Policies {
+func (s *Store) GetPolicyRuleFromContext(policyName string, ruleName string) *Rule {
+	for _, policy := range s.context.Policies {
 		if policy.Name == policyName {
 			for _, rule := range policy.Rules {
 				if rule.Name == ruleName {
@@ -89,22 +97,85 @@ type Rule struct {
 	ForeachValues map[string][]interface{} `json:"foreachValues"`
 }
 
-func SetSubjects(subjects Subject) {
-	Subjects = subjects
+func (s *Store) SetSubjects(subjects Subject) {
+	s.subjects = subjects
 }
 
-func GetSubjects() Subject {
-	return Subjects
+func (s *Store) GetSubjects() Subject {
+	return s.subjects
 }
 
 type Subject struct {
 	Subject rbacv1.Subject `json:"subject,omitempty" yaml:"subject,omitempty"`
 }
 
-func AllowApiCall(allow bool) {
-	AllowApiCalls = allow
+func (s *Store) AllowApiCall(allow bool) {
+	s.allowApiCalls = allow
+}
+
+func (s *Store) IsAllowApiCall() bool {
+	return s.allowApiCalls
+}
+
+// Fixtures contains the external data served to context entries in mock mode
+type Fixtures struct {
+	// APICalls are the raw API server responses, keyed by url path
+	APICalls map[string][]byte
+	// ServiceCalls are the raw service responses, keyed by url
+	ServiceCalls map[string][]byte
+	// GlobalContextEntries are the raw data of global context entries, keyed by name
+	GlobalContextEntries map[string][]byte
+	// ConfigMaps are the config maps, keyed by namespace/name
+	ConfigMaps map[string]*corev1.ConfigMap
+	// Images are the image registry data, keyed by image reference
+	Images map[string]interface{}
+}
+
+func (s *Store) SetFixtures(f Fixtures) {
+	s.fixtures = f
+}
+
+func (s *Store) HasAPICallFixtures() bool {
+	return len(s.fixtures.APICalls) > 0
+}
+
+func (s *Store) GetAPICallFixture(urlPath string) ([]byte, bool) {
+	data, ok := s.fixtures.APICalls[urlPath]
+	return data, ok
+}
+
+func (s *Store) HasServiceCallFixtures() bool {
+	return len(s.fixtures.ServiceCalls) > 0
+}
+
+func (s *Store) GetServiceCallFixture(url string) ([]byte, bool) {
+	data, ok := s.fixtures.ServiceCalls[url]
+	return data, ok
+}
+
+func (s *Store) HasGlobalContextFixtures() bool {
+	return len(s.fixtures.GlobalContextEntries) > 0
+}
+
+func (s *Store) GetGlobalContextFixture(name string) ([]byte, bool) {
+	data, ok := s.fixtures.GlobalContextEntries[name]
+	return data, ok
+}
+
+func (s *Store) HasConfigMapFixtures() bool {
+	return len(s.fixtures.ConfigMaps) > 0
+}
+
+func (s *Store) GetConfigMapFixture(namespace, name string) (*corev1.ConfigMap, bool) {
+	cm, ok := s.fixtures.ConfigMaps[namespace+"/"+name]
+	return cm, ok
+}
+
+func (s *Store) HasImageFixtures() bool {
+	return len(s.fixtures.Images) > 0
 }
 
-func IsAllowApiCall() bool {
-	return AllowApiCalls
+func (s *Store) GetImageFixture(reference string) (interface{}, bool) {
+	data, ok := s.fixtures.Images[reference]
+	return data, ok
 }
@@ -7,12 +7,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/cmd/internal"
+	"github.com/kyverno/kyverno/pkg/apicallcache"
 	"github.com/kyverno/kyverno/pkg/background"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
@@ -24,6 +26,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	configcontroller "github.com/kyverno/kyverno/pkg/controllers/config"
+	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
+	imageverifycontroller "github.com/kyverno/kyverno/pkg/controllers/imageverify"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
@@ -35,6 +39,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	event "github.com/kyverno/kyverno/pkg/event"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -47,6 +53,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/utils"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
@@ -80,12 +87,26 @@ func setupRegistryClient(logger logr.Logger, lister corev1listers.SecretNamespac
 	return registryclient.New(registryOptions...)
 }
 
-func setupCosign(logger logr.Logger, imageSignatureRepository string) {
+func setupCosign(ctx context.Context, logger logr.Logger, imageSignatureRepository string, tufMirror string, tufRoot string) error {
 	logger = logger.WithName("cosign")
-	logger.Info("setup cosign...", "repository", imageSignatureRepository)
+	logger.Info("setup cosign...", "repository", imageSignatureRepository, "tufMirror", tufMirror, "tufRoot", tufRoot)
 	if imageSignatureRepository != "" {
 		cosign.ImageSignatureRepository = imageSignatureRepository
 	}
+	if tufMirror != "" || tufRoot != "" {
+		var root []byte
+		if tufRoot != "" {
+			data, err := os.ReadFile(filepath.Clean(tufRoot))
+			if err != nil {
+				return err
+			}
+			root = data
+		}
+		if err := cosign.InitializeTUF(ctx, tufMirror, root); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func showWarnings(logger logr.Logger, splitPolicyReport bool) {
@@ -100,6 +121,24 @@ func showWarnings(logger logr.Logger, splitPolicyReport bool) {
 	}
 }
 
+func setupImageVerifyCache(logger logr.Logger, enabled bool, maxSize int, ttl time.Duration, metricsConfig metrics.MetricsConfigManager) imageverifycache.Cache {
+	logger = logger.WithName("image-verify-cache")
+	logger.Info("setup image verify cache...", "enabled", enabled, "maxSize", maxSize, "ttl", ttl)
+	if !enabled {
+		return nil
+	}
+	return imageverifycache.New(maxSize, ttl, metricsConfig)
+}
+
+func setupAPICallCache(logger logr.Logger, enabled bool, maxSize int, ttl time.Duration) apicallcache.Cache {
+	logger = logger.WithName("apicall-cache")
+	logger.Info("setup apicall cache...", "enabled", enabled, "maxSize", maxSize, "ttl", ttl)
+	if !enabled {
+		return nil
+	}
+	return apicallcache.New(maxSize, ttl)
+}
+
 func sanityChecks(dynamicClient dclient.Interface) error {
 	if !utils.CRDsInstalled(dynamicClient.Discovery()) {
 		return fmt.Errorf("CRDs not installed")
@@ -118,8 +157,10 @@ func createNonLeaderControllers(
 	rclient registryclient.Client,
 	configuration config.Configuration,
 	policyCache policycache.Cache,
+	globalContext globalcontextstore.Store,
 	eventGenerator event.Interface,
 	manager openapi.Manager,
+	isLeader func() bool,
 ) ([]internal.Controller, func() error) {
 	policyCacheController := policycachecontroller.NewController(
 		policyCache,
@@ -145,12 +186,21 @@ func createNonLeaderControllers(
 		kubeKyvernoInformer.Core().V1().Pods(),
 		eventGenerator,
 		configuration,
+		globalContext,
+	)
+	globalContextController := globalcontextcontroller.NewController(
+		kyvernoClient,
+		dynamicClient,
+		kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
+		globalContext,
+		isLeader,
 	)
 	return []internal.Controller{
 			internal.NewController(policycachecontroller.ControllerName, policyCacheController, policycachecontroller.Workers),
 			internal.NewController(openapicontroller.ControllerName, openApiController, openapicontroller.Workers),
 			internal.NewController(configcontroller.ControllerName, configurationController, configcontroller.Workers),
 			internal.NewController("update-request-controller", updateRequestController, genWorkers),
+			internal.NewController(globalcontextcontroller.ControllerName, globalContextController, globalcontextcontroller.Workers),
 		},
 		func() error {
 			return policyCacheController.WarmUp()
@@ -165,6 +215,9 @@ func createReportControllers(
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	rclient registryclient.Client,
+	imageVerifyCache imageverifycache.Cache,
+	apiCallCache apicallcache.Cache,
+	globalContext globalcontextstore.Store,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
@@ -172,6 +225,7 @@ func createReportControllers(
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
 	kyvernoV1 := kyvernoInformer.Kyverno().V1()
+	kyvernoV2alpha1 := kyvernoInformer.Kyverno().V2alpha1()
 	if backgroundScan || admissionReports {
 		resourceReportController := resourcereportcontroller.NewController(
 			client,
@@ -219,8 +273,12 @@ func createReportControllers(
 					metadataFactory,
 					kyvernoV1.Policies(),
 					kyvernoV1.ClusterPolicies(),
+					kyvernoV2alpha1.PolicyExceptions(),
 					kubeInformer.Core().V1().Namespaces(),
 					resourceReportController,
+					imageVerifyCache,
+					apiCallCache,
+					globalContext,
 				),
 				backgroundScanWorkers,
 			))
@@ -241,6 +299,7 @@ func createrLeaderControllers(
 	admissionReports bool,
 	reportsChunkSize int,
 	backgroundScanWorkers int,
+	imageVerifyInterval time.Duration,
 	serverIP string,
 	webhookTimeout int,
 	autoUpdateWebhooks bool,
@@ -252,6 +311,9 @@ func createrLeaderControllers(
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
 	rclient registryclient.Client,
+	imageVerifyCache imageverifycache.Cache,
+	apiCallCache apicallcache.Cache,
+	globalContext globalcontextstore.Store,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	eventGenerator event.Interface,
@@ -307,10 +369,33 @@ func createrLeaderControllers(
 		dynamicClient,
 		kyvernoClient,
 		rclient,
+		imageVerifyCache,
+		apiCallCache,
+		globalContext,
 		metadataInformer,
 		kubeInformer,
 		kyvernoInformer,
 	)
+	if imageVerifyInterval > 0 {
+		reportControllers = append(reportControllers, internal.NewController(
+			imageverifycontroller.ControllerName,
+			imageverifycontroller.NewController(
+				dynamicClient,
+				kyvernoClient,
+				rclient,
+				kyvernoInformer.Kyverno().V1().Policies(),
+				kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+				kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions(),
+				kubeInformer.Core().V1().Pods(),
+				kubeInformer.Core().V1().Namespaces(),
+				apiCallCache,
+				globalContext,
+				eventGenerator,
+				imageVerifyInterval,
+			),
+			imageverifycontroller.Workers,
+		))
+	}
 	return append(
 			[]internal.Controller{
 				internal.NewController("policy-controller", policyCtrl, 2),
@@ -334,14 +419,23 @@ func main() {
 		autoUpdateWebhooks         bool
 		imagePullSecrets           string
 		imageSignatureRepository   string
+		tufMirror                  string
+		tufRoot                    string
 		allowInsecureRegistry      bool
 		webhookRegistrationTimeout time.Duration
 		backgroundScan             bool
 		admissionReports           bool
 		reportsChunkSize           int
 		backgroundScanWorkers      int
+		imageVerifyInterval        time.Duration
 		dumpPayload                bool
 		leaderElectionRetryPeriod  time.Duration
+		imageVerifyCacheEnabled    bool
+		imageVerifyCacheTTL        time.Duration
+		imageVerifyCacheMaxSize    int
+		apiCallCacheEnabled        bool
+		apiCallCacheTTL            time.Duration
+		apiCallCacheMaxSize        int
 		// DEPRECATED: remove in 1.9
 		splitPolicyReport bool
 	)
@@ -353,6 +447,8 @@ func main() {
 	flagset.StringVar(&serverIP, "serverIP", "", "IP address where Kyverno controller runs. Only required if out-of-cluster.")
 	flagset.StringVar(&imagePullSecrets, "imagePullSecrets", "", "Secret resource names for image registry access credentials.")
 	flagset.StringVar(&imageSignatureRepository, "imageSignatureRepository", "", "Alternate repository for image signatures. Can be overridden per rule via `verifyImages.Repository`.")
+	flagset.StringVar(&tufMirror, "tufMirror", "", "TUF repository mirror used to resolve the Sigstore roots and keys, a file:// URL can point to a local copy. Defaults to the public Sigstore TUF repository.")
+	flagset.StringVar(&tufRoot, "tufRoot", "", "Path to the trusted TUF root, e.g. mounted from a Secret or ConfigMap. Defaults to the root embedded for the public Sigstore TUF repository.")
 	flagset.BoolVar(&allowInsecureRegistry, "allowInsecureRegistry", false, "Whether to allow insecure connections to registries. Don't use this for anything but testing.")
 	flagset.BoolVar(&autoUpdateWebhooks, "autoUpdateWebhooks", true, "Set this flag to 'false' to disable auto-configuration of the webhook.")
 	flagset.DurationVar(&webhookRegistrationTimeout, "webhookRegistrationTimeout", 120*time.Second, "Timeout for webhook registration, e.g., 30s, 1m, 5m.")
@@ -362,7 +458,14 @@ func main() {
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
 	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
+	flagset.DurationVar(&imageVerifyInterval, "imageVerifyInterval", 0, "Interval at which the images of running pods recorded as verified are verified again, to detect revoked signatures and rotated keys. Set to 0 to disable.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flagset.BoolVar(&imageVerifyCacheEnabled, "imageVerifyCacheEnabled", true, "Enable or disable caching of image verification results.")
+	flagset.DurationVar(&imageVerifyCacheTTL, "imageVerifyCacheTTLDuration", 60*time.Minute, "Max TTL value for the image verification cache entries.")
+	flagset.IntVar(&imageVerifyCacheMaxSize, "imageVerifyCacheMaxSize", 1000, "Max number of entries in the image verification cache.")
+	flagset.BoolVar(&apiCallCacheEnabled, "apiCallCacheEnabled", false, "Enable or disable caching of context APICall results across requests.")
+	flagset.DurationVar(&apiCallCacheTTL, "apiCallCacheTTLDuration", 30*time.Second, "Max TTL value for the APICall cache entries.")
+	flagset.IntVar(&apiCallCacheMaxSize, "apiCallCacheMaxSize", 1000, "Max number of entries in the APICall cache.")
 	// DEPRECATED: remove in 1.9
 	flagset.BoolVar(&splitPolicyReport, "splitPolicyReport", false, "This is deprecated, please don't use it, will be removed in v1.9.")
 	// config
@@ -421,7 +524,16 @@ func main() {
 		os.Exit(1)
 	}
 	// setup cosign
-	setupCosign(logger, imageSignatureRepository)
+	if err := setupCosign(signalCtx, logger, imageSignatureRepository, tufMirror, tufRoot); err != nil {
+		logger.Error(err, "failed to setup cosign")
+		os.Exit(1)
+	}
+	// setup image verify cache
+	imageVerifyCache := setupImageVerifyCache(logger, imageVerifyCacheEnabled, imageVerifyCacheMaxSize, imageVerifyCacheTTL, metricsConfig)
+	// setup apicall cache
+	apiCallCache := setupAPICallCache(logger, apiCallCacheEnabled, apiCallCacheMaxSize, apiCallCacheTTL)
+	// setup global context store
+	globalContext := globalcontextstore.New()
 	informerBasedResolver, err := resolvers.NewInformerBasedResolver(cacheInformer.Core().V1().ConfigMaps().Lister())
 	if err != nil {
 		logger.Error(err, "failed to create informer based resolver")
@@ -475,6 +587,8 @@ func main() {
 		kubeKyvernoInformer.Apps().V1().Deployments(),
 		certRenewer,
 	)
+	// leader election is set up below, before any non leader controller runs
+	var le leaderelection.Interface
 	// create non leader controllers
 	nonLeaderControllers, nonLeaderBootstrap := createNonLeaderControllers(
 		genWorkers,
@@ -487,8 +601,10 @@ func main() {
 		rclient,
 		configuration,
 		policyCache,
+		globalContext,
 		eventGenerator,
 		openApiManager,
+		func() bool { return le.IsLeader() },
 	)
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, kyvernoInformer, kubeInformer, kubeKyvernoInformer, cacheInformer) {
@@ -505,7 +621,7 @@ func main() {
 	// start event generator
 	go eventGenerator.Run(signalCtx, 3)
 	// setup leader election
-	le, err := leaderelection.New(
+	le, err = leaderelection.New(
 		logger.WithName("leader-election"),
 		"kyverno",
 		config.KyvernoNamespace(),
@@ -530,6 +646,7 @@ func main() {
 				admissionReports,
 				reportsChunkSize,
 				backgroundScanWorkers,
+				imageVerifyInterval,
 				serverIP,
 				webhookTimeout,
 				autoUpdateWebhooks,
@@ -541,6 +658,9 @@ func main() {
 				kyvernoClient,
 				dClient,
 				rclient,
+				imageVerifyCache,
+				apiCallCache,
+				globalContext,
 				configuration,
 				metricsConfig,
 				eventGenerator,
@@ -614,6 +734,10 @@ func main() {
 		kubeInformer.Rbac().V1().RoleBindings().Lister(),
 		kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace()),
+		kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions().Lister(),
+		imageVerifyCache,
+		apiCallCache,
+		globalContext,
 		urgen,
 		eventGenerator,
 		openApiManager,
@@ -622,6 +746,7 @@ func main() {
 	server := webhooks.NewServer(
 		policyHandlers,
 		resourceHandlers,
+		webhooksexception.NewHandlers(),
 		configuration,
 		metricsConfig,
 		webhooks.DebugModeOptions{
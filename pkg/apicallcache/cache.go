@@ -0,0 +1,77 @@
+package apicallcache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// Request is the type of request scoped caches
+	Request = "request"
+	// Global is the type of global caches
+	Global = "global"
+)
+
+// Cache stores the results of context APICall lookups
+type Cache interface {
+	// Get returns the cached data for the given key
+	Get(ctx context.Context, key string) ([]byte, bool)
+	// Set stores the data for the given key
+	Set(ctx context.Context, key string, data []byte)
+}
+
+type requestCache struct {
+	lock    sync.RWMutex
+	entries map[string][]byte
+}
+
+// NewRequestCache creates a cache meant to live for the duration of a single admission request
+// or background scan, it deduplicates identical lookups made by different rules and foreach elements.
+func NewRequestCache() Cache {
+	return &requestCache{
+		entries: map[string][]byte{},
+	}
+}
+
+func (c *requestCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	data, ok := c.entries[key]
+	record(ctx, Request, ok)
+	return data, ok
+}
+
+func (c *requestCache) Set(_ context.Context, key string, data []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[key] = data
+}
+
+type globalCache struct {
+	cache *cache.LRUExpireCache
+	ttl   time.Duration
+}
+
+// New creates a LRU cache shared by all requests holding at most maxSize entries, entries expire after ttl.
+func New(maxSize int, ttl time.Duration) Cache {
+	return &globalCache{
+		cache: cache.NewLRUExpireCache(maxSize),
+		ttl:   ttl,
+	}
+}
+
+func (c *globalCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	if value, ok := c.cache.Get(key); ok {
+		record(ctx, Global, true)
+		return value.([]byte), true
+	}
+	record(ctx, Global, false)
+	return nil, false
+}
+
+func (c *globalCache) Set(_ context.Context, key string, data []byte) {
+	c.cache.Add(key, data, c.ttl)
+}
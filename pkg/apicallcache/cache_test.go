@@ -0,0 +1,42 @@
+package apicallcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestRequestCache(t *testing.T) {
+	ctx := context.TODO()
+	c := NewRequestCache()
+	_, ok := c.Get(ctx, "a")
+	assert.Assert(t, !ok)
+	c.Set(ctx, "a", []byte(`{"a":1}`))
+	data, ok := c.Get(ctx, "a")
+	assert.Assert(t, ok)
+	assert.Equal(t, string(data), `{"a":1}`)
+}
+
+func TestGlobalCache(t *testing.T) {
+	ctx := context.TODO()
+	c := New(1, time.Minute)
+	c.Set(ctx, "a", []byte(`{"a":1}`))
+	data, ok := c.Get(ctx, "a")
+	assert.Assert(t, ok)
+	assert.Equal(t, string(data), `{"a":1}`)
+	// max size is one, "a" gets evicted
+	c.Set(ctx, "b", []byte(`{"b":1}`))
+	_, ok = c.Get(ctx, "a")
+	assert.Assert(t, !ok)
+}
+
+func TestGlobalCache_TTL(t *testing.T) {
+	ctx := context.TODO()
+	c := New(10, time.Millisecond)
+	c.Set(ctx, "a", []byte(`{"a":1}`))
+	time.Sleep(5 * time.Millisecond)
+	_, ok := c.Get(ctx, "a")
+	assert.Assert(t, !ok)
+}
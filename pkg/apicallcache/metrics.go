@@ -0,0 +1,63 @@
+package apicallcache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+type cacheMetrics struct {
+	hits   syncint64.Counter
+	misses syncint64.Counter
+}
+
+var (
+	metricsOnce    sync.Once
+	apiCallMetrics cacheMetrics
+	metricsLogger  = logging.WithName("apicall-cache")
+)
+
+func getMetrics() cacheMetrics {
+	metricsOnce.Do(func() {
+		meter := global.MeterProvider().Meter(metrics.MeterName)
+		hits, err := meter.SyncInt64().Counter(
+			"kyverno_apicall_cache_hits",
+			instrument.WithDescription("can be used to track the number of context APICall lookups served from cache, i.e. API server queries saved"),
+		)
+		if err != nil {
+			metricsLogger.Error(err, "Failed to create instrument, kyverno_apicall_cache_hits")
+		}
+		misses, err := meter.SyncInt64().Counter(
+			"kyverno_apicall_cache_misses",
+			instrument.WithDescription("can be used to track the number of context APICall lookups not found in cache"),
+		)
+		if err != nil {
+			metricsLogger.Error(err, "Failed to create instrument, kyverno_apicall_cache_misses")
+		}
+		apiCallMetrics = cacheMetrics{
+			hits:   hits,
+			misses: misses,
+		}
+	})
+	return apiCallMetrics
+}
+
+func record(ctx context.Context, cacheType string, hit bool) {
+	m := getMetrics()
+	attributes := []attribute.KeyValue{
+		attribute.String("cache_type", cacheType),
+	}
+	if hit {
+		if m.hits != nil {
+			m.hits.Add(ctx, 1, attributes...)
+		}
+	} else if m.misses != nil {
+		m.misses.Add(ctx, 1, attributes...)
+	}
+}
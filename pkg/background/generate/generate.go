@@ -27,6 +27,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/event"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	kyvernoutils "github.com/kyverno/kyverno/pkg/utils"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -53,6 +54,7 @@ type GenerateController struct {
 	npolicyLister kyvernov1listers.PolicyLister
 
 	configuration config.Configuration
+	globalContext globalcontextstore.Store
 	eventGen      event.Interface
 
 	log logr.Logger
@@ -69,6 +71,7 @@ func NewGenerateController(
 	urLister kyvernov1beta1listers.UpdateRequestNamespaceLister,
 	nsLister corev1listers.NamespaceLister,
 	dynamicConfig config.Configuration,
+	globalContext globalcontextstore.Store,
 	eventGen event.Interface,
 	log logr.Logger,
 ) *GenerateController {
@@ -82,6 +85,7 @@ func NewGenerateController(
 		urLister:      urLister,
 		nsLister:      nsLister,
 		configuration: dynamicConfig,
+		globalContext: globalContext,
 		eventGen:      eventGen,
 		log:           log,
 	}
@@ -197,6 +201,7 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	if err != nil {
 		return nil, precreatedResource, err
 	}
+	policyContext = policyContext.WithGlobalContext(c.globalContext)
 
 	// check if the policy still applies to the resource
 	engineResponse := engine.GenerateResponse(c.rclient, policyContext, ur)
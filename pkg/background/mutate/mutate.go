@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/event"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"go.uber.org/multierr"
@@ -35,6 +36,7 @@ type MutateExistingController struct {
 	npolicyLister kyvernov1listers.PolicyLister
 
 	configuration config.Configuration
+	globalContext globalcontextstore.Store
 	eventGen      event.Interface
 
 	log logr.Logger
@@ -48,6 +50,7 @@ func NewMutateExistingController(
 	policyLister kyvernov1listers.ClusterPolicyLister,
 	npolicyLister kyvernov1listers.PolicyLister,
 	dynamicConfig config.Configuration,
+	globalContext globalcontextstore.Store,
 	eventGen event.Interface,
 	log logr.Logger,
 ) *MutateExistingController {
@@ -58,6 +61,7 @@ func NewMutateExistingController(
 		policyLister:  policyLister,
 		npolicyLister: npolicyLister,
 		configuration: dynamicConfig,
+		globalContext: globalContext,
 		eventGen:      eventGen,
 		log:           log,
 	}
@@ -92,6 +96,7 @@ func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 			errs = append(errs, err)
 			continue
 		}
+		policyContext = policyContext.WithGlobalContext(c.globalContext)
 
 		er := engine.Mutate(c.rclient, policyContext)
 		for _, r := range er.PolicyResponse.Rules {
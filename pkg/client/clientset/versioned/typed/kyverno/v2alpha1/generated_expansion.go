@@ -21,3 +21,7 @@ package v2alpha1
 type CleanupPolicyExpansion interface{}
 
 type ClusterCleanupPolicyExpansion interface{}
+
+type GlobalContextEntryExpansion interface{}
+
+type PolicyExceptionExpansion interface{}
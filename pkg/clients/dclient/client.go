@@ -37,8 +37,13 @@ type Interface interface {
 	// ListResource returns the list of resources in unstructured/json format
 	// Access items using []Items
 	ListResource(ctx context.Context, apiVersion string, kind string, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error)
+	// ListResourceWithOptions returns the list of resources in unstructured/json format using the given list options
+	// (can be used to page through resources with limit and continue)
+	ListResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, options metav1.ListOptions) (*unstructured.UnstructuredList, error)
 	// DeleteResource deletes the specified resource
 	DeleteResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool) error
+	// DeleteResourceWithOptions deletes the specified resource using the given delete options
+	DeleteResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, options metav1.DeleteOptions) error
 	// CreateResource creates object for the specified resource/namespace
 	CreateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error)
 	// UpdateResource updates object for the specified resource/namespace
@@ -149,6 +154,11 @@ func (c *client) ListResource(ctx context.Context, apiVersion string, kind strin
 	if lselector != nil {
 		options = metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(lselector)}
 	}
+	return c.ListResourceWithOptions(ctx, apiVersion, kind, namespace, options)
+}
+
+// ListResourceWithOptions returns the list of resources in unstructured/json format using the given list options
+func (c *client) ListResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, options metav1.ListOptions) (*unstructured.UnstructuredList, error) {
 	return c.getResourceInterface(apiVersion, kind, namespace).List(ctx, options)
 }
 
@@ -158,6 +168,11 @@ func (c *client) DeleteResource(ctx context.Context, apiVersion string, kind str
 	if dryRun {
 		options = metav1.DeleteOptions{DryRun: []string{metav1.DryRunAll}}
 	}
+	return c.DeleteResourceWithOptions(ctx, apiVersion, kind, namespace, name, options)
+}
+
+// DeleteResourceWithOptions deletes the specified resource using the given delete options
+func (c *client) DeleteResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, options metav1.DeleteOptions) error {
 	return c.getResourceInterface(apiVersion, kind, namespace).Delete(ctx, name, options)
 }
 
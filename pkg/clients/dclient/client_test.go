@@ -83,11 +83,22 @@ func TestCRUDResource(t *testing.T) {
 	if err != nil {
 		t.Errorf("ListResource not working: %s", err)
 	}
+	// List Resources with options
+	_, err = f.client.ListResourceWithOptions(context.TODO(), "", "thekind", "ns-foo", metav1.ListOptions{Limit: 1})
+	if err != nil {
+		t.Errorf("ListResourceWithOptions not working: %s", err)
+	}
 	// DeleteResouce
 	err = f.client.DeleteResource(context.TODO(), "", "thekind", "ns-foo", "name-bar", false)
 	if err != nil {
 		t.Errorf("DeleteResouce not working: %s", err)
 	}
+	// DeleteResourceWithOptions
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = f.client.DeleteResourceWithOptions(context.TODO(), "", "thekind", "ns-foo", "name-baz", metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil {
+		t.Errorf("DeleteResourceWithOptions not working: %s", err)
+	}
 	// CreateResource
 	_, err = f.client.CreateResource(context.TODO(), "", "thekind", "ns-foo", kubeutils.NewUnstructured("group/version", "TheKind", "ns-foo", "name-foo1"), false)
 	if err != nil {
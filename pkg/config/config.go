@@ -31,6 +31,8 @@ const (
 	PolicyValidatingWebhookConfigurationName = "kyverno-policy-validating-webhook-cfg"
 	// PolicyValidatingWebhookName default policy validating webhook name
 	PolicyValidatingWebhookName = "validate-policy.kyverno.svc"
+	// ExceptionValidatingWebhookName default policy exception validating webhook name
+	ExceptionValidatingWebhookName = "validate-exception.kyverno.svc"
 	// PolicyMutatingWebhookConfigurationName default policy mutating webhook configuration name
 	PolicyMutatingWebhookConfigurationName = "kyverno-policy-mutating-webhook-cfg"
 	// PolicyMutatingWebhookName default policy mutating webhook name
@@ -50,6 +52,8 @@ const (
 	PolicyValidatingWebhookServicePath = "/policyvalidate"
 	// PolicyMutatingWebhookServicePath is the path for policy mutation webhook(used to default)
 	PolicyMutatingWebhookServicePath = "/policymutate"
+	// ExceptionValidatingWebhookServicePath is the path for policy exception validation webhook(used to validate policy exception resource)
+	ExceptionValidatingWebhookServicePath = "/exceptionvalidate"
 	// VerifyMutatingWebhookServicePath is the path for verify webhook(used to veryfing if admission control is enabled and active)
 	VerifyMutatingWebhookServicePath = "/verifymutate"
 	// LivenessServicePath is the path for check liveness health
@@ -0,0 +1,243 @@
+package globalcontext
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/globalcontext/externalapi"
+	"github.com/kyverno/kyverno/pkg/globalcontext/k8sresource"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "global-context-controller"
+	maxRetries     = 10
+	// defaultRefreshInterval is the default refresh interval of api call entries
+	defaultRefreshInterval = 10 * time.Minute
+	// leadershipCheckPeriod is the period at which the replica checks if it became the leader
+	leadershipCheckPeriod = 5 * time.Second
+)
+
+// status is the last status of an entry, reported tells if it was written by the leader
+type status struct {
+	err      error
+	reported bool
+}
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+	dclient       dclient.Interface
+
+	// listers
+	gctxLister kyvernov2alpha1listers.GlobalContextEntryLister
+
+	// queue
+	queue   workqueue.RateLimitingInterface
+	enqueue controllerutils.EnqueueFunc
+
+	// store
+	store store.Store
+
+	// isLeader tells if the replica is the leader, only the leader updates the status of the entries
+	isLeader func() bool
+	// wasLeader is the leadership of the replica at the last check
+	wasLeader bool
+
+	// generations of the entries loaded in the store, and their last status
+	lock     sync.Mutex
+	loaded   map[string]int64
+	statuses map[string]status
+}
+
+// NewController creates a controller keeping the data of global context entries
+// up to date in the given store. It runs in every replica so that all of them
+// serve the data, but only the leader reports the status of the entries.
+func NewController(
+	kyvernoClient versioned.Interface,
+	dclient dclient.Interface,
+	gctxInformer kyvernov2alpha1informers.GlobalContextEntryInformer,
+	store store.Store,
+	isLeader func() bool,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		kyvernoClient: kyvernoClient,
+		dclient:       dclient,
+		gctxLister:    gctxInformer.Lister(),
+		queue:         queue,
+		store:         store,
+		isLeader:      isLeader,
+		loaded:        map[string]int64{},
+		statuses:      map[string]status{},
+	}
+	c.enqueue = controllerutils.AddDefaultEventHandlers(logger, gctxInformer.Informer(), queue)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	go wait.UntilWithContext(ctx, c.checkLeadership, leadershipCheckPeriod)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+// checkLeadership requeues all the entries when the replica becomes the leader, the status
+// of the entries loaded before is written by the reconciliation
+func (c *controller) checkLeadership(ctx context.Context) {
+	isLeader := c.isLeader()
+	if isLeader && !c.wasLeader {
+		entries, err := c.gctxLister.List(labels.Everything())
+		if err != nil {
+			logger.Error(err, "failed to list global context entries")
+			return
+		}
+		for _, entry := range entries {
+			if err := c.enqueue(entry); err != nil {
+				logger.Error(err, "failed to enqueue global context entry", "name", entry.Name)
+			}
+		}
+	}
+	c.wasLeader = isLeader
+}
+
+func (c *controller) isLoaded(name string, generation int64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	loaded, ok := c.loaded[name]
+	return ok && loaded == generation
+}
+
+func (c *controller) load(name string, generation int64, entry store.Entry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.store.Set(name, entry)
+	c.loaded[name] = generation
+}
+
+func (c *controller) unload(name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.store.Delete(name)
+	delete(c.loaded, name)
+	delete(c.statuses, name)
+}
+
+// pendingStatus returns the last status of an entry when it was not written by the leader
+func (c *controller) pendingStatus(name string) (status, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	last, ok := c.statuses[name]
+	return last, ok && !last.reported
+}
+
+func (c *controller) setStatus(name string, err error, reported bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.statuses[name] = status{err: err, reported: reported}
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	gctxEntry, err := c.gctxLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			c.unload(name)
+			return nil
+		}
+		return err
+	}
+	// status updates don't change the generation
+	if c.isLoaded(name, gctxEntry.Generation) {
+		// the status was not written when the entry was loaded by a replica that was not the leader yet
+		if last, pending := c.pendingStatus(name); pending && c.isLeader() {
+			c.updateStatus(ctx, logger, name, last.err)
+		}
+		return nil
+	}
+	if errs := gctxEntry.Validate(); len(errs) > 0 {
+		c.unload(name)
+		c.updateStatus(ctx, logger, name, errs.ToAggregate())
+		return nil
+	}
+	if res := gctxEntry.Spec.KubernetesResource; res != nil {
+		gvr := schema.GroupVersionResource{Group: res.Group, Version: res.Version, Resource: res.Resource}
+		entry, err := k8sresource.New(ctx, c.dclient.GetDynamicInterface(), gvr, res.Namespace)
+		if err != nil {
+			c.updateStatus(ctx, logger, name, err)
+			return err
+		}
+		c.load(name, gctxEntry.Generation, entry)
+		c.updateStatus(ctx, logger, name, nil)
+	} else if apiCall := gctxEntry.Spec.APICall; apiCall != nil {
+		interval := defaultRefreshInterval
+		if apiCall.RefreshInterval != nil && apiCall.RefreshInterval.Duration > 0 {
+			interval = apiCall.RefreshInterval.Duration
+		}
+		call := *apiCall.APICall.DeepCopy()
+		caller := func(ctx context.Context) ([]byte, error) {
+			return engine.ExecuteAPICall(ctx, logger, c.dclient, name, call)
+		}
+		onRefresh := func(err error) {
+			if err != nil {
+				logger.Error(err, "failed to refresh global context entry", "name", name)
+			}
+			c.updateStatus(ctx, logger, name, err)
+		}
+		c.load(name, gctxEntry.Generation, externalapi.New(ctx, interval, caller, onRefresh))
+	}
+	return nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, logger logr.Logger, name string, loadErr error) {
+	c.setStatus(name, loadErr, c.writeStatus(ctx, logger, name, loadErr))
+}
+
+// writeStatus writes the status of an entry when the replica is the leader, it returns true when the status was written
+func (c *controller) writeStatus(ctx context.Context, logger logr.Logger, name string, loadErr error) bool {
+	if !c.isLeader() {
+		return false
+	}
+	gctxEntry, err := c.gctxLister.Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get global context entry", "name", name)
+		}
+		return false
+	}
+	_, err = controllerutils.UpdateStatus(
+		ctx,
+		gctxEntry,
+		c.kyvernoClient.KyvernoV2alpha1().GlobalContextEntries(),
+		func(gctxEntry *kyvernov2alpha1.GlobalContextEntry) error {
+			if loadErr != nil {
+				gctxEntry.Status.SetReady(false, loadErr.Error())
+			} else {
+				gctxEntry.Status.SetReady(true, "Data loaded successfully")
+				now := metav1.Now()
+				gctxEntry.Status.LastRefreshTime = &now
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		logger.Error(err, "failed to update global context entry status", "name", name)
+		return false
+	}
+	return true
+}
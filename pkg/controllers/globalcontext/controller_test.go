@@ -0,0 +1,59 @@
+package globalcontext
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_StatusWrittenWhenBecomingLeader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kyvernoClient := fake.NewSimpleClientset(&kyvernov2alpha1.GlobalContextEntry{
+		ObjectMeta: metav1.ObjectMeta{Name: "namespaces"},
+		Spec: kyvernov2alpha1.GlobalContextEntrySpec{
+			KubernetesResource: &kyvernov2alpha1.KubernetesResource{Version: "v1", Resource: "namespaces"},
+		},
+	})
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+	})
+	assert.NilError(t, err)
+	informers := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, 0)
+	leader := false
+	c := NewController(kyvernoClient, client, informers.Kyverno().V2alpha1().GlobalContextEntries(), store.New(), func() bool { return leader }).(*controller)
+	informers.Start(ctx.Done())
+	informers.WaitForCacheSync(ctx.Done())
+	ready := func() bool {
+		entry, err := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(ctx, "namespaces", metav1.GetOptions{})
+		assert.NilError(t, err)
+		return len(entry.Status.Conditions) > 0
+	}
+
+	// the entry is loaded by a replica that is not the leader
+	assert.NilError(t, c.reconcile(ctx, logger, "namespaces", "", "namespaces"))
+	c.checkLeadership(ctx)
+	assert.Assert(t, !ready())
+
+	// the replica becomes the leader, the loaded entry is requeued and its status written
+	for c.queue.Len() > 0 {
+		key, _ := c.queue.Get()
+		c.queue.Done(key)
+	}
+	leader = true
+	c.checkLeadership(ctx)
+	assert.Equal(t, c.queue.Len(), 1)
+	assert.NilError(t, c.reconcile(ctx, logger, "namespaces", "", "namespaces"))
+	assert.Assert(t, ready())
+	_, pending := c.pendingStatus("namespaces")
+	assert.Assert(t, !pending)
+}
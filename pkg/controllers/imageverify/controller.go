@@ -0,0 +1,451 @@
+package imageverify
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/apicallcache"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	"github.com/kyverno/kyverno/pkg/engine"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	"github.com/kyverno/kyverno/pkg/event"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "image-verify-controller"
+	maxRetries     = 10
+	cacheMaxSize   = 1000
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	rclient       registryclient.Client
+
+	// listers
+	polLister   kyvernov1listers.PolicyLister
+	cpolLister  kyvernov1listers.ClusterPolicyLister
+	polexLister kyvernov2alpha1listers.PolicyExceptionLister
+	podLister   corev1listers.PodLister
+	nsLister    corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// cache
+	apiCallCache  apicallcache.Cache
+	globalContext globalcontextstore.Store
+
+	// imageVerifyCache holds the verifications of the current run, including the failed ones,
+	// each distinct image digest is verified once per policy rule and run
+	lock             sync.RWMutex
+	imageVerifyCache imageverifycache.Cache
+
+	// statuses holds the rule statuses of the last verification of each pod, events are only
+	// generated when they change
+	statusesLock sync.Mutex
+	statuses     map[types.UID]map[string]response.RuleStatus
+
+	eventGen event.Interface
+	interval time.Duration
+}
+
+// NewController returns a controller verifying again, every interval, the images of the running pods
+// recorded as verified in the image verification annotation. When a signature is revoked or a signing
+// key rotated, the failures are recorded in the background scan reports of the pods and as events.
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	rclient registryclient.Client,
+	polInformer kyvernov1informers.PolicyInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polexInformer kyvernov2alpha1informers.PolicyExceptionInformer,
+	podInformer corev1informers.PodInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	apiCallCache apicallcache.Cache,
+	globalContext globalcontextstore.Store,
+	eventGen event.Interface,
+	interval time.Duration,
+) controllers.Controller {
+	return &controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		rclient:       rclient,
+		polLister:     polInformer.Lister(),
+		cpolLister:    cpolInformer.Lister(),
+		polexLister:   polexInformer.Lister(),
+		podLister:     podInformer.Lister(),
+		nsLister:      nsInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		apiCallCache:  apiCallCache,
+		globalContext: globalContext,
+		statuses:      map[types.UID]map[string]response.RuleStatus{},
+		eventGen:      eventGen,
+		interval:      interval,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.ticker)
+}
+
+func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.enqueuePods(logger)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// enqueuePods starts a new run, the running pods with verified images are enqueued
+func (c *controller) enqueuePods(logger logr.Logger) {
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list pods")
+		return
+	}
+	c.resetCache()
+	digests := sets.NewString()
+	running := map[types.UID]bool{}
+	count := 0
+	for _, pod := range pods {
+		if !isRunning(pod) {
+			continue
+		}
+		running[pod.UID] = true
+		images, err := engine.VerifiedImages(pod.GetAnnotations())
+		if err != nil {
+			logger.Error(err, "failed to read the verified images", "namespace", pod.Namespace, "name", pod.Name)
+			continue
+		}
+		if len(images) == 0 {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(pod)
+		if err != nil {
+			logger.Error(err, "failed to compute key")
+			continue
+		}
+		digests.Insert(runningImages(pod)...)
+		c.queue.Add(key)
+		count++
+	}
+	c.pruneStatuses(running)
+	logger.V(2).Info("verifying running images", "pods", count, "digests", digests.Len())
+}
+
+func (c *controller) resetCache() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.imageVerifyCache = imageverifycache.NewWithErrors(cacheMaxSize, c.interval)
+}
+
+// pruneStatuses forgets the statuses of the pods that are not running anymore
+func (c *controller) pruneStatuses(running map[types.UID]bool) {
+	c.statusesLock.Lock()
+	defer c.statusesLock.Unlock()
+	for uid := range c.statuses {
+		if !running[uid] {
+			delete(c.statuses, uid)
+		}
+	}
+}
+
+// changedRules returns a copy of the response with the rules whose status changed since the last
+// verification of the pod, the images were verified at admission so the first statuses are compared
+// with a pass
+func (c *controller) changedRules(uid types.UID, resp *response.EngineResponse) *response.EngineResponse {
+	c.statusesLock.Lock()
+	defer c.statusesLock.Unlock()
+	key, _ := cache.MetaNamespaceKeyFunc(resp.Policy)
+	statuses := c.statuses[uid]
+	if statuses == nil {
+		statuses = map[string]response.RuleStatus{}
+		c.statuses[uid] = statuses
+	}
+	changed := *resp
+	changed.PolicyResponse.Rules = nil
+	for _, rule := range resp.PolicyResponse.Rules {
+		previous, ok := statuses[key+"/"+rule.Name]
+		if !ok {
+			previous = response.RuleStatusPass
+		}
+		statuses[key+"/"+rule.Name] = rule.Status
+		if rule.Status != previous {
+			changed.PolicyResponse.Rules = append(changed.PolicyResponse.Rules, rule)
+		}
+	}
+	return &changed
+}
+
+func (c *controller) getCache() imageverifycache.Cache {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.imageVerifyCache
+}
+
+func (c *controller) fetchPolicies(namespace string) ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	pols, err := c.polLister.Policies(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	var imagePolicies []kyvernov1.PolicyInterface
+	for _, policy := range utils.RemoveNonBackgroundPolicies(logger, policies...) {
+		if policy.GetSpec().HasVerifyImages() {
+			imagePolicies = append(imagePolicies, policy)
+		}
+	}
+	return imagePolicies, nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !isRunning(pod) {
+		return nil
+	}
+	policies, err := c.fetchPolicies(namespace)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	ns, err := c.nsLister.Get(namespace)
+	if err != nil {
+		return err
+	}
+	resource, err := toUnstructured(pinImages(pod))
+	if err != nil {
+		return err
+	}
+	// the images recorded as verified would be skipped
+	engine.RemoveImageVerificationMetadata(resource)
+	var responses []*response.EngineResponse
+	for _, policy := range policies {
+		resp, err := c.verifyImages(*resource, ns.GetLabels(), policy)
+		if err != nil {
+			logger.Error(err, "failed to verify images", "policy", policy.GetName())
+			continue
+		}
+		if len(resp.PolicyResponse.Rules) == 0 {
+			continue
+		}
+		responses = append(responses, resp)
+		c.eventGen.Add(generateEvents(c.changedRules(pod.UID, resp))...)
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+	return c.updateReport(ctx, pod, responses...)
+}
+
+func (c *controller) verifyImages(resource unstructured.Unstructured, nsLabels map[string]string, policy kyvernov1.PolicyInterface) (*response.EngineResponse, error) {
+	ctx := enginecontext.NewContext()
+	if err := ctx.AddResource(resource.Object); err != nil {
+		return nil, err
+	}
+	if err := ctx.AddNamespace(resource.GetNamespace()); err != nil {
+		return nil, err
+	}
+	if err := ctx.AddImageInfos(&resource); err != nil {
+		return nil, err
+	}
+	if err := ctx.AddOperation("CREATE"); err != nil {
+		return nil, err
+	}
+	policyCtx := engine.NewPolicyContextWithJsonContext(ctx).
+		WithNewResource(resource).
+		WithPolicy(policy).
+		WithClient(c.client).
+		WithNamespaceLabels(nsLabels).
+		WithExceptions(c.polexLister).
+		WithImageVerifyCache(c.getCache()).
+		WithAPICallCache(c.apiCallCache).
+		WithGlobalContext(c.globalContext)
+	resp, _ := engine.VerifyAndPatchImages(c.rclient, policyCtx)
+	return resp, nil
+}
+
+// updateReport replaces the image verification results in the background scan report of the pod,
+// pods without report are skipped, the background scan controller creates them
+func (c *controller) updateReport(ctx context.Context, pod *corev1.Pod, responses ...*response.EngineResponse) error {
+	before, err := c.kyvernoClient.KyvernoV1alpha2().BackgroundScanReports(pod.Namespace).Get(ctx, string(pod.UID), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	report := reportutils.DeepCopy(before)
+	for _, resp := range responses {
+		reportutils.SetPolicyLabel(report, resp.Policy)
+	}
+	reportutils.SetResults(report, mergeResults(before.GetResults(), responses...)...)
+	if utils.ReportsAreIdentical(before, report) {
+		return nil
+	}
+	_, err = reportutils.UpdateReport(ctx, report, c.kyvernoClient)
+	return err
+}
+
+// mergeResults replaces the results of the rules in the responses, they are recorded with the image
+// verification source so that the background scan keeps them
+func mergeResults(results []policyreportv1alpha2.PolicyReportResult, responses ...*response.EngineResponse) []policyreportv1alpha2.PolicyReportResult {
+	var merged []policyreportv1alpha2.PolicyReportResult
+	rules := sets.NewString()
+	for _, resp := range responses {
+		for _, result := range reportutils.EngineResponseToReportResults(resp) {
+			result.Source = reportutils.SourceImageVerify
+			rules.Insert(result.Policy + "/" + result.Rule)
+			merged = append(merged, result)
+		}
+	}
+	for _, result := range results {
+		if !rules.Has(result.Policy + "/" + result.Rule) {
+			merged = append(merged, result)
+		}
+	}
+	return merged
+}
+
+func generateEvents(resp *response.EngineResponse) []event.Info {
+	var events []event.Info
+	for i := range resp.PolicyResponse.Rules {
+		rule := &resp.PolicyResponse.Rules[i]
+		switch rule.Status {
+		case response.RuleStatusFail:
+			events = append(events,
+				event.NewResourceViolationEvent(event.PolicyController, event.PolicyViolation, resp, rule),
+				event.NewPolicyFailEvent(event.PolicyController, event.PolicyViolation, resp, rule, false),
+			)
+		case response.RuleStatusError:
+			events = append(events, event.NewPolicyFailEvent(event.PolicyController, event.PolicyError, resp, rule, false))
+		}
+	}
+	return events
+}
+
+func isRunning(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning
+}
+
+func toUnstructured(pod *corev1.Pod) (*unstructured.Unstructured, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		return nil, err
+	}
+	resource := &unstructured.Unstructured{Object: data}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("Pod")
+	return resource, nil
+}
+
+// runningImages returns the images of the pod pinned to the digests of the running containers
+func runningImages(pod *corev1.Pod) []string {
+	var images []string
+	pinned := pinImages(pod)
+	for _, containers := range [][]corev1.Container{pinned.Spec.InitContainers, pinned.Spec.Containers} {
+		for _, container := range containers {
+			images = append(images, container.Image)
+		}
+	}
+	for _, container := range pinned.Spec.EphemeralContainers {
+		images = append(images, container.Image)
+	}
+	return images
+}
+
+// pinImages returns a copy of the pod with the container images pinned to the digests reported in
+// the container statuses, the images running in the pod are verified instead of their tags
+func pinImages(pod *corev1.Pod) *corev1.Pod {
+	pod = pod.DeepCopy()
+	digests := map[string]string{}
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, pod.Status.EphemeralContainerStatuses} {
+		for _, status := range statuses {
+			if digest := imageDigest(status.ImageID); digest != "" {
+				digests[status.Name] = digest
+			}
+		}
+	}
+	pin := func(name, image string) string {
+		if digest, ok := digests[name]; ok && !strings.Contains(image, "@") {
+			return image + "@" + digest
+		}
+		return image
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Image = pin(pod.Spec.InitContainers[i].Name, pod.Spec.InitContainers[i].Image)
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Image = pin(pod.Spec.Containers[i].Name, pod.Spec.Containers[i].Image)
+	}
+	for i := range pod.Spec.EphemeralContainers {
+		pod.Spec.EphemeralContainers[i].Image = pin(pod.Spec.EphemeralContainers[i].Name, pod.Spec.EphemeralContainers[i].Image)
+	}
+	return pod
+}
+
+// imageDigest returns the manifest digest of a container status image ID, like
+// docker-pullable://ghcr.io/kyverno/kyverno@sha256:..., other IDs have no digest
+func imageDigest(imageID string) string {
+	index := strings.LastIndex(imageID, "@")
+	if index < 0 || !strings.HasPrefix(imageID[index+1:], "sha256:") {
+		return ""
+	}
+	return imageID[index+1:]
+}
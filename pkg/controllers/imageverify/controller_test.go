@@ -0,0 +1,135 @@
+package imageverify
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/engine/response"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const digest = "sha256:b1f2a2c5e8f4b6f5d0c2a2e0b3c1f1e2d4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9"
+
+func Test_imageDigest(t *testing.T) {
+	assert.Equal(t, imageDigest("docker-pullable://ghcr.io/kyverno/test@"+digest), digest)
+	assert.Equal(t, imageDigest("ghcr.io/kyverno/test@"+digest), digest)
+	assert.Equal(t, imageDigest(digest), "")
+	assert.Equal(t, imageDigest(""), "")
+}
+
+func Test_pinImages(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Image: "ghcr.io/kyverno/init:v1"}},
+			Containers: []corev1.Container{
+				{Name: "app", Image: "ghcr.io/kyverno/app:v1"},
+				{Name: "pinned", Image: "ghcr.io/kyverno/pinned@" + digest},
+				{Name: "pending", Image: "ghcr.io/kyverno/pending:v1"},
+			},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", ImageID: "ghcr.io/kyverno/init@" + digest}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", ImageID: "docker-pullable://ghcr.io/kyverno/app@" + digest},
+				{Name: "pinned", ImageID: "ghcr.io/kyverno/pinned@" + digest},
+				{Name: "pending"},
+			},
+		},
+	}
+	assert.DeepEqual(t, runningImages(pod), []string{
+		"ghcr.io/kyverno/init:v1@" + digest,
+		"ghcr.io/kyverno/app:v1@" + digest,
+		"ghcr.io/kyverno/pinned@" + digest,
+		"ghcr.io/kyverno/pending:v1",
+	})
+	// the pod is not modified
+	assert.Equal(t, pod.Spec.Containers[0].Image, "ghcr.io/kyverno/app:v1")
+}
+
+func Test_mergeResults(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify"}}
+	resp := &response.EngineResponse{
+		Policy: policy,
+		PolicyResponse: response.PolicyResponse{
+			Rules: []response.RuleResponse{{Name: "signature", Status: response.RuleStatusFail, Message: "signature revoked"}},
+		},
+	}
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Policy: "verify", Rule: "signature", Result: "pass"},
+		{Policy: "verify", Rule: "labels", Result: "pass"},
+		{Policy: "other", Rule: "signature", Result: "pass"},
+	}
+	merged := mergeResults(results, resp)
+	assert.Equal(t, len(merged), 3)
+	assert.Equal(t, merged[0].Policy, "verify")
+	assert.Equal(t, merged[0].Rule, "signature")
+	assert.Equal(t, merged[0].Result, policyreportv1alpha2.PolicyResult("fail"))
+	assert.Equal(t, merged[0].Message, "signature revoked")
+	assert.Equal(t, merged[0].Source, reportutils.SourceImageVerify)
+	assert.Equal(t, merged[1].Rule, "labels")
+	assert.Equal(t, merged[2].Policy, "other")
+}
+
+func Test_generateEvents(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify"}}
+	resource := unstructured.Unstructured{}
+	resource.SetKind("Pod")
+	resource.SetNamespace("default")
+	resource.SetName("app")
+	resp := &response.EngineResponse{
+		PatchedResource: resource,
+		Policy:          policy,
+		PolicyResponse: response.PolicyResponse{
+			Policy: response.PolicySpec{Name: "verify"},
+			Rules: []response.RuleResponse{
+				{Name: "signature", Status: response.RuleStatusFail, Message: "signature revoked"},
+				{Name: "attestation", Status: response.RuleStatusError, Message: "registry unavailable"},
+				{Name: "digest", Status: response.RuleStatusPass},
+			},
+		},
+	}
+	events := generateEvents(resp)
+	assert.Equal(t, len(events), 3)
+	assert.Equal(t, events[0].Kind, "Pod")
+	assert.Equal(t, events[0].Name, "app")
+	assert.Equal(t, events[0].Reason, "PolicyViolation")
+	assert.Equal(t, events[1].Kind, "ClusterPolicy")
+	assert.Equal(t, events[1].Reason, "PolicyViolation")
+	assert.Equal(t, events[2].Kind, "ClusterPolicy")
+	assert.Equal(t, events[2].Reason, "PolicyError")
+}
+
+func Test_changedRules(t *testing.T) {
+	c := &controller{statuses: map[types.UID]map[string]response.RuleStatus{}}
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify"}}
+	newResponse := func(statuses ...response.RuleStatus) *response.EngineResponse {
+		resp := &response.EngineResponse{Policy: policy}
+		for i, status := range statuses {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, response.RuleResponse{Name: []string{"signature", "attestation"}[i], Status: status})
+		}
+		return resp
+	}
+	rules := func(resp *response.EngineResponse) []string {
+		var names []string
+		for _, rule := range resp.PolicyResponse.Rules {
+			names = append(names, rule.Name)
+		}
+		return names
+	}
+	// the images were verified at admission
+	assert.DeepEqual(t, rules(c.changedRules("app", newResponse(response.RuleStatusPass, response.RuleStatusFail))), []string{"attestation"})
+	// the failure was already reported
+	assert.DeepEqual(t, rules(c.changedRules("app", newResponse(response.RuleStatusPass, response.RuleStatusFail))), []string(nil))
+	assert.DeepEqual(t, rules(c.changedRules("app", newResponse(response.RuleStatusFail, response.RuleStatusError))), []string{"signature", "attestation"})
+	// the statuses are recorded per pod
+	assert.DeepEqual(t, rules(c.changedRules("other", newResponse(response.RuleStatusFail))), []string{"signature"})
+	c.pruneStatuses(map[types.UID]bool{"other": true})
+	assert.Equal(t, len(c.statuses), 1)
+	assert.DeepEqual(t, rules(c.changedRules("app", newResponse(response.RuleStatusFail))), []string{"signature"})
+}
@@ -2,28 +2,37 @@ package background
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/apicallcache"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	"github.com/kyverno/kyverno/pkg/engine/response"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
@@ -47,6 +56,7 @@ type controller struct {
 	// listers
 	polLister      kyvernov1listers.PolicyLister
 	cpolLister     kyvernov1listers.ClusterPolicyLister
+	polexLister    kyvernov2alpha1listers.PolicyExceptionLister
 	bgscanrLister  cache.GenericLister
 	cbgscanrLister cache.GenericLister
 	nsLister       corev1listers.NamespaceLister
@@ -57,7 +67,14 @@ type controller struct {
 	cbgscanEnqueue controllerutils.EnqueueFunc
 
 	// cache
-	metadataCache resource.MetadataCache
+	metadataCache    resource.MetadataCache
+	imageVerifyCache imageverifycache.Cache
+	apiCallCache     apicallcache.Cache
+	globalContext    globalcontextstore.Store
+
+	// rescan stores keys of reports that need a full rescan (policy exceptions changed)
+	lock   sync.Mutex
+	rescan sets.String
 }
 
 func NewController(
@@ -67,28 +84,38 @@ func NewController(
 	metadataFactory metadatainformers.SharedInformerFactory,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polexInformer kyvernov2alpha1informers.PolicyExceptionInformer,
 	nsInformer corev1informers.NamespaceInformer,
 	metadataCache resource.MetadataCache,
+	imageVerifyCache imageverifycache.Cache,
+	apiCallCache apicallcache.Cache,
+	globalContext globalcontextstore.Store,
 ) controllers.Controller {
 	bgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
 	cbgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
-		client:         client,
-		kyvernoClient:  kyvernoClient,
-		rclient:        rclient,
-		polLister:      polInformer.Lister(),
-		cpolLister:     cpolInformer.Lister(),
-		bgscanrLister:  bgscanr.Lister(),
-		cbgscanrLister: cbgscanr.Lister(),
-		nsLister:       nsInformer.Lister(),
-		queue:          queue,
-		bgscanEnqueue:  controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue),
-		cbgscanEnqueue: controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue),
-		metadataCache:  metadataCache,
+		client:           client,
+		kyvernoClient:    kyvernoClient,
+		rclient:          rclient,
+		polLister:        polInformer.Lister(),
+		cpolLister:       cpolInformer.Lister(),
+		polexLister:      polexInformer.Lister(),
+		bgscanrLister:    bgscanr.Lister(),
+		cbgscanrLister:   cbgscanr.Lister(),
+		nsLister:         nsInformer.Lister(),
+		queue:            queue,
+		bgscanEnqueue:    controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue),
+		cbgscanEnqueue:   controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue),
+		metadataCache:    metadataCache,
+		imageVerifyCache: imageVerifyCache,
+		apiCallCache:     apiCallCache,
+		globalContext:    globalContext,
+		rescan:           sets.NewString(),
 	}
 	controllerutils.AddEventHandlersT(polInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
 	controllerutils.AddEventHandlersT(cpolInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy)
+	controllerutils.AddEventHandlersT(polexInformer.Informer(), c.addException, c.updateException, c.deleteException)
 	c.metadataCache.AddEventHandler(func(eventType resource.EventType, uid types.UID, _ schema.GroupVersionKind, res resource.Resource) {
 		// if it's a deletion, nothing to do
 		if eventType == resource.Deleted {
@@ -146,6 +173,92 @@ func (c *controller) deletePolicy(obj kyvernov1.PolicyInterface) {
 	}
 }
 
+func (c *controller) addException(obj *kyvernov2alpha1.PolicyException) {
+	c.enqueueException(obj)
+}
+
+func (c *controller) updateException(old, obj *kyvernov2alpha1.PolicyException) {
+	if old.GetResourceVersion() != obj.GetResourceVersion() {
+		c.enqueueException(old)
+		c.enqueueException(obj)
+	}
+}
+
+func (c *controller) deleteException(obj *kyvernov2alpha1.PolicyException) {
+	c.enqueueException(obj)
+}
+
+func (c *controller) enqueueException(polex *kyvernov2alpha1.PolicyException) {
+	for _, exception := range polex.Spec.Exceptions {
+		policy, err := c.lookupPolicy(exception.PolicyName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.Error(err, "failed to lookup policy", "policy", exception.PolicyName)
+			}
+			continue
+		}
+		selector, err := reportutils.SelectorPolicyExists(policy)
+		if err != nil {
+			logger.Error(err, "failed to create label selector")
+		}
+		if err := c.enqueueRescan(selector); err != nil {
+			logger.Error(err, "failed to enqueue")
+		}
+	}
+}
+
+func (c *controller) lookupPolicy(key string) (kyvernov1.PolicyInterface, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return c.cpolLister.Get(name)
+	}
+	return c.polLister.Policies(namespace).Get(name)
+}
+
+// enqueueRescan enqueues reports matching the selector and flags them for a full rescan
+func (c *controller) enqueueRescan(selector labels.Selector) error {
+	var reports []runtime.Object
+	bgscans, err := c.bgscanrLister.List(selector)
+	if err != nil {
+		return err
+	}
+	reports = append(reports, bgscans...)
+	cbgscans, err := c.cbgscanrLister.List(selector)
+	if err != nil {
+		return err
+	}
+	reports = append(reports, cbgscans...)
+	for _, report := range reports {
+		key, err := cache.MetaNamespaceKeyFunc(report)
+		if err != nil {
+			logger.Error(err, "failed to compute key")
+			continue
+		}
+		c.markRescan(key)
+		c.queue.Add(key)
+	}
+	return nil
+}
+
+func (c *controller) markRescan(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.rescan.Insert(key)
+}
+
+func (c *controller) popRescan(key string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.rescan.Has(key) {
+		c.rescan.Delete(key)
+		return true
+	}
+	return false
+}
+
 func (c *controller) enqueue(selector labels.Selector) error {
 	bgscans, err := c.bgscanrLister.List(selector)
 	if err != nil {
@@ -196,7 +309,7 @@ func (c *controller) fetchPolicies(logger logr.Logger, namespace string) ([]kyve
 	return policies, nil
 }
 
-func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource) error {
+func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk schema.GroupVersionKind, resource resource.Resource, rescan bool) error {
 	namespace := meta.GetNamespace()
 	metaLabels := meta.GetLabels()
 	// load all policies
@@ -217,8 +330,8 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 		return err
 	}
 	//	if the resource changed, we need to rebuild the report
-	if !reportutils.CompareHash(meta, resource.Hash) {
-		scanner := utils.NewScanner(logger, c.client, c.rclient)
+	if !reportutils.CompareHash(meta, resource.Hash) || rescan {
+		scanner := utils.NewScanner(logger, c.client, c.rclient, c.polexLister, c.imageVerifyCache, c.apiCallCache, c.globalContext)
 		before, err := c.getReport(ctx, meta.GetNamespace(), meta.GetName())
 		if err != nil {
 			return nil
@@ -249,6 +362,7 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 			}
 		}
 		reportutils.SetResponses(report, responses...)
+		reportutils.SetResults(report, utils.KeepImageVerifyResults(before, report.GetResults(), backgroundPolicies...)...)
 		if utils.ReportsAreIdentical(before, report) {
 			return nil
 		}
@@ -307,7 +421,7 @@ func (c *controller) updateReport(ctx context.Context, meta metav1.Object, gvk s
 		}
 		// creations
 		if len(toCreate) > 0 {
-			scanner := utils.NewScanner(logger, c.client, c.rclient)
+			scanner := utils.NewScanner(logger, c.client, c.rclient, c.polexLister, c.imageVerifyCache, c.apiCallCache, c.globalContext)
 			resource, err := c.client.GetResource(ctx, gvk.GroupVersion().String(), gvk.Kind, resource.Namespace, resource.Name)
 			if err != nil {
 				return err
@@ -394,5 +508,12 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		}
 		return err
 	}
-	return c.updateReport(ctx, report, gvk, resource)
+	rescan := c.popRescan(key)
+	if err := c.updateReport(ctx, report, gvk, resource, rescan); err != nil {
+		if rescan {
+			c.markRescan(key)
+		}
+		return err
+	}
+	return nil
 }
@@ -3,10 +3,14 @@ package utils
 import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/apicallcache"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -16,6 +20,10 @@ type scanner struct {
 	logger           logr.Logger
 	client           dclient.Interface
 	rclient          registryclient.Client
+	peLister         kyvernov2alpha1listers.PolicyExceptionLister
+	imageVerifyCache imageverifycache.Cache
+	apiCallCache     apicallcache.Cache
+	globalContext    globalcontextstore.Store
 	excludeGroupRole []string
 }
 
@@ -28,11 +36,15 @@ type Scanner interface {
 	ScanResource(unstructured.Unstructured, map[string]string, ...kyvernov1.PolicyInterface) map[kyvernov1.PolicyInterface]ScanResult
 }
 
-func NewScanner(logger logr.Logger, client dclient.Interface, rclient registryclient.Client, excludeGroupRole ...string) Scanner {
+func NewScanner(logger logr.Logger, client dclient.Interface, rclient registryclient.Client, peLister kyvernov2alpha1listers.PolicyExceptionLister, imageVerifyCache imageverifycache.Cache, apiCallCache apicallcache.Cache, globalContext globalcontextstore.Store, excludeGroupRole ...string) Scanner {
 	return &scanner{
 		logger:           logger,
 		client:           client,
 		rclient:          rclient,
+		peLister:         peLister,
+		imageVerifyCache: imageVerifyCache,
+		apiCallCache:     apiCallCache,
+		globalContext:    globalContext,
 		excludeGroupRole: excludeGroupRole,
 	}
 }
@@ -83,7 +95,10 @@ func (s *scanner) validateResource(resource unstructured.Unstructured, nsLabels
 		WithPolicy(policy).
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
-		WithExcludeGroupRole(s.excludeGroupRole...)
+		WithExcludeGroupRole(s.excludeGroupRole...).
+		WithExceptions(s.peLister).
+		WithAPICallCache(s.apiCallCache).
+		WithGlobalContext(s.globalContext)
 	return engine.Validate(s.rclient, policyCtx), nil
 }
 
@@ -106,7 +121,11 @@ func (s *scanner) validateImages(resource unstructured.Unstructured, nsLabels ma
 		WithPolicy(policy).
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
-		WithExcludeGroupRole(s.excludeGroupRole...)
+		WithExcludeGroupRole(s.excludeGroupRole...).
+		WithExceptions(s.peLister).
+		WithImageVerifyCache(s.imageVerifyCache).
+		WithAPICallCache(s.apiCallCache).
+		WithGlobalContext(s.globalContext)
 	response, _ := engine.VerifyAndPatchImages(s.rclient, policyCtx)
 	if len(response.PolicyResponse.Rules) > 0 {
 		s.logger.Info("validateImages", "policy", policy, "response", response)
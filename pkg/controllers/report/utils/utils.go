@@ -6,10 +6,13 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/policy"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 )
 
 func CanBackgroundProcess(logger logr.Logger, p kyvernov1.PolicyInterface) bool {
@@ -55,6 +58,35 @@ func RemoveNonValidationPolicies(logger logr.Logger, policies ...kyvernov1.Polic
 	return validationPolicies
 }
 
+// KeepImageVerifyResults returns the results with the image verification results of the report before
+// the scan, the scan skips the images recorded as verified and can't produce them. Only the results of
+// the unchanged policies are kept, they replace the results of the same rules.
+func KeepImageVerifyResults(before kyvernov1alpha2.ReportInterface, results []policyreportv1alpha2.PolicyReportResult, policies ...kyvernov1.PolicyInterface) []policyreportv1alpha2.PolicyReportResult {
+	keys := sets.NewString()
+	for _, policy := range policies {
+		if before.GetLabels()[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
+			continue
+		}
+		if key, err := cache.MetaNamespaceKeyFunc(policy); err == nil {
+			keys.Insert(key)
+		}
+	}
+	var kept []policyreportv1alpha2.PolicyReportResult
+	rules := sets.NewString()
+	for _, result := range before.GetResults() {
+		if result.Source == reportutils.SourceImageVerify && keys.Has(result.Policy) {
+			rules.Insert(result.Policy + "/" + result.Rule)
+			kept = append(kept, result)
+		}
+	}
+	for _, result := range results {
+		if !rules.Has(result.Policy + "/" + result.Rule) {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
+
 func ReportsAreIdentical(before, after kyvernov1alpha2.ReportInterface) bool {
 	bLabels := sets.NewString()
 	aLabels := sets.NewString()
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeepImageVerifyResults(t *testing.T) {
+	verify := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify", ResourceVersion: "1"}}
+	changed := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "changed", ResourceVersion: "2"}}
+	before := &kyvernov1alpha2.BackgroundScanReport{}
+	before.SetLabels(map[string]string{
+		reportutils.PolicyLabel(verify):  "1",
+		reportutils.PolicyLabel(changed): "1",
+	})
+	before.SetResults([]policyreportv1alpha2.PolicyReportResult{
+		{Source: reportutils.SourceImageVerify, Policy: "verify", Rule: "signature", Result: "fail"},
+		{Source: kyvernov1.ValueKyvernoApp, Policy: "verify", Rule: "labels", Result: "fail"},
+		{Source: reportutils.SourceImageVerify, Policy: "changed", Rule: "signature", Result: "fail"},
+		{Source: reportutils.SourceImageVerify, Policy: "deleted", Rule: "signature", Result: "fail"},
+	})
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Source: kyvernov1.ValueKyvernoApp, Policy: "verify", Rule: "signature", Result: "pass"},
+		{Source: kyvernov1.ValueKyvernoApp, Policy: "verify", Rule: "labels", Result: "pass"},
+		{Source: kyvernov1.ValueKyvernoApp, Policy: "changed", Rule: "signature", Result: "pass"},
+	}
+	kept := KeepImageVerifyResults(before, results, verify, changed)
+	assert.DeepEqual(t, kept, []policyreportv1alpha2.PolicyReportResult{
+		{Source: reportutils.SourceImageVerify, Policy: "verify", Rule: "signature", Result: "fail"},
+		{Source: kyvernov1.ValueKyvernoApp, Policy: "verify", Rule: "labels", Result: "pass"},
+		{Source: kyvernov1.ValueKyvernoApp, Policy: "changed", Rule: "signature", Result: "pass"},
+	})
+}
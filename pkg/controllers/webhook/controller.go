@@ -61,6 +61,11 @@ var (
 		APIGroups:   []string{"kyverno.io"},
 		APIVersions: []string{"v1", "v2beta1"},
 	}
+	exceptionRule = admissionregistrationv1.Rule{
+		Resources:   []string{"policyexceptions"},
+		APIGroups:   []string{"kyverno.io"},
+		APIVersions: []string{"v2alpha1"},
+	}
 	verifyRule = admissionregistrationv1.Rule{
 		Resources:   []string{"leases"},
 		APIGroups:   []string{"coordination.k8s.io"},
@@ -575,6 +580,19 @@ func (c *controller) buildPolicyValidatingWebhookConfiguration(caBundle []byte)
 				FailurePolicy:           &ignore,
 				SideEffects:             &none,
 				AdmissionReviewVersions: []string{"v1"},
+			}, {
+				Name:         config.ExceptionValidatingWebhookName,
+				ClientConfig: c.clientConfig(caBundle, config.ExceptionValidatingWebhookServicePath),
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Rule: exceptionRule,
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create,
+						admissionregistrationv1.Update,
+					},
+				}},
+				FailurePolicy:           &ignore,
+				SideEffects:             &none,
+				AdmissionReviewVersions: []string{"v1"},
 			}},
 		},
 		nil
@@ -6,21 +6,48 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/sigstore/cosign/pkg/cosign"
 	"github.com/sigstore/cosign/pkg/oci"
+	"github.com/sigstore/cosign/pkg/oci/remote"
 )
 
 var client Cosign = &driver{}
 
 type Cosign interface {
-	VerifyImageSignatures(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts) ([]oci.Signature, bool, error)
-	VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts) (checkedAttestations []oci.Signature, bundleVerified bool, err error)
+	VerifyImageSignatures(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts, keys TrustedKeys) ([]oci.Signature, bool, error)
+	VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts, keys TrustedKeys) (checkedAttestations []oci.Signature, bundleVerified bool, err error)
 }
 
+// driver fetches the signatures before verifying them with the trusted keys, the registry
+// calls are not made with the trusted keys lock held
 type driver struct{}
 
-func (d *driver) VerifyImageSignatures(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts) ([]oci.Signature, bool, error) {
-	return cosign.VerifyImageSignatures(ctx, signedImgRef, co)
+func (d *driver) VerifyImageSignatures(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts, keys TrustedKeys) ([]oci.Signature, bool, error) {
+	fetched, err := fetchSignatures(signedImgRef, co, remote.SignatureTag)
+	if err != nil {
+		return nil, false, err
+	}
+	var signatures []oci.Signature
+	var bundleVerified bool
+	err = withTrustedKeys(keys, func() error {
+		var err error
+		signatures, bundleVerified, err = fetched.verify(cosign.ErrNoMatchingSignatures, func(sig oci.Signature) (bool, error) {
+			return cosign.VerifyImageSignature(ctx, sig, fetched.digest, co)
+		})
+		return err
+	})
+	return signatures, bundleVerified, err
 }
 
-func (d *driver) VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
-	return cosign.VerifyImageAttestations(ctx, signedImgRef, co)
+func (d *driver) VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts, keys TrustedKeys) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
+	fetched, err := fetchSignatures(signedImgRef, co, remote.AttestationTag)
+	if err != nil {
+		return nil, false, err
+	}
+	err = withTrustedKeys(keys, func() error {
+		var err error
+		checkedAttestations, bundleVerified, err = fetched.verify(cosign.ErrNoMatchingAttestations, func(att oci.Signature) (bool, error) {
+			return verifyAttestation(ctx, att, fetched.digest, co)
+		})
+		return err
+	})
+	return checkedAttestations, bundleVerified, err
 }
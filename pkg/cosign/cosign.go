@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
@@ -42,15 +43,24 @@ type Options struct {
 	CertChain            string
 	Roots                string
 	Subject              string
+	SubjectRegExp        string
 	Issuer               string
+	IssuerRegExp         string
 	AdditionalExtensions map[string]string
 	Annotations          map[string]string
 	Repository           string
 	RekorURL             string
+	RekorPubKey          string
+	CTLogPubKey          string
+	IgnoreTlog           bool
 	SignatureAlgorithm   string
 	PredicateType        string
 }
 
+func (opts Options) trustedKeys() TrustedKeys {
+	return TrustedKeys{RekorPubKey: opts.RekorPubKey, CTLogPubKey: opts.CTLogPubKey}
+}
+
 type Response struct {
 	Digest     string
 	Statements []map[string]interface{}
@@ -76,7 +86,7 @@ func VerifySignature(rclient registryclient.Client, opts Options) (*Response, er
 	)
 
 	tracing.DoInSpan(context.Background(), "cosign", "verify_image_signatures", func(ctx context.Context) {
-		signatures, bundleVerified, err = client.VerifyImageSignatures(ctx, ref, cosignOpts)
+		signatures, bundleVerified, err = client.VerifyImageSignatures(ctx, ref, cosignOpts, opts.trustedKeys())
 	})
 
 	if err != nil {
@@ -84,13 +94,20 @@ func VerifySignature(rclient registryclient.Client, opts Options) (*Response, er
 		return nil, err
 	}
 
+	if opts.IgnoreTlog {
+		signatures, err = bundledSignatures(signatures)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	logger.V(3).Info("verified image", "count", len(signatures), "bundleVerified", bundleVerified)
 	payload, err := extractPayload(signatures)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := matchSignatures(signatures, opts.Subject, opts.Issuer, opts.AdditionalExtensions); err != nil {
+	if err := matchSignatures(signatures, opts.Subject, opts.SubjectRegExp, opts.Issuer, opts.IssuerRegExp, opts.AdditionalExtensions); err != nil {
 		return nil, err
 	}
 
@@ -175,7 +192,12 @@ func buildCosignOptions(rclient registryclient.Client, opts Options) (*cosign.Ch
 				if err != nil {
 					return nil, errors.Wrap(err, "failed to load load certificate chain")
 				}
-				cosignOpts.SigVerifier, err = cosign.ValidateAndUnpackCertWithChain(cert, chain, cosignOpts)
+				// the embedded SCT of the certificate is verified with the CT log key
+				err = withTrustedKeys(opts.trustedKeys(), func() error {
+					var err error
+					cosignOpts.SigVerifier, err = cosign.ValidateAndUnpackCertWithChain(cert, chain, cosignOpts)
+					return err
+				})
 				if err != nil {
 					return nil, errors.Wrap(err, "failed to load validate certificate chain")
 				}
@@ -202,7 +224,8 @@ func buildCosignOptions(rclient registryclient.Client, opts Options) (*cosign.Ch
 		}
 	}
 
-	if opts.RekorURL != "" {
+	// without a Rekor client, cosign verifies the signed entry timestamps of the bundles offline
+	if opts.RekorURL != "" && !opts.IgnoreTlog {
 		cosignOpts.RekorClient, err = rekor.NewClient(opts.RekorURL)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to create Rekor client from URL %s", opts.RekorURL)
@@ -221,6 +244,26 @@ func buildCosignOptions(rclient registryclient.Client, opts Options) (*cosign.Ch
 	return cosignOpts, nil
 }
 
+// bundledSignatures returns the signatures bundled with a transparency log entry, cosign verifies
+// the signed entry timestamp of bundles but accepts signatures without bundle when the transparency
+// log is not queried
+func bundledSignatures(signatures []oci.Signature) ([]oci.Signature, error) {
+	var bundled []oci.Signature
+	for _, signature := range signatures {
+		bundle, err := signature.Bundle()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the transparency log bundle")
+		}
+		if bundle != nil {
+			bundled = append(bundled, signature)
+		}
+	}
+	if len(bundled) == 0 {
+		return nil, fmt.Errorf("no signature is bundled with a transparency log entry, it is required when the transparency log is ignored")
+	}
+	return bundled, nil
+}
+
 func loadCertPool(roots []byte) (*x509.CertPool, error) {
 	cp := x509.NewCertPool()
 	if !cp.AppendCertsFromPEM(roots) {
@@ -269,7 +312,7 @@ func FetchAttestations(rclient registryclient.Client, opts Options) (*Response,
 	var bundleVerified bool
 
 	tracing.DoInSpan(context.Background(), "cosign_operations", "verify_image_signatures", func(ctx context.Context) {
-		signatures, bundleVerified, err = client.VerifyImageAttestations(context.Background(), ref, cosignOpts)
+		signatures, bundleVerified, err = client.VerifyImageAttestations(context.Background(), ref, cosignOpts, opts.trustedKeys())
 	})
 
 	if err != nil {
@@ -282,6 +325,13 @@ func FetchAttestations(rclient registryclient.Client, opts Options) (*Response,
 		return nil, err
 	}
 
+	if opts.IgnoreTlog {
+		signatures, err = bundledSignatures(signatures)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	payload, err := extractPayload(signatures)
 	if err != nil {
 		return nil, err
@@ -298,7 +348,7 @@ func FetchAttestations(rclient registryclient.Client, opts Options) (*Response,
 			continue
 		}
 
-		if err := matchSignatures([]oci.Signature{signature}, opts.Subject, opts.Issuer, opts.AdditionalExtensions); err != nil {
+		if err := matchSignatures([]oci.Signature{signature}, opts.Subject, opts.SubjectRegExp, opts.Issuer, opts.IssuerRegExp, opts.AdditionalExtensions); err != nil {
 			return nil, err
 		}
 	}
@@ -489,8 +539,8 @@ func extractDigest(imgRef string, payload []payload.SimpleContainerImage) (strin
 	return "", fmt.Errorf("digest not found for " + imgRef)
 }
 
-func matchSignatures(signatures []oci.Signature, subject, issuer string, extensions map[string]string) error {
-	if subject == "" && issuer == "" && len(extensions) == 0 {
+func matchSignatures(signatures []oci.Signature, subject, subjectRegExp, issuer, issuerRegExp string, extensions map[string]string) error {
+	if subject == "" && subjectRegExp == "" && issuer == "" && issuerRegExp == "" && len(extensions) == 0 {
 		return nil
 	}
 
@@ -505,7 +555,7 @@ func matchSignatures(signatures []oci.Signature, subject, issuer string, extensi
 			return errors.Errorf("certificate not found")
 		}
 
-		if err := matchCertificateData(cert, subject, issuer, extensions); err != nil {
+		if err := matchCertificateData(cert, subject, subjectRegExp, issuer, issuerRegExp, extensions); err != nil {
 			errs = append(errs, err)
 		} else {
 			// only one signature certificate needs to match the required subject, issuer, and extensions
@@ -521,29 +571,35 @@ func matchSignatures(signatures []oci.Signature, subject, issuer string, extensi
 	return fmt.Errorf("invalid signature")
 }
 
-func matchCertificateData(cert *x509.Certificate, subject, issuer string, extensions map[string]string) error {
-	if subject != "" {
+func matchCertificateData(cert *x509.Certificate, subject, subjectRegExp, issuer, issuerRegExp string, extensions map[string]string) error {
+	if subject != "" || subjectRegExp != "" {
 		s := sigs.CertSubject(cert)
-		if !wildcard.Match(subject, s) {
+		if subject != "" && !wildcard.Match(subject, s) {
 			return fmt.Errorf("subject mismatch: expected %s, received %s", subject, s)
 		}
+		if err := matchRegExp("subject", subjectRegExp, s); err != nil {
+			return err
+		}
 	}
 
-	if err := matchExtensions(cert, issuer, extensions); err != nil {
+	if err := matchExtensions(cert, issuer, issuerRegExp, extensions); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func matchExtensions(cert *x509.Certificate, issuer string, extensions map[string]string) error {
+func matchExtensions(cert *x509.Certificate, issuer, issuerRegExp string, extensions map[string]string) error {
 	ce := cosign.CertExtensions{Cert: cert}
 
-	if issuer != "" {
+	if issuer != "" || issuerRegExp != "" {
 		val := ce.GetIssuer()
-		if !wildcard.Match(issuer, val) {
+		if issuer != "" && !wildcard.Match(issuer, val) {
 			return fmt.Errorf("issuer mismatch: expected %s, received %s", issuer, val)
 		}
+		if err := matchRegExp("issuer", issuerRegExp, val); err != nil {
+			return err
+		}
 	}
 
 	for requiredKey, requiredValue := range extensions {
@@ -560,6 +616,21 @@ func matchExtensions(cert *x509.Certificate, issuer string, extensions map[strin
 	return nil
 }
 
+// matchRegExp matches a certificate field against a regular expression, an empty expression matches any value
+func matchRegExp(field, expression, value string) error {
+	if expression == "" {
+		return nil
+	}
+	regex, err := regexp.Compile(expression)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s regular expression %s", field, expression)
+	}
+	if !regex.MatchString(value) {
+		return fmt.Errorf("%s mismatch: expected to match %s, received %s", field, expression, value)
+	}
+	return nil
+}
+
 func extractCertExtensionValue(key string, ce cosign.CertExtensions) (string, error) {
 	switch key {
 	case cosign.CertExtensionOIDCIssuer, cosign.CertExtensionMap[cosign.CertExtensionOIDCIssuer]:
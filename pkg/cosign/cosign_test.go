@@ -106,20 +106,20 @@ func TestCosignMatchCertificateData(t *testing.T) {
 		"githubWorkflowRepository": "JimBugwadia/demo-java-tomcat",
 	}
 
-	matchErr := matchCertificateData(cert1, subject1, issuer1, extensions)
+	matchErr := matchCertificateData(cert1, subject1, "", issuer1, "", extensions)
 	assert.NilError(t, matchErr)
 
-	matchErr = matchCertificateData(cert1, "", issuer1, extensions)
+	matchErr = matchCertificateData(cert1, "", "", issuer1, "", extensions)
 	assert.NilError(t, matchErr)
 
-	matchErr = matchCertificateData(cert1, subject1, issuer1, nil)
+	matchErr = matchCertificateData(cert1, subject1, "", issuer1, "", nil)
 	assert.NilError(t, matchErr)
 
-	matchErr = matchCertificateData(cert1, "wrong-subject", issuer1, extensions)
+	matchErr = matchCertificateData(cert1, "wrong-subject", "", issuer1, "", extensions)
 	assert.Error(t, matchErr, "subject mismatch: expected wrong-subject, received https://github.com/JimBugwadia/demo-java-tomcat/.github/workflows/publish.yaml@refs/tags/v0.0.22")
 
 	extensions["githubWorkflowTrigger"] = "pull"
-	matchErr = matchCertificateData(cert1, subject1, issuer1, extensions)
+	matchErr = matchCertificateData(cert1, subject1, "", issuer1, "", extensions)
 	assert.Error(t, matchErr, "extension mismatch: expected pull for key githubWorkflowTrigger, received push")
 }
 
@@ -201,15 +201,40 @@ func TestCosignMatchSignatures(t *testing.T) {
 	subject2 := "*@nirmata.com"
 	issuer2 := "https://github.com/login/oauth"
 
-	matchErr := matchSignatures(sigs, subject1, issuer1, extensions)
+	matchErr := matchSignatures(sigs, subject1, "", issuer1, "", extensions)
 	assert.NilError(t, matchErr)
 
-	matchErr = matchSignatures(sigs, subject2, issuer2, nil)
+	matchErr = matchSignatures(sigs, subject2, "", issuer2, "", nil)
 	assert.NilError(t, matchErr)
 
-	matchErr = matchSignatures(sigs, subject2, issuer1, nil)
+	matchErr = matchSignatures(sigs, subject2, "", issuer1, "", nil)
 	assert.Error(t, matchErr, "subject mismatch: expected *@nirmata.com, received https://github.com/JimBugwadia/demo-java-tomcat/.github/workflows/publish.yaml@refs/tags/v0.0.22; issuer mismatch: expected https://token.actions.githubusercontent.com, received https://github.com/login/oauth")
 
-	matchErr = matchSignatures(sigs, subject2, issuer2, extensions)
+	matchErr = matchSignatures(sigs, subject2, "", issuer2, "", extensions)
 	assert.ErrorContains(t, matchErr, "extension mismatch")
 }
+
+func TestCosignMatchSignaturesRegExp(t *testing.T) {
+	pem := "-----BEGIN CERTIFICATE-----\nMIIDtzCCAzygAwIBAgIUX9MdOHZMlRONmc0Iu3DtiLXLVLYwCgYIKoZIzj0EAwMw\nNzEVMBMGA1UEChMMc2lnc3RvcmUuZGV2MR4wHAYDVQQDExVzaWdzdG9yZS1pbnRl\ncm1lZGlhdGUwHhcNMjIxMDA3MTkyNDI0WhcNMjIxMDA3MTkzNDI0WjAAMFkwEwYH\nKoZIzj0CAQYIKoZIzj0DAQcDQgAE0+a5/FhwY4fREWP++3V4rciGiqWGRgHaiP1z\nSlWihKkU71sBVeTzjdrcN8wXzBAefqh5URBfCeE8pJRfQsVKxKOCAlswggJXMA4G\nA1UdDwEB/wQEAwIHgDATBgNVHSUEDDAKBggrBgEFBQcDAzAdBgNVHQ4EFgQUJy79\nhpkwHtXtLWOvFu/icY56bwgwHwYDVR0jBBgwFoAU39Ppz1YkEZb5qNjpKFWixi4Y\nZD8wbgYDVR0RAQH/BGQwYoZgaHR0cHM6Ly9naXRodWIuY29tL0ppbUJ1Z3dhZGlh\nL2RlbW8tamF2YS10b21jYXQvLmdpdGh1Yi93b3JrZmxvd3MvcHVibGlzaC55YW1s\nQHJlZnMvdGFncy92MC4wLjIyMDkGCisGAQQBg78wAQEEK2h0dHBzOi8vdG9rZW4u\nYWN0aW9ucy5naXRodWJ1c2VyY29udGVudC5jb20wEgYKKwYBBAGDvzABAgQEcHVz\naDA2BgorBgEEAYO/MAEDBChjNzY0NTI4NGZhN2FlYmU1NTQ2MThlZWU4NzliNGQ2\nOTQ3Zjg1NjRlMB8GCisGAQQBg78wAQQEEWJ1aWxkLXNpZ24tYXR0ZXN0MCoGCisG\nAQQBg78wAQUEHEppbUJ1Z3dhZGlhL2RlbW8tamF2YS10b21jYXQwHwYKKwYBBAGD\nvzABBgQRcmVmcy90YWdzL3YwLjAuMjIwgYoGCisGAQQB1nkCBAIEfAR6AHgAdgAI\nYJLwKFL/aEXR0WsnhJxFZxisFj3DONJt5rwiBjZvcgAAAYOz5+pbAAAEAwBHMEUC\nIBb8fwsLBOu+qJkL6UhT4pwGvRVAN2n74BF1BL703rqPAiEAznbfgYJbqA+JIUiQ\nwwLiFOD8pqidSl+HhW8Lhdg3o+wwCgYIKoZIzj0EAwMDaQAwZgIxAJIBIkZBhM+K\nkBIFNeuWBsyVaAcFRallz3C8jvPQCPbec0ZpIsw624dUs8zD3c96AQIxALf875rt\n+oZgwE6hsDazJzoTcBZ1mYVF6bAlwVdtMiC98aApG6T+qaBirxSgu7IGQw==\n-----END CERTIFICATE-----\n"
+	cert, err := loadCert([]byte(pem))
+	assert.NilError(t, err)
+	sigs := []oci.Signature{testSignature{cert: cert}}
+
+	subjectRegExp := `^https://github\.com/JimBugwadia/demo-java-tomcat/\.github/workflows/publish\.yaml@refs/(heads/main|tags/v[0-9.]+)$`
+	issuerRegExp := `^https://token\.actions\.githubusercontent\.com$`
+
+	matchErr := matchSignatures(sigs, "", subjectRegExp, "", issuerRegExp, nil)
+	assert.NilError(t, matchErr)
+
+	matchErr = matchSignatures(sigs, "https://github.com/JimBugwadia/*", subjectRegExp, "", issuerRegExp, map[string]string{"githubWorkflowTrigger": "push"})
+	assert.NilError(t, matchErr)
+
+	matchErr = matchSignatures(sigs, "", `@refs/heads/main$`, "", issuerRegExp, nil)
+	assert.Error(t, matchErr, "subject mismatch: expected to match @refs/heads/main$, received https://github.com/JimBugwadia/demo-java-tomcat/.github/workflows/publish.yaml@refs/tags/v0.0.22")
+
+	matchErr = matchSignatures(sigs, "", subjectRegExp, "", `^https://github\.com/login/oauth$`, nil)
+	assert.Error(t, matchErr, "issuer mismatch: expected to match ^https://github\\.com/login/oauth$, received https://token.actions.githubusercontent.com")
+
+	matchErr = matchSignatures(sigs, "", "(", "", "", nil)
+	assert.ErrorContains(t, matchErr, "invalid subject regular expression (")
+}
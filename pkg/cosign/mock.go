@@ -37,11 +37,11 @@ type mock struct {
 	data map[string][]cosign.SignedPayload
 }
 
-func (m *mock) VerifyImageSignatures(_ context.Context, signedImgRef name.Reference, _ *cosign.CheckOpts) ([]oci.Signature, bool, error) {
+func (m *mock) VerifyImageSignatures(_ context.Context, signedImgRef name.Reference, _ *cosign.CheckOpts, _ TrustedKeys) ([]oci.Signature, bool, error) {
 	return m.getSignatures(signedImgRef)
 }
 
-func (m *mock) VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
+func (m *mock) VerifyImageAttestations(ctx context.Context, signedImgRef name.Reference, co *cosign.CheckOpts, _ TrustedKeys) (checkedAttestations []oci.Signature, bundleVerified bool, err error) {
 	return m.getSignatures(signedImgRef)
 }
 
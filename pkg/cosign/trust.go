@@ -0,0 +1,99 @@
+package cosign
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/tuf"
+)
+
+const (
+	// cosign reads alternate Rekor and CT log public keys from the files referenced by these variables
+	rekorPublicKeyEnv = "SIGSTORE_REKOR_PUBLIC_KEY"
+	ctLogPublicKeyEnv = "SIGSTORE_CT_LOG_PUBLIC_KEY_FILE"
+)
+
+// trustedKeysLock guards the cosign environment variables, verifications with custom Rekor or
+// CT log keys hold it exclusively while the variables are set. Only the local verification of the
+// fetched signatures runs with the lock held, cosign v1 reads the keys from these variables only.
+var trustedKeysLock sync.RWMutex
+
+// TrustedKeys are the custom Rekor and CT log public keys of a verification
+type TrustedKeys struct {
+	RekorPubKey string
+	CTLogPubKey string
+}
+
+// InitializeTUF initializes the TUF client used to resolve the Sigstore roots and keys from
+// a trusted root and a mirror, instead of the public Sigstore TUF repository. The mirror can
+// be a file:// URL to a local copy of the repository for clusters without network access.
+func InitializeTUF(ctx context.Context, mirror string, root []byte) error {
+	if mirror == "" {
+		mirror = tuf.DefaultRemoteRoot
+	}
+	if err := tuf.Initialize(ctx, mirror, root); err != nil {
+		return errors.Wrapf(err, "failed to initialize TUF from %s", mirror)
+	}
+	return nil
+}
+
+// withTrustedKeys runs the verification with the custom Rekor and CT log public keys, the
+// signatures must be fetched before
+func withTrustedKeys(keys TrustedKeys, verify func() error) error {
+	if keys.RekorPubKey == "" && keys.CTLogPubKey == "" {
+		trustedKeysLock.RLock()
+		defer trustedKeysLock.RUnlock()
+		return verify()
+	}
+
+	trustedKeysLock.Lock()
+	defer trustedKeysLock.Unlock()
+	dir, err := os.MkdirTemp("", "kyverno-cosign-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the trusted keys directory")
+	}
+	defer os.RemoveAll(dir)
+
+	files := []struct {
+		env  string
+		file string
+		key  string
+	}{
+		{env: rekorPublicKeyEnv, file: "rekor.pub", key: keys.RekorPubKey},
+		{env: ctLogPublicKeyEnv, file: "ctfe.pub", key: keys.CTLogPubKey},
+	}
+	for _, k := range files {
+		if k.key == "" {
+			continue
+		}
+		path := filepath.Join(dir, k.file)
+		if err := os.WriteFile(path, []byte(k.key), 0o600); err != nil {
+			return errors.Wrapf(err, "failed to write %s", k.file)
+		}
+		restore, err := setEnv(k.env, path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set %s", k.env)
+		}
+		defer restore()
+	}
+
+	return verify()
+}
+
+// setEnv sets an environment variable and returns a function restoring its previous value
+func setEnv(key, value string) (func(), error) {
+	previous, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		return nil, err
+	}
+	return func() {
+		if ok {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}, nil
+}
@@ -0,0 +1,143 @@
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/cosign/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/pkg/oci"
+	"github.com/sigstore/cosign/pkg/oci/mutate"
+	"github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/sigstore/cosign/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"gotest.tools/assert"
+)
+
+const rekorPubKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE2G2Y+2tabdTV5BcGiBIx0a9fAFwr
+kBbmLSGtks4L3qX6yYY0zufBnhC8Ur/iy55GhWP/9A/bY2LhC30M9+RYtw==
+-----END PUBLIC KEY-----`
+
+func TestWithTrustedKeys(t *testing.T) {
+	os.Unsetenv(rekorPublicKeyEnv)
+	t.Setenv(ctLogPublicKeyEnv, "/etc/sigstore/ctfe.pub")
+
+	err := withTrustedKeys(TrustedKeys{RekorPubKey: rekorPubKey}, func() error {
+		path, ok := os.LookupEnv(rekorPublicKeyEnv)
+		assert.Assert(t, ok)
+		data, err := os.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Equal(t, string(data), rekorPubKey)
+		assert.Equal(t, os.Getenv(ctLogPublicKeyEnv), "/etc/sigstore/ctfe.pub")
+		return nil
+	})
+	assert.NilError(t, err)
+	_, ok := os.LookupEnv(rekorPublicKeyEnv)
+	assert.Assert(t, !ok)
+
+	err = withTrustedKeys(TrustedKeys{CTLogPubKey: rekorPubKey}, func() error {
+		assert.Assert(t, os.Getenv(ctLogPublicKeyEnv) != "/etc/sigstore/ctfe.pub")
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, os.Getenv(ctLogPublicKeyEnv), "/etc/sigstore/ctfe.pub")
+
+	err = withTrustedKeys(TrustedKeys{}, func() error {
+		_, ok := os.LookupEnv(rekorPublicKeyEnv)
+		assert.Assert(t, !ok)
+		return nil
+	})
+	assert.NilError(t, err)
+}
+
+func TestDriverFetchesSignaturesWithoutLock(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	img, err := random.Image(256, 1)
+	assert.NilError(t, err)
+	tag, err := name.NewTag(strings.TrimPrefix(server.URL, "http://") + "/kyverno/test:signed")
+	assert.NilError(t, err)
+	assert.NilError(t, gcrremote.Write(tag, img))
+	h, err := img.Digest()
+	assert.NilError(t, err)
+	digest := tag.Context().Digest(h.String())
+
+	// sign the image digest and push the signature
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	signer, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	assert.NilError(t, err)
+	data, err := (&payload.Cosign{Image: digest}).MarshalJSON()
+	assert.NilError(t, err)
+	sig, err := signer.SignMessage(bytes.NewReader(data))
+	assert.NilError(t, err)
+	ociSig, err := static.NewSignature(data, base64.StdEncoding.EncodeToString(sig))
+	assert.NilError(t, err)
+	se, err := remote.SignedImage(digest)
+	assert.NilError(t, err)
+	signed, err := mutate.AttachSignatureToImage(se, ociSig)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.WriteSignatures(digest.Repository, signed))
+
+	co := &cosign.CheckOpts{SigVerifier: signer, ClaimVerifier: cosign.SimpleClaimVerifier}
+	// the registry is reached while a verification with custom keys holds the lock
+	trustedKeysLock.Lock()
+	fetched, err := fetchSignatures(tag, co, remote.SignatureTag)
+	trustedKeysLock.Unlock()
+	assert.NilError(t, err)
+	assert.Equal(t, len(fetched.signatures), 1)
+	assert.Equal(t, fetched.digest, h)
+
+	signatures, bundleVerified, err := (&driver{}).VerifyImageSignatures(context.Background(), tag, co, TrustedKeys{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(signatures), 1)
+	assert.Assert(t, !bundleVerified)
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	co.SigVerifier, err = signature.LoadECDSAVerifier(&other.PublicKey, crypto.SHA256)
+	assert.NilError(t, err)
+	_, _, err = (&driver{}).VerifyImageSignatures(context.Background(), tag, co, TrustedKeys{})
+	assert.Assert(t, errors.Is(err, cosign.ErrNoMatchingSignatures))
+
+	_, _, err = (&driver{}).VerifyImageAttestations(context.Background(), tag, co, TrustedKeys{})
+	assert.Assert(t, errors.Is(err, cosign.ErrNoMatchingAttestations))
+}
+
+type bundledTestSignature struct {
+	testSignature
+	bundle *bundle.RekorBundle
+}
+
+func (ts bundledTestSignature) Bundle() (*bundle.RekorBundle, error) {
+	return ts.bundle, nil
+}
+
+func TestBundledSignatures(t *testing.T) {
+	bundled := bundledTestSignature{bundle: &bundle.RekorBundle{}}
+	unbundled := bundledTestSignature{}
+
+	signatures, err := bundledSignatures([]oci.Signature{unbundled, bundled})
+	assert.NilError(t, err)
+	assert.Equal(t, len(signatures), 1)
+	assert.Equal(t, signatures[0], oci.Signature(bundled))
+
+	_, err = bundledSignatures([]oci.Signature{unbundled})
+	assert.Error(t, err, "no signature is bundled with a transparency log entry, it is required when the transparency log is ignored")
+}
@@ -0,0 +1,212 @@
+package cosign
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+	ssldsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/cosign/pkg/oci"
+	"github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/sigstore/cosign/pkg/oci/static"
+	"github.com/sigstore/cosign/pkg/types"
+	rekorclient "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature/dsse"
+)
+
+// fetchedSignatures are the signatures, or attestations, of an image fetched from the registry
+type fetchedSignatures struct {
+	digest     v1.Hash
+	signatures []oci.Signature
+	// errors of the signatures that could not be fetched
+	errs []string
+}
+
+// fetchSignatures fetches the signatures stored under the tag of the image digest, with their
+// payloads, so that they can be verified without network calls to the registry
+func fetchSignatures(ref name.Reference, co *cosign.CheckOpts, tag func(name.Reference, ...remote.Option) (name.Tag, error)) (*fetchedSignatures, error) {
+	if co.RootCerts == nil && co.SigVerifier == nil {
+		return nil, errors.New("one of verifier or root certs is required")
+	}
+	digest, err := remote.ResolveDigest(ref, co.RegistryClientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	h, err := v1.NewHash(digest.Identifier())
+	if err != nil {
+		return nil, err
+	}
+	st, err := tag(digest, co.RegistryClientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	sigs, err := remote.Signatures(st, co.RegistryClientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	sl, err := sigs.Get()
+	if err != nil {
+		return nil, err
+	}
+	fetched := &fetchedSignatures{digest: h}
+	for _, sig := range sl {
+		sig, err := static.Copy(sig)
+		if err != nil {
+			fetched.errs = append(fetched.errs, err.Error())
+			continue
+		}
+		fetched.signatures = append(fetched.signatures, sig)
+	}
+	return fetched, nil
+}
+
+// verify returns the signatures accepted by the verification, noMatch is returned when none is
+func (f *fetchedSignatures) verify(noMatch error, verify func(oci.Signature) (bool, error)) ([]oci.Signature, bool, error) {
+	var checked []oci.Signature
+	bundleVerified := false
+	errs := f.errs
+	for _, sig := range f.signatures {
+		verified, err := verify(sig)
+		bundleVerified = bundleVerified || verified
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		checked = append(checked, sig)
+	}
+	if len(checked) == 0 {
+		return nil, false, fmt.Errorf("%w:\n%s", noMatch, strings.Join(errs, "\n "))
+	}
+	return checked, bundleVerified, nil
+}
+
+// verifyAttestation verifies an attestation like cosign.VerifyImageSignature verifies a signature,
+// cosign only verifies attestations along with their fetching
+func verifyAttestation(ctx context.Context, att oci.Signature, h v1.Hash, co *cosign.CheckOpts) (bool, error) {
+	verifier := co.SigVerifier
+	var cert *x509.Certificate
+	if verifier == nil {
+		var err error
+		cert, err = att.Cert()
+		if err != nil {
+			return false, err
+		}
+		if cert == nil {
+			return false, cosign.NewVerificationError("no certificate found on attestation")
+		}
+		chain, err := att.Chain()
+		if err != nil {
+			return false, err
+		}
+		// the intermediate certificates are those of the chain, without the root
+		if len(chain) <= 1 {
+			co.IntermediateCerts = nil
+		} else if co.IntermediateCerts == nil {
+			pool := x509.NewCertPool()
+			for _, cert := range chain[:len(chain)-1] {
+				pool.AddCert(cert)
+			}
+			co.IntermediateCerts = pool
+		}
+		verifier, err = cosign.ValidateAndUnpackCert(cert, co)
+		if err != nil {
+			return false, err
+		}
+	}
+	payload, err := att.Payload()
+	if err != nil {
+		return false, err
+	}
+	env := ssldsse.Envelope{}
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return false, err
+	}
+	if env.PayloadType != types.IntotoPayloadType {
+		return false, cosign.NewVerificationError("invalid payloadType %s on envelope. Expected %s", env.PayloadType, types.IntotoPayloadType)
+	}
+	envVerifier, err := ssldsse.NewEnvelopeVerifier(&dsse.VerifierAdapter{SignatureVerifier: verifier})
+	if err != nil {
+		return false, err
+	}
+	if _, err := envVerifier.Verify(&env); err != nil {
+		return false, err
+	}
+	if co.ClaimVerifier != nil {
+		if err := co.ClaimVerifier(att, h, co.Annotations); err != nil {
+			return false, err
+		}
+	}
+	verified, err := cosign.VerifyBundle(ctx, att, co.RekorClient)
+	if err != nil && co.RekorClient == nil {
+		return false, fmt.Errorf("unable to verify bundle: %w", err)
+	}
+	if verified || co.RekorClient == nil {
+		return verified, nil
+	}
+	// without bundle, the attestation is looked up in the transparency log
+	var pem []byte
+	if co.SigVerifier != nil {
+		pub, err := co.SigVerifier.PublicKey(co.PKOpts...)
+		if err != nil {
+			return false, err
+		}
+		pem, err = cryptoutils.MarshalPublicKeyToPEM(pub)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		pem, err = cryptoutils.MarshalCertificateToPEM(cert)
+		if err != nil {
+			return false, err
+		}
+	}
+	entry, err := tlogEntry(ctx, co.RekorClient, att, pem)
+	if err != nil {
+		return false, err
+	}
+	if cert != nil {
+		return false, cosign.CheckExpiry(cert, time.Unix(*entry.IntegratedTime, 0))
+	}
+	return false, nil
+}
+
+// tlogEntry returns the earliest valid transparency log entry of the signature
+func tlogEntry(ctx context.Context, rekorClient *rekorclient.Rekor, sig oci.Signature, pem []byte) (*models.LogEntryAnon, error) {
+	b64sig, err := sig.Base64Signature()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := sig.Payload()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := cosign.FindTlogEntry(ctx, rekorClient, b64sig, payload, pem)
+	if err != nil {
+		return nil, err
+	}
+	var earliest *models.LogEntryAnon
+	var errs []string
+	for i := range entries {
+		entry := entries[i]
+		if err := cosign.VerifyTLogEntry(ctx, rekorClient, &entry); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if earliest == nil || *entry.IntegratedTime < *earliest.IntegratedTime {
+			earliest = &entry
+		}
+	}
+	if earliest == nil {
+		return nil, fmt.Errorf("no valid tlog entries found %s", strings.Join(errs, ", "))
+	}
+	return earliest, nil
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"context"
+)
+
+// cachedAPICall returns the data stored for key in the request scoped cache or in the global cache,
+// otherwise it calls fetch and stores successful results. The global cache is only used when global is true.
+func cachedAPICall(ctx *PolicyContext, key string, global bool, fetch func() ([]byte, error)) ([]byte, error) {
+	goctx := context.TODO()
+	requestCache := ctx.apiCallRequestCache
+	globalCache := ctx.apiCallCache
+	if !global {
+		globalCache = nil
+	}
+	if requestCache != nil {
+		if data, ok := requestCache.Get(goctx, key); ok {
+			return data, nil
+		}
+	}
+	if globalCache != nil {
+		if data, ok := globalCache.Get(goctx, key); ok {
+			if requestCache != nil {
+				requestCache.Set(goctx, key, data)
+			}
+			return data, nil
+		}
+	}
+	data, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if requestCache != nil {
+		requestCache.Set(goctx, key, data)
+	}
+	if globalCache != nil {
+		globalCache.Set(goctx, key, data)
+	}
+	return data, nil
+}
@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+)
+
+// well-known predicate types with a normalized summary
+const (
+	predicateTypeCosignVuln       = "https://cosign.sigstore.dev/attestation/vuln/v1"
+	predicateTypeCosignVulnLegacy = "cosign.sigstore.dev/attestation/vuln/v1"
+)
+
+// severities are the vulnerability severities counted in the summary of vulnerability scans,
+// other severities are counted as unknown
+var severities = []string{"critical", "high", "medium", "low", "unknown"}
+
+// attestationSummary returns the normalized fields of the statements of well-known predicate types,
+// exposed to the attestation conditions as the attestation variable, and nil for other types:
+//   - vulnerability scans (cosign vuln) have the scanner, the scan timestamp, the vulnerability counts
+//     per severity and the vulnerability IDs, from Trivy and Grype JSON results
+//   - SPDX and CycloneDX SBOMs have the format, the creation timestamp and the packages with their
+//     name, version and purl
+//
+// Timestamps are RFC3339 UTC times that can be compared with the time JMESPath functions.
+func attestationSummary(statement map[string]interface{}) map[string]interface{} {
+	predicateType, _ := statement["predicateType"].(string)
+	predicate, ok := statement["predicate"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	switch predicateType {
+	case predicateTypeCosignVuln, predicateTypeCosignVulnLegacy:
+		return vulnerabilityScanSummary(predicate)
+	case in_toto.PredicateSPDX:
+		return spdxSummary(predicate)
+	case in_toto.PredicateCycloneDX:
+		return cycloneDXSummary(predicate)
+	}
+
+	return nil
+}
+
+func vulnerabilityScanSummary(predicate map[string]interface{}) map[string]interface{} {
+	scanner := objectField(predicate, "scanner")
+	counts := map[string]interface{}{}
+	for _, severity := range severities {
+		counts[severity] = 0
+	}
+
+	ids := []interface{}{}
+	vulnerabilities := scannedVulnerabilities(scanner["result"])
+	for _, vulnerability := range vulnerabilities {
+		severity := normalizeSeverity(vulnerability.severity)
+		counts[severity] = counts[severity].(int) + 1
+		if vulnerability.id != "" {
+			ids = append(ids, vulnerability.id)
+		}
+	}
+	counts["total"] = len(vulnerabilities)
+
+	return map[string]interface{}{
+		"type": "vulnerabilityScan",
+		"scanner": map[string]interface{}{
+			"uri":     stringField(scanner, "uri"),
+			"version": stringField(scanner, "version"),
+		},
+		"timestamp":        normalizeTimestamp(stringField(objectField(predicate, "metadata"), "scanFinishedOn")),
+		"vulnerabilities":  counts,
+		"vulnerabilityIDs": ids,
+	}
+}
+
+type vulnerability struct {
+	id       string
+	severity string
+}
+
+// scannedVulnerabilities returns the vulnerabilities of a Trivy or Grype JSON scan result
+func scannedVulnerabilities(result interface{}) []vulnerability {
+	report, ok := result.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var vulnerabilities []vulnerability
+	// Trivy
+	for _, target := range arrayField(report, "Results") {
+		for _, v := range arrayField(asObject(target), "Vulnerabilities") {
+			vulnerabilities = append(vulnerabilities, vulnerability{
+				id:       stringField(asObject(v), "VulnerabilityID"),
+				severity: stringField(asObject(v), "Severity"),
+			})
+		}
+	}
+
+	// Grype
+	for _, match := range arrayField(report, "matches") {
+		v := objectField(asObject(match), "vulnerability")
+		vulnerabilities = append(vulnerabilities, vulnerability{
+			id:       stringField(v, "id"),
+			severity: stringField(v, "severity"),
+		})
+	}
+
+	return vulnerabilities
+}
+
+func normalizeSeverity(severity string) string {
+	severity = strings.ToLower(severity)
+	if severity == "negligible" {
+		return "low"
+	}
+
+	for _, s := range severities {
+		if s == severity {
+			return s
+		}
+	}
+
+	return "unknown"
+}
+
+func spdxSummary(predicate map[string]interface{}) map[string]interface{} {
+	packages := []interface{}{}
+	for _, p := range arrayField(predicate, "packages") {
+		pkg := asObject(p)
+		var purl string
+		for _, ref := range arrayField(pkg, "externalRefs") {
+			if stringField(asObject(ref), "referenceType") == "purl" {
+				purl = stringField(asObject(ref), "referenceLocator")
+				break
+			}
+		}
+
+		packages = append(packages, sbomPackage(stringField(pkg, "name"), stringField(pkg, "versionInfo"), purl))
+	}
+
+	return map[string]interface{}{
+		"type":      "sbom",
+		"format":    "spdx",
+		"timestamp": normalizeTimestamp(stringField(objectField(predicate, "creationInfo"), "created")),
+		"packages":  packages,
+	}
+}
+
+func cycloneDXSummary(predicate map[string]interface{}) map[string]interface{} {
+	packages := []interface{}{}
+	var addComponents func(components []interface{})
+	addComponents = func(components []interface{}) {
+		for _, c := range components {
+			component := asObject(c)
+			packages = append(packages, sbomPackage(stringField(component, "name"), stringField(component, "version"), stringField(component, "purl")))
+			addComponents(arrayField(component, "components"))
+		}
+	}
+	addComponents(arrayField(predicate, "components"))
+
+	return map[string]interface{}{
+		"type":      "sbom",
+		"format":    "cyclonedx",
+		"timestamp": normalizeTimestamp(stringField(objectField(predicate, "metadata"), "timestamp")),
+		"packages":  packages,
+	}
+}
+
+func sbomPackage(name, version, purl string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":    name,
+		"version": version,
+		"purl":    purl,
+	}
+}
+
+// normalizeTimestamp converts an RFC3339 timestamp to UTC, other values are returned unchanged
+func normalizeTimestamp(timestamp string) string {
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return timestamp
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func asObject(value interface{}) map[string]interface{} {
+	object, _ := value.(map[string]interface{})
+	return object
+}
+
+func objectField(object map[string]interface{}, key string) map[string]interface{} {
+	return asObject(object[key])
+}
+
+func arrayField(object map[string]interface{}, key string) []interface{} {
+	array, _ := object[key].([]interface{})
+	return array
+}
+
+func stringField(object map[string]interface{}, key string) string {
+	value, _ := object[key].(string)
+	return value
+}
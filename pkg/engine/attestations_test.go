@@ -0,0 +1,277 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+var trivyStatement = `
+{
+    "predicateType": "https://cosign.sigstore.dev/attestation/vuln/v1",
+    "predicate": {
+        "invocation": {},
+        "scanner": {
+            "uri": "pkg:github/aquasecurity/trivy@v0.31.3",
+            "version": "0.31.3",
+            "result": {
+                "SchemaVersion": 2,
+                "ArtifactName": "ghcr.io/kyverno/test-verify-image:signed",
+                "Results": [
+                    {
+                        "Target": "alpine 3.16",
+                        "Vulnerabilities": [
+                            {"VulnerabilityID": "CVE-2022-37434", "Severity": "CRITICAL"},
+                            {"VulnerabilityID": "CVE-2022-2097", "Severity": "MEDIUM"}
+                        ]
+                    },
+                    {
+                        "Target": "app/go.sum",
+                        "Vulnerabilities": [
+                            {"VulnerabilityID": "CVE-2022-32149", "Severity": "HIGH"}
+                        ]
+                    }
+                ]
+            }
+        },
+        "metadata": {
+            "scanStartedOn": "2022-10-12T10:15:01+02:00",
+            "scanFinishedOn": "2022-10-12T10:15:09.123+02:00"
+        }
+    }
+}
+`
+
+var grypeStatement = `
+{
+    "predicateType": "cosign.sigstore.dev/attestation/vuln/v1",
+    "predicate": {
+        "scanner": {
+            "uri": "pkg:github/anchore/grype@v0.50.2",
+            "version": "0.50.2",
+            "result": {
+                "matches": [
+                    {"vulnerability": {"id": "CVE-2021-22946", "severity": "High"}},
+                    {"vulnerability": {"id": "CVE-2021-22947", "severity": "Negligible"}},
+                    {"vulnerability": {"id": "GHSA-xxxx", "severity": "Unrated"}}
+                ]
+            }
+        },
+        "metadata": {
+            "scanFinishedOn": "2022-10-12T08:15:09Z"
+        }
+    }
+}
+`
+
+var spdxStatement = `
+{
+    "predicateType": "https://spdx.dev/Document",
+    "predicate": {
+        "spdxVersion": "SPDX-2.2",
+        "creationInfo": {
+            "created": "2022-10-12T08:15:09Z"
+        },
+        "packages": [
+            {
+                "name": "musl",
+                "versionInfo": "1.2.3-r0",
+                "externalRefs": [
+                    {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:musl:musl:1.2.3-r0:*:*:*:*:*:*:*"},
+                    {"referenceCategory": "PACKAGE_MANAGER", "referenceType": "purl", "referenceLocator": "pkg:alpine/musl@1.2.3-r0"}
+                ]
+            },
+            {
+                "name": "busybox",
+                "versionInfo": "1.35.0-r17"
+            }
+        ]
+    }
+}
+`
+
+var cycloneDXStatement = `
+{
+    "predicateType": "https://cyclonedx.org/bom",
+    "predicate": {
+        "bomFormat": "CycloneDX",
+        "metadata": {
+            "timestamp": "2022-10-12T10:15:09+02:00"
+        },
+        "components": [
+            {
+                "name": "app",
+                "version": "1.0.0",
+                "purl": "pkg:golang/example.com/app@1.0.0",
+                "components": [
+                    {"name": "golang.org/x/text", "version": "v0.3.7", "purl": "pkg:golang/golang.org/x/text@v0.3.7"}
+                ]
+            }
+        ]
+    }
+}
+`
+
+func unmarshalStatement(t *testing.T, statement string) map[string]interface{} {
+	var s map[string]interface{}
+	assert.NilError(t, json.Unmarshal([]byte(statement), &s))
+	return s
+}
+
+func Test_AttestationSummary(t *testing.T) {
+	testCases := []struct {
+		name      string
+		statement string
+		summary   map[string]interface{}
+	}{
+		{
+			name:      "trivy",
+			statement: trivyStatement,
+			summary: map[string]interface{}{
+				"type": "vulnerabilityScan",
+				"scanner": map[string]interface{}{
+					"uri":     "pkg:github/aquasecurity/trivy@v0.31.3",
+					"version": "0.31.3",
+				},
+				"timestamp": "2022-10-12T08:15:09Z",
+				"vulnerabilities": map[string]interface{}{
+					"critical": 1, "high": 1, "medium": 1, "low": 0, "unknown": 0, "total": 3,
+				},
+				"vulnerabilityIDs": []interface{}{"CVE-2022-37434", "CVE-2022-2097", "CVE-2022-32149"},
+			},
+		},
+		{
+			name:      "grype",
+			statement: grypeStatement,
+			summary: map[string]interface{}{
+				"type": "vulnerabilityScan",
+				"scanner": map[string]interface{}{
+					"uri":     "pkg:github/anchore/grype@v0.50.2",
+					"version": "0.50.2",
+				},
+				"timestamp": "2022-10-12T08:15:09Z",
+				"vulnerabilities": map[string]interface{}{
+					"critical": 0, "high": 1, "medium": 0, "low": 1, "unknown": 1, "total": 3,
+				},
+				"vulnerabilityIDs": []interface{}{"CVE-2021-22946", "CVE-2021-22947", "GHSA-xxxx"},
+			},
+		},
+		{
+			name:      "spdx",
+			statement: spdxStatement,
+			summary: map[string]interface{}{
+				"type":      "sbom",
+				"format":    "spdx",
+				"timestamp": "2022-10-12T08:15:09Z",
+				"packages": []interface{}{
+					map[string]interface{}{"name": "musl", "version": "1.2.3-r0", "purl": "pkg:alpine/musl@1.2.3-r0"},
+					map[string]interface{}{"name": "busybox", "version": "1.35.0-r17", "purl": ""},
+				},
+			},
+		},
+		{
+			name:      "cyclonedx",
+			statement: cycloneDXStatement,
+			summary: map[string]interface{}{
+				"type":      "sbom",
+				"format":    "cyclonedx",
+				"timestamp": "2022-10-12T08:15:09Z",
+				"packages": []interface{}{
+					map[string]interface{}{"name": "app", "version": "1.0.0", "purl": "pkg:golang/example.com/app@1.0.0"},
+					map[string]interface{}{"name": "golang.org/x/text", "version": "v0.3.7", "purl": "pkg:golang/golang.org/x/text@v0.3.7"},
+				},
+			},
+		},
+		{
+			name:      "other",
+			statement: `{"predicateType": "https://example.com/CodeReview/v1", "predicate": {"author": "alice"}}`,
+			summary:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			summary := attestationSummary(unmarshalStatement(t, tc.statement))
+			assert.DeepEqual(t, summary, tc.summary)
+		})
+	}
+}
+
+func Test_AttestationSummaryConditions(t *testing.T) {
+	conditions := []v1.AnyAllConditions{
+		{
+			AllConditions: []v1.Condition{
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ attestation.vulnerabilities.critical }}\"")},
+					Operator: "Equals",
+					RawValue: &apiextv1.JSON{Raw: []byte("1")},
+				},
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ time_after(attestation.timestamp, time_add(time_now(), '-168h')) }}\"")},
+					Operator: "Equals",
+					RawValue: &apiextv1.JSON{Raw: []byte("false")},
+				},
+			},
+		},
+	}
+
+	pass, err := evaluateConditions(conditions, context.NewContext(), unmarshalStatement(t, trivyStatement), logging.GlobalLogger())
+	assert.NilError(t, err)
+	assert.Equal(t, pass, true)
+}
+
+func Test_AttestationConditionsInSequence(t *testing.T) {
+	ctx := context.NewContext()
+	assert.NilError(t, ctx.AddContextEntry("attestation", []byte(`"user"`)))
+
+	vulnerabilityConditions := []v1.AnyAllConditions{
+		{
+			AllConditions: []v1.Condition{
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ attestation.vulnerabilities.critical }}\"")},
+					Operator: "Equals",
+					RawValue: &apiextv1.JSON{Raw: []byte("1")},
+				},
+			},
+		},
+	}
+	pass, err := evaluateConditions(vulnerabilityConditions, ctx, unmarshalStatement(t, trivyStatement), logging.GlobalLogger())
+	assert.NilError(t, err)
+	assert.Equal(t, pass, true)
+
+	// a statement without a summary sees the context of the policy, not the summary of the previous statement
+	customConditions := []v1.AnyAllConditions{
+		{
+			AllConditions: []v1.Condition{
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ attestation }}\"")},
+					Operator: "Equals",
+					RawValue: &apiextv1.JSON{Raw: []byte("\"user\"")},
+				},
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ scanner || 'none' }}\"")},
+					Operator: "Equals",
+					RawValue: &apiextv1.JSON{Raw: []byte("\"none\"")},
+				},
+			},
+		},
+	}
+	customStatement := map[string]interface{}{
+		"predicateType": "https://example.com/custom/v1",
+		"predicate": map[string]interface{}{
+			"foo": "bar",
+		},
+	}
+	pass, err = evaluateConditions(customConditions, ctx, customStatement, logging.GlobalLogger())
+	assert.NilError(t, err)
+	assert.Equal(t, pass, true)
+
+	attestation, err := ctx.Query("attestation")
+	assert.NilError(t, err)
+	assert.Equal(t, attestation, "user")
+}
@@ -8,6 +8,7 @@ import (
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/logging"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/pkg/errors"
@@ -101,6 +102,8 @@ type context struct {
 	jsonRaw            []byte
 	jsonRawCheckpoints [][]byte
 	images             map[string]map[string]apiutils.ImageInfo
+	// clock is the current time of time functions in queries, nil for the actual time
+	clock jmespath.Clock
 }
 
 // NewContext returns a new context
@@ -108,6 +111,13 @@ func NewContext() Interface {
 	return NewContextFromRaw([]byte(`{}`))
 }
 
+// NewContextWithClock returns a new context, time functions in its queries use the clock as the current time
+func NewContextWithClock(clock jmespath.Clock) Interface {
+	ctx := NewContextFromRaw([]byte(`{}`)).(*context)
+	ctx.clock = clock
+	return ctx
+}
+
 // NewContextFromRaw returns a new context initialized with raw data
 func NewContextFromRaw(raw []byte) Interface {
 	ctx := context{
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+// loadGlobalContext adds the data of the referenced global context entry to the context
+func loadGlobalContext(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) error {
+	name := entry.GlobalReference.Name
+	contextData, err := fetchGlobalContextData(entry, ctx)
+	if err != nil {
+		return err
+	}
+	if entry.GlobalReference.JMESPath != "" {
+		path, err := variables.SubstituteAll(logger, ctx.jsonContext, entry.GlobalReference.JMESPath)
+		if err != nil {
+			return fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.GlobalReference.JMESPath, err)
+		}
+		results, err := applyJMESPathJSON(path.(string), contextData)
+		if err != nil {
+			return err
+		}
+		contextData, err = json.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("failed to marshal data %v for context entry %s: %v", results, entry.Name, err)
+		}
+	}
+	if err := ctx.jsonContext.AddContextEntry(entry.Name, contextData); err != nil {
+		return fmt.Errorf("failed to add global context entry %s to context, error: %v", name, err)
+	}
+	logger.V(4).Info("added global context entry", "name", name, "len", len(contextData))
+	return nil
+}
+
+// fetchGlobalContextData returns the data of the global context entry referenced by the context entry,
+// the CLI serves it from the fixtures
+func fetchGlobalContextData(entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
+	name := entry.GlobalReference.Name
+	if ctx.cliStore.GetMock() {
+		data, ok := ctx.cliStore.GetGlobalContextFixture(name)
+		if !ok {
+			return nil, fmt.Errorf("no global context fixture for %s referenced by context entry %s", name, entry.Name)
+		}
+		return data, nil
+	}
+	if ctx.globalContext == nil {
+		return nil, fmt.Errorf("failed to load global context entry %s for context entry %s: global context not available", name, entry.Name)
+	}
+	globalEntry, ok := ctx.globalContext.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("global context entry %s referenced by context entry %s not found", name, entry.Name)
+	}
+	data, err := globalEntry.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data of global context entry %s for context entry %s: %v", name, entry.Name, err)
+	}
+	contextData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data of global context entry %s: %v", name, err)
+	}
+	return contextData, nil
+}
+
+// ExecuteAPICall performs the APICall of a global context entry and returns the JSON response,
+// transformed with the APICall JMESPath if set. Variables are not substituted.
+func ExecuteAPICall(ctx context.Context, logger logr.Logger, client dclient.Interface, name string, call kyvernov1.APICall) ([]byte, error) {
+	var data []byte
+	var err error
+	if call.Service != nil {
+		data, err = callService(logger, kyvernov1.ContextEntry{Name: name, APICall: &call}, call.Service)
+	} else {
+		if client == nil {
+			return nil, fmt.Errorf("failed to get resource with raw url %s: client not available", call.URLPath)
+		}
+		data, err = client.RawAbsPath(ctx, call.URLPath)
+		if err != nil {
+			err = fmt.Errorf("failed to get resource with raw url %s: %v", call.URLPath, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if call.JMESPath == "" {
+		return data, nil
+	}
+	results, err := applyJMESPathJSON(call.JMESPath, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(results)
+}
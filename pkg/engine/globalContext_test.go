@@ -0,0 +1,141 @@
+package engine
+
+import (
+	gocontext "context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+)
+
+type fakeGlobalContextEntry struct {
+	data interface{}
+	err  error
+}
+
+func (e *fakeGlobalContextEntry) Get() (interface{}, error) { return e.data, e.err }
+func (e *fakeGlobalContextEntry) Stop()                     {}
+
+func Test_LoadGlobalContext(t *testing.T) {
+	store := globalcontextstore.New()
+	store.Set("deployments", &fakeGlobalContextEntry{
+		data: []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "api", "namespace": "apps"}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "web", "namespace": "apps"}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "db", "namespace": "data"}},
+		},
+	})
+	store.Set("broken", &fakeGlobalContextEntry{err: errors.New("data not yet available")})
+
+	testCases := []struct {
+		name      string
+		reference kyvernov1.GlobalContextEntryReference
+		noStore   bool
+		expected  interface{}
+		wantErr   string
+	}{{
+		name:      "without JMESPath",
+		reference: kyvernov1.GlobalContextEntryReference{Name: "deployments", JMESPath: ""},
+		expected: []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "api", "namespace": "apps"}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "web", "namespace": "apps"}},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "db", "namespace": "data"}},
+		},
+	}, {
+		name:      "with variables in JMESPath",
+		reference: kyvernov1.GlobalContextEntryReference{Name: "deployments", JMESPath: "[?metadata.namespace == '{{request.object.metadata.namespace}}'].metadata.name"},
+		expected:  []interface{}{"api", "web"},
+	}, {
+		name:      "entry not found",
+		reference: kyvernov1.GlobalContextEntryReference{Name: "missing"},
+		wantErr:   "global context entry missing referenced by context entry gctx not found",
+	}, {
+		name:      "entry not ready",
+		reference: kyvernov1.GlobalContextEntryReference{Name: "broken"},
+		wantErr:   "data not yet available",
+	}, {
+		name:      "no store",
+		reference: kyvernov1.GlobalContextEntryReference{Name: "deployments"},
+		noStore:   true,
+		wantErr:   "global context not available",
+	}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			reference := test.reference
+			entry := kyvernov1.ContextEntry{
+				Name:            "gctx",
+				GlobalReference: &reference,
+			}
+			policyContext := newServiceCallPolicyContext(t)
+			if !test.noStore {
+				policyContext = policyContext.WithGlobalContext(store)
+			}
+			err := loadGlobalContext(logging.GlobalLogger(), entry, policyContext)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			result, err := policyContext.JSONContext().Query("gctx")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, test.expected)
+		})
+	}
+}
+
+func Test_LoadGlobalContextFixtures(t *testing.T) {
+	cliStore := &store.Store{}
+	cliStore.SetMock(true)
+	entries := []kyvernov1.ContextEntry{{
+		Name:            "frozen",
+		GlobalReference: &kyvernov1.GlobalContextEntryReference{Name: "frozen-namespaces", JMESPath: "contains(@, '{{request.object.metadata.namespace}}')"},
+	}}
+
+	// without fixture nor value, the entry is not ignored
+	policyContext := newServiceCallPolicyContext(t).WithCLIStore(cliStore)
+	err := LoadContext(logging.GlobalLogger(), nil, entries, policyContext, "rule")
+	assert.ErrorContains(t, err, "no global context fixture for frozen-namespaces referenced by context entry frozen")
+
+	// the values file provides the entry
+	cliStore.SetContext(store.Context{Policies: []store.Policy{{Rules: []store.Rule{{Name: "rule", Values: map[string]interface{}{"frozen": false}}}}}})
+	policyContext = newServiceCallPolicyContext(t).WithCLIStore(cliStore)
+	assert.NilError(t, LoadContext(logging.GlobalLogger(), nil, entries, policyContext, "rule"))
+	result, err := policyContext.JSONContext().Query("frozen")
+	assert.NilError(t, err)
+	assert.Equal(t, result, false)
+
+	// the fixtures override the values
+	cliStore.SetFixtures(store.Fixtures{
+		GlobalContextEntries: map[string][]byte{"frozen-namespaces": []byte(`["apps"]`)},
+	})
+	policyContext = newServiceCallPolicyContext(t).WithCLIStore(cliStore)
+	assert.NilError(t, LoadContext(logging.GlobalLogger(), nil, entries, policyContext, "rule"))
+	result, err = policyContext.JSONContext().Query("frozen")
+	assert.NilError(t, err)
+	assert.Equal(t, result, true)
+}
+
+func Test_ExecuteAPICall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"owners":{"apps":"payments"}}`))
+	}))
+	defer server.Close()
+
+	call := kyvernov1.APICall{
+		Service:  &kyvernov1.ServiceCall{URL: server.URL + "/owners"},
+		JMESPath: "owners",
+	}
+	data, err := ExecuteAPICall(gocontext.TODO(), logging.GlobalLogger(), nil, "owners", call)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"apps":"payments"}`)
+
+	_, err = ExecuteAPICall(gocontext.TODO(), logging.GlobalLogger(), nil, "owners", kyvernov1.APICall{URLPath: "/api/v1/namespaces"})
+	assert.ErrorContains(t, err, "client not available")
+}
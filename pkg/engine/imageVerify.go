@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
@@ -16,7 +18,9 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/notary"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/kyverno/kyverno/pkg/utils/jsonpointer"
@@ -96,6 +100,12 @@ func VerifyAndPatchImages(rclient registryclient.Client, policyContext *PolicyCo
 			continue
 		}
 
+		// check if there is a policy exception matches the incoming resource
+		if exceptionResp := hasPolicyExceptions(logger, response.ImageVerify, policyContext, rule); exceptionResp != nil {
+			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *exceptionResp)
+			continue
+		}
+
 		logger.V(3).Info("processing image verification rule", "ruleSelector", applyRules)
 
 		var err error
@@ -205,6 +215,19 @@ func (iv *imageVerifier) verify(imageVerify kyvernov1.ImageVerification, matched
 			continue
 		}
 
+		if imageInfo.Semver != "" {
+			resolved, err := iv.resolveSemver(imageInfo)
+			if err != nil {
+				ruleResp := ruleError(iv.rule, response.ImageVerify, "failed to resolve the semver range", err)
+				iv.resp.PolicyResponse.Rules = append(iv.resp.PolicyResponse.Rules, *ruleResp)
+				incrementAppliedCount(iv.resp)
+				continue
+			}
+
+			imageInfo = resolved
+			image = imageInfo.String()
+		}
+
 		verified, err := isImageVerified(iv.policyContext.newResource, image, iv.logger)
 		if err == nil && verified {
 			iv.logger.Info("image was previously verified, skipping check", "image", image)
@@ -245,6 +268,12 @@ func (iv *imageVerifier) handleMutateDigest(digest string, imageInfo apiutils.Im
 		return nil, "", nil
 	}
 
+	// a reference built from several fields of the resource can only be pinned through a digest field
+	if imageInfo.Derived && imageInfo.DigestPointer == "" {
+		iv.logger.V(4).Info("skipping digest mutation, no digest field is configured for the artifact", "image", imageInfo.String())
+		return nil, "", nil
+	}
+
 	if digest == "" {
 		desc, err := iv.rclient.FetchImageDescriptor(context.TODO(), imageInfo.String())
 		if err != nil {
@@ -253,7 +282,7 @@ func (iv *imageVerifier) handleMutateDigest(digest string, imageInfo apiutils.Im
 		digest = desc.Digest.String()
 	}
 
-	patch, err := makeAddDigestPatch(imageInfo, digest)
+	patch, err := makeAddDigestPatch(iv.policyContext.newResource.Object, imageInfo, digest)
 	if err != nil {
 		return nil, "", errors.Wrapf(err, "failed to create image digest patch")
 	}
@@ -263,6 +292,43 @@ func (iv *imageVerifier) handleMutateDigest(digest string, imageInfo apiutils.Im
 	return patch, digest, nil
 }
 
+// resolveSemver resolves the semver range of an artifact to the highest matching tag of its repository.
+// Ranges are constraints with the syntax and the prerelease rules of Flux, prerelease tags only match
+// constraints with a prerelease.
+func (iv *imageVerifier) resolveSemver(imageInfo apiutils.ImageInfo) (apiutils.ImageInfo, error) {
+	constraint, err := semver.NewConstraint(imageInfo.Semver)
+	if err != nil {
+		return imageInfo, errors.Wrapf(err, "invalid semver range %s", imageInfo.Semver)
+	}
+
+	repository := imageInfo.Registry + "/" + imageInfo.Path
+	tags, err := iv.rclient.ListTags(context.TODO(), repository)
+	if err != nil {
+		return imageInfo, err
+	}
+
+	var latest *semver.Version
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil || !constraint.Check(version) {
+			continue
+		}
+
+		if latest == nil || version.GreaterThan(latest) {
+			latest = version
+			imageInfo.Tag = tag
+		}
+	}
+
+	if latest == nil {
+		return imageInfo, errors.Errorf("no tag of %s matches %s", repository, imageInfo.Semver)
+	}
+
+	iv.logger.V(4).Info("resolved semver range", "repository", repository, "semver", imageInfo.Semver, "tag", imageInfo.Tag)
+	imageInfo.Semver = ""
+	return imageInfo, nil
+}
+
 func hasImageVerifiedAnnotationChanged(ctx *PolicyContext, log logr.Logger) bool {
 	if reflect.DeepEqual(ctx.newResource, unstructured.Unstructured{}) ||
 		reflect.DeepEqual(ctx.oldResource, unstructured.Unstructured{}) {
@@ -375,7 +441,7 @@ func (iv *imageVerifier) verifyAttestations(imageVerify kyvernov1.ImageVerificat
 			for _, a := range entries {
 				entryPath := fmt.Sprintf("%s.entries[%d]", attestorPath, i)
 				opts, subPath := iv.buildOptionsAndPath(a, imageVerify, image, attestation)
-				cosignResp, err := cosign.FetchAttestations(iv.rclient, *opts)
+				cosignResp, err := iv.fetchAttestations(*opts)
 				if err != nil {
 					iv.logger.Error(err, "failed to fetch attestations")
 					msg := fmt.Sprintf("failed to fetch attestations %s: %s", image, err.Error())
@@ -435,7 +501,11 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 			}
 		} else {
 			opts, subPath := iv.buildOptionsAndPath(a, imageVerify, image, kyvernov1.Attestation{PredicateType: predicateType})
-			cosignResp, entryError = cosign.VerifySignature(iv.rclient, *opts)
+			if imageVerify.Type == kyvernov1.Notary {
+				cosignResp, entryError = iv.verifyNotarySignature(*opts)
+			} else {
+				cosignResp, entryError = iv.verifySignature(*opts)
+			}
 			if entryError != nil {
 				entryError = errors.Wrapf(entryError, attestorPath+subPath)
 			}
@@ -457,6 +527,104 @@ func (iv *imageVerifier) verifyAttestorSet(attestorSet kyvernov1.AttestorSet, im
 	return nil, err
 }
 
+// verifySignature verifies the image signature, successful verifications are cached when an image verify cache is configured
+func (iv *imageVerifier) verifySignature(opts cosign.Options) (*cosign.Response, error) {
+	return iv.cached("verify", opts, cosign.VerifySignature)
+}
+
+// verifyNotarySignature verifies the image Notary v2 signature, successful verifications are cached when an image verify cache is configured
+func (iv *imageVerifier) verifyNotarySignature(opts cosign.Options) (*cosign.Response, error) {
+	return iv.cached("notary", opts, notary.VerifySignature)
+}
+
+// fetchAttestations fetches and verifies the image attestations, successful results are cached when an image verify cache is configured
+func (iv *imageVerifier) fetchAttestations(opts cosign.Options) (*cosign.Response, error) {
+	return iv.cached("attestations", opts, cosign.FetchAttestations)
+}
+
+func (iv *imageVerifier) cached(operation string, opts cosign.Options, fn func(registryclient.Client, cosign.Options) (*cosign.Response, error)) (*cosign.Response, error) {
+	ivCache := iv.policyContext.imageVerifyCache
+	policy := iv.policyContext.Policy()
+	if ivCache == nil || policy == nil {
+		return fn(iv.rclient, opts)
+	}
+	ctx := context.TODO()
+	policyKey := policy.GetName()
+	if policy.GetNamespace() != "" {
+		policyKey = policy.GetNamespace() + "/" + policyKey
+	}
+	// the image is pinned to its current digest so that a retagged or re-pushed image does not hit the cache
+	pinned, err := iv.pinImageReference(ctx, opts.ImageRef)
+	if err != nil {
+		iv.logger.V(4).Info("failed to resolve image digest, skipping image verify cache", "image", opts.ImageRef, "error", err.Error())
+		return fn(iv.rclient, opts)
+	}
+	opts.ImageRef = pinned
+	keyVersion, err := iv.keyVersion(ctx, opts.Key)
+	if err != nil {
+		iv.logger.V(4).Info("failed to resolve key version, skipping image verify cache", "key", opts.Key, "error", err.Error())
+		return fn(iv.rclient, opts)
+	}
+	key, err := imageverifycache.Key(policyKey, iv.rule.Name, operation, struct {
+		Options    cosign.Options
+		KeyVersion string
+	}{opts, keyVersion})
+	if err != nil {
+		iv.logger.Error(err, "failed to build image verify cache key")
+		return fn(iv.rclient, opts)
+	}
+	if resp, ok := ivCache.Get(ctx, key); ok {
+		iv.logger.V(4).Info("image verification result found in cache", "image", opts.ImageRef, "operation", operation)
+		return resp, nil
+	}
+	errCache, cacheErrors := ivCache.(imageverifycache.ErrorCache)
+	if cacheErrors {
+		if err, ok := errCache.GetError(ctx, key); ok {
+			iv.logger.V(4).Info("image verification error found in cache", "image", opts.ImageRef, "operation", operation)
+			return nil, err
+		}
+	}
+	resp, err := fn(iv.rclient, opts)
+	if err == nil {
+		ivCache.Set(ctx, key, resp)
+	} else if cacheErrors {
+		errCache.SetError(ctx, key, err)
+	}
+	return resp, err
+}
+
+// pinImageReference appends the digest the image reference currently resolves to, unless it already has one
+func (iv *imageVerifier) pinImageReference(ctx context.Context, imageRef string) (string, error) {
+	if strings.Contains(imageRef, "@") {
+		return imageRef, nil
+	}
+	desc, err := iv.rclient.FetchImageDescriptor(ctx, imageRef)
+	if err != nil {
+		return "", err
+	}
+	return imageRef + "@" + desc.Digest.String(), nil
+}
+
+// keyVersion returns the resource version of the secret holding the key, so that rotating the secret
+// invalidates the cached verifications, keys that are not loaded from a secret have no version
+func (iv *imageVerifier) keyVersion(ctx context.Context, key string) (string, error) {
+	if !strings.HasPrefix(key, "k8s://") {
+		return "", nil
+	}
+	if iv.policyContext.client == nil {
+		return "", errors.New("no client to fetch the key secret")
+	}
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(key, "k8s://"), "/")
+	if !ok {
+		return "", errors.Errorf("invalid key secret reference %s", key)
+	}
+	secret, err := iv.policyContext.client.GetResource(ctx, "v1", "Secret", namespace, name)
+	if err != nil {
+		return "", err
+	}
+	return secret.GetResourceVersion(), nil
+}
+
 func expandStaticKeys(attestorSet kyvernov1.AttestorSet) kyvernov1.AttestorSet {
 	var entries []kyvernov1.Attestor
 	for _, e := range attestorSet.Entries {
@@ -536,26 +704,22 @@ func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageV
 		} else if attestor.Keys.KMS != "" {
 			opts.Key = attestor.Keys.KMS
 		}
-		if attestor.Keys.Rekor != nil {
-			opts.RekorURL = attestor.Keys.Rekor.URL
-		}
+		setRekorOptions(opts, attestor.Keys.Rekor)
 		opts.SignatureAlgorithm = attestor.Keys.SignatureAlgorithm
 	} else if attestor.Certificates != nil {
 		path = path + ".certificates"
 		opts.Cert = attestor.Certificates.Certificate
 		opts.CertChain = attestor.Certificates.CertificateChain
-		if attestor.Certificates.Rekor != nil {
-			opts.RekorURL = attestor.Certificates.Rekor.URL
-		}
+		setRekorOptions(opts, attestor.Certificates.Rekor)
 	} else if attestor.Keyless != nil {
 		path = path + ".keyless"
-		if attestor.Keyless.Rekor != nil {
-			opts.RekorURL = attestor.Keyless.Rekor.URL
-		}
+		setRekorOptions(opts, attestor.Keyless.Rekor)
 
 		opts.Roots = attestor.Keyless.Roots
 		opts.Issuer = attestor.Keyless.Issuer
+		opts.IssuerRegExp = attestor.Keyless.IssuerRegExp
 		opts.Subject = attestor.Keyless.Subject
+		opts.SubjectRegExp = attestor.Keyless.SubjectRegExp
 		opts.AdditionalExtensions = attestor.Keyless.AdditionalExtensions
 	}
 
@@ -570,14 +734,69 @@ func (iv *imageVerifier) buildOptionsAndPath(attestor kyvernov1.Attestor, imageV
 	return opts, path
 }
 
-func makeAddDigestPatch(imageInfo apiutils.ImageInfo, digest string) ([]byte, error) {
+// setRekorOptions sets the transparency log options, a nil Rekor is not checked
+func setRekorOptions(opts *cosign.Options, rekor *kyvernov1.CTLog) {
+	if rekor == nil {
+		return
+	}
+	opts.RekorURL = rekor.URL
+	opts.RekorPubKey = rekor.RekorPubKey
+	opts.CTLogPubKey = rekor.CTLogPubKey
+	opts.IgnoreTlog = rekor.IgnoreTlog
+}
+
+// makeAddDigestPatch returns the patch pinning the image to the digest, either adding the digest to the
+// digest field of the resource, creating its missing parent objects, or appending it to the image reference
+func makeAddDigestPatch(resource map[string]interface{}, imageInfo apiutils.ImageInfo, digest string) ([]byte, error) {
 	patch := make(map[string]interface{})
+	if imageInfo.DigestPointer != "" {
+		path, value, err := addPatchTarget(resource, imageInfo.DigestPointer, digest)
+		if err != nil {
+			return nil, err
+		}
+		patch["op"] = "add"
+		patch["path"] = path
+		patch["value"] = value
+		return json.Marshal(patch)
+	}
+
 	patch["op"] = "replace"
 	patch["path"] = imageInfo.Pointer
 	patch["value"] = imageInfo.String() + "@" + digest
 	return json.Marshal(patch)
 }
 
+// addPatchTarget returns the path and the value of the add patch setting the value at the pointer of the
+// resource, the patch adds the first missing parent object of the pointer with the value nested in it
+func addPatchTarget(resource map[string]interface{}, pointer string, value interface{}) (string, interface{}, error) {
+	fields := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	var current interface{} = resource
+	for i, field := range fields[:len(fields)-1] {
+		var next interface{}
+		switch parent := current.(type) {
+		case map[string]interface{}:
+			next = parent[field]
+		case []interface{}:
+			if index, err := strconv.Atoi(field); err == nil && index >= 0 && index < len(parent) {
+				next = parent[index]
+			}
+		default:
+			return "", nil, errors.Errorf("/%s is not an object", strings.Join(fields[:i], "/"))
+		}
+		if next == nil {
+			for j := len(fields) - 1; j > i; j-- {
+				value = map[string]interface{}{fields[j]: value}
+			}
+			return "/" + strings.Join(fields[:i+1], "/"), value, nil
+		}
+		current = next
+	}
+	if _, ok := current.(map[string]interface{}); !ok {
+		return "", nil, errors.Errorf("/%s is not an object", strings.Join(fields[:len(fields)-1], "/"))
+	}
+	return pointer, value, nil
+}
+
 func (iv *imageVerifier) verifyAttestation(statements []map[string]interface{}, attestation kyvernov1.Attestation, imageInfo apiutils.ImageInfo) error {
 	image := imageInfo.String()
 	statementsByPredicate, types := buildStatementMap(statements)
@@ -626,18 +845,21 @@ func (iv *imageVerifier) checkAttestations(a kyvernov1.Attestation, s map[string
 		return true, nil
 	}
 
-	iv.policyContext.jsonContext.Checkpoint()
-	defer iv.policyContext.jsonContext.Restore()
-
 	return evaluateConditions(a.Conditions, iv.policyContext.jsonContext, s, iv.logger)
 }
 
+// evaluateConditions evaluates the conditions against the predicate and the attestation summary
+// of the statement, the context is restored afterwards so that a statement never sees the data of
+// a previous one.
 func evaluateConditions(
 	conditions []kyvernov1.AnyAllConditions,
 	ctx enginecontext.Interface,
 	s map[string]interface{},
 	log logr.Logger,
 ) (bool, error) {
+	ctx.Checkpoint()
+	defer ctx.Restore()
+
 	predicate, ok := s["predicate"].(map[string]interface{})
 	if !ok {
 		return false, fmt.Errorf("failed to extract predicate from statement: %v", s)
@@ -647,6 +869,12 @@ func evaluateConditions(
 		return false, errors.Wrapf(err, fmt.Sprintf("failed to add Statement to the context %v", s))
 	}
 
+	if summary := attestationSummary(s); summary != nil {
+		if err := enginecontext.AddJSONObject(ctx, map[string]interface{}{"attestation": summary}); err != nil {
+			return false, errors.Wrapf(err, "failed to add the attestation summary to the context")
+		}
+	}
+
 	c, err := variables.SubstituteAllInConditions(log, ctx, conditions)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to substitute variables in attestation conditions")
@@ -2,10 +2,12 @@ package engine
 
 import (
 	"encoding/json"
+	"sort"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 const imageVerifyAnnotationKey = "kyverno.io/verify-images"
@@ -46,6 +48,41 @@ func parseImageMetadata(jsonData string) (*ImageVerificationMetadata, error) {
 	}, nil
 }
 
+// VerifiedImages returns the images recorded as verified in the image verification annotation
+func VerifiedImages(annotations map[string]string) ([]string, error) {
+	data, ok := annotations[imageVerifyAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	ivm, err := parseImageMetadata(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse image metadata")
+	}
+
+	var images []string
+	for image, verified := range ivm.Data {
+		if verified {
+			images = append(images, image)
+		}
+	}
+
+	sort.Strings(images)
+	return images, nil
+}
+
+// RemoveImageVerificationMetadata removes the image verification annotation from the resource,
+// previously verified images are then verified again instead of being skipped
+func RemoveImageVerificationMetadata(resource *unstructured.Unstructured) {
+	annotations := resource.GetAnnotations()
+	if _, ok := annotations[imageVerifyAnnotationKey]; !ok {
+		return
+	}
+
+	delete(annotations, imageVerifyAnnotationKey)
+	resource.SetAnnotations(annotations)
+}
+
 func (ivm *ImageVerificationMetadata) Patches(hasAnnotations bool, log logr.Logger) ([][]byte, error) {
 	var patches [][]byte
 	if !hasAnnotations {
@@ -1,14 +1,26 @@
 package engine
 
 import (
+	gocontext "context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http/httptest"
 	"strings"
 	"testing"
-
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -17,6 +29,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
 	"gotest.tools/assert"
 )
 
@@ -437,6 +451,139 @@ func Test_SignatureGoodSigned(t *testing.T) {
 	assert.Equal(t, err.PolicyResponse.Rules[0].Status, response.RuleStatusPass, err.PolicyResponse.Rules[0].Message)
 }
 
+type fakeImageVerifyCache struct {
+	response *cosign.Response
+	gets     int
+	sets     int
+}
+
+func (c *fakeImageVerifyCache) Get(_ gocontext.Context, _ string) (*cosign.Response, bool) {
+	c.gets++
+	return c.response, c.response != nil
+}
+
+func (c *fakeImageVerifyCache) Set(_ gocontext.Context, _ string, response *cosign.Response) {
+	c.sets++
+	c.response = response
+}
+
+// pushRandomImage pushes a random image to the given tag and returns its digest
+func pushRandomImage(t *testing.T, image string) string {
+	img, err := random.Image(256, 1)
+	assert.NilError(t, err)
+	ref, err := name.NewTag(image)
+	assert.NilError(t, err)
+	assert.NilError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	assert.NilError(t, err)
+	return digest.String()
+}
+
+func Test_ImageVerifyCache(t *testing.T) {
+	cosign.ClearMock()
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	repository := strings.TrimPrefix(server.URL, "http://") + "/kyverno/test-verify-image"
+	pushRandomImage(t, repository+":unsigned")
+	// the image is not signed, the cached result must be used without verifying the signature
+	unsigned := strings.Replace(testSampleResource, "ghcr.io/kyverno/test-verify-image:signed", repository+":unsigned", -1)
+	policy := strings.Replace(testSampleSingleKeyPolicy, "ghcr.io/kyverno/test-verify-image", repository, -1)
+	ivCache := &fakeImageVerifyCache{
+		response: &cosign.Response{Digest: "sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105"},
+	}
+	policyContext := buildContext(t, policy, unsigned, "").WithImageVerifyCache(ivCache)
+	err, _ := VerifyAndPatchImages(registryclient.NewOrDie(), policyContext)
+	assert.Equal(t, len(err.PolicyResponse.Rules), 1)
+	assert.Equal(t, err.PolicyResponse.Rules[0].Status, response.RuleStatusPass, err.PolicyResponse.Rules[0].Message)
+	assert.Equal(t, ivCache.gets, 1)
+	assert.Equal(t, ivCache.sets, 0)
+}
+
+func Test_ImageVerifyCacheKey(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	image := strings.TrimPrefix(server.URL, "http://") + "/kyverno/test-verify-image:signed"
+	digest := pushRandomImage(t, image)
+
+	secret, err := utils.ConvertToUnstructured([]byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"keys","namespace":"kyverno","resourceVersion":"1"}}`))
+	assert.NilError(t, err)
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, secret)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+
+	policyContext := buildContext(t, testSampleSingleKeyPolicy, testSampleResource, "").
+		WithImageVerifyCache(imageverifycache.New(10, time.Minute, nil)).
+		WithClient(client)
+	iv := &imageVerifier{
+		logger:        logging.GlobalLogger(),
+		rclient:       registryclient.NewOrDie(),
+		policyContext: policyContext,
+		rule:          &kyverno.Rule{Name: "check-image"},
+	}
+	var verified []string
+	verify := func(_ registryclient.Client, opts cosign.Options) (*cosign.Response, error) {
+		verified = append(verified, opts.ImageRef)
+		return &cosign.Response{}, nil
+	}
+	opts := cosign.Options{ImageRef: image, Key: "k8s://kyverno/keys"}
+
+	_, err = iv.cached("verify", opts, verify)
+	assert.NilError(t, err)
+	_, err = iv.cached("verify", opts, verify)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, verified, []string{image + "@" + digest})
+
+	// the tag is pushed again and resolves to a different digest
+	retagged := pushRandomImage(t, image)
+	_, err = iv.cached("verify", opts, verify)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, verified, []string{image + "@" + digest, image + "@" + retagged})
+
+	// the key secret is rotated
+	secret.SetResourceVersion("2")
+	_, err = client.UpdateResource(gocontext.TODO(), "v1", "Secret", "kyverno", secret, false)
+	assert.NilError(t, err)
+	_, err = iv.cached("verify", opts, verify)
+	assert.NilError(t, err)
+	assert.Equal(t, len(verified), 3)
+}
+
+func Test_ImageVerifyCacheErrors(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	image := strings.TrimPrefix(server.URL, "http://") + "/kyverno/test-verify-image:signed"
+	pushRandomImage(t, image)
+
+	verifications := 0
+	verify := func(_ registryclient.Client, opts cosign.Options) (*cosign.Response, error) {
+		verifications++
+		return nil, errors.New("signature revoked")
+	}
+	opts := cosign.Options{ImageRef: image}
+	for _, tc := range []struct {
+		cache         imageverifycache.Cache
+		verifications int
+	}{
+		// failures are verified again by default
+		{cache: imageverifycache.New(10, time.Minute, nil), verifications: 2},
+		// caches storing errors fail without verifying again
+		{cache: imageverifycache.NewWithErrors(10, time.Minute), verifications: 1},
+	} {
+		verifications = 0
+		iv := &imageVerifier{
+			logger:        logging.GlobalLogger(),
+			rclient:       registryclient.NewOrDie(),
+			policyContext: buildContext(t, testSampleSingleKeyPolicy, testSampleResource, "").WithImageVerifyCache(tc.cache),
+			rule:          &kyverno.Rule{Name: "check-image"},
+		}
+		for i := 0; i < 2; i++ {
+			_, err := iv.cached("verify", opts, verify)
+			assert.Error(t, err, "signature revoked")
+		}
+		assert.Equal(t, verifications, tc.verifications)
+	}
+}
+
 func Test_SignatureUnsigned(t *testing.T) {
 	cosign.ClearMock()
 	unsigned := strings.Replace(testSampleResource, ":signed", ":unsigned", -1)
@@ -832,3 +979,118 @@ func Test_ParsePEMDelimited(t *testing.T) {
 	assert.Equal(t, len(verifiedImages.Data), 1)
 	assert.Equal(t, verifiedImages.isVerified(image), true)
 }
+
+func Test_VerifiedImages(t *testing.T) {
+	annotations := map[string]string{
+		imageVerifyAnnotationKey: `{"ghcr.io/kyverno/test-verify-image:signed":true,"ghcr.io/kyverno/test-verify-image:unsigned":false,"docker.io/nginx:latest":true}`,
+	}
+	images, err := VerifiedImages(annotations)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, images, []string{"docker.io/nginx:latest", "ghcr.io/kyverno/test-verify-image:signed"})
+
+	images, err = VerifiedImages(map[string]string{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(images), 0)
+
+	_, err = VerifiedImages(map[string]string{imageVerifyAnnotationKey: "invalid"})
+	assert.ErrorContains(t, err, "failed to parse image metadata")
+
+	resource := unstructured.Unstructured{}
+	resource.SetAnnotations(map[string]string{imageVerifyAnnotationKey: "{}", "team": "a"})
+	RemoveImageVerificationMetadata(&resource)
+	assert.DeepEqual(t, resource.GetAnnotations(), map[string]string{"team": "a"})
+}
+
+func Test_HandleMutateDigest(t *testing.T) {
+	digest := "sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3"
+	resource, err := utils.ConvertToUnstructured([]byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta2","kind":"OCIRepository","metadata":{"name":"podinfo"},"spec":{"url":"oci://ghcr.io/stefanprodan/manifests/podinfo","ref":{"tag":"6.2.0"}}}`))
+	assert.NilError(t, err)
+	policyContext := NewPolicyContext().WithNewResource(*resource)
+	iv := &imageVerifier{logger: logging.GlobalLogger(), rclient: registryclient.NewOrDie(), policyContext: policyContext}
+
+	image := apiutils.ImageInfo{
+		ImageInfo: imageutils.ImageInfo{Registry: "ghcr.io", Name: "test-verify-image", Path: "kyverno/test-verify-image", Tag: "signed"},
+		Pointer:   "/spec/containers/0/image",
+	}
+	patch, _, err := iv.handleMutateDigest(digest, image)
+	assert.NilError(t, err)
+	assert.Equal(t, string(patch), `{"op":"replace","path":"/spec/containers/0/image","value":"ghcr.io/kyverno/test-verify-image:signed@`+digest+`"}`)
+
+	// the repository URL of an artifact is never replaced, the digest is added to the digest field
+	artifact := apiutils.ImageInfo{
+		ImageInfo:     imageutils.ImageInfo{Registry: "ghcr.io", Name: "podinfo", Path: "stefanprodan/manifests/podinfo", Tag: "6.2.0"},
+		Pointer:       "/spec/url",
+		DigestPointer: "/spec/ref/digest",
+		Derived:       true,
+	}
+	patch, _, err = iv.handleMutateDigest(digest, artifact)
+	assert.NilError(t, err)
+	assert.Equal(t, string(patch), `{"op":"add","path":"/spec/ref/digest","value":"`+digest+`"}`)
+
+	// the missing parent objects of the digest field are created
+	resource, err = utils.ConvertToUnstructured([]byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta2","kind":"OCIRepository","metadata":{"name":"podinfo"},"spec":{"url":"oci://ghcr.io/stefanprodan/manifests/podinfo"}}`))
+	assert.NilError(t, err)
+	iv.policyContext = NewPolicyContext().WithNewResource(*resource)
+	patch, _, err = iv.handleMutateDigest(digest, artifact)
+	assert.NilError(t, err)
+	assert.Equal(t, string(patch), `{"op":"add","path":"/spec/ref","value":{"digest":"`+digest+`"}}`)
+
+	// the digest can't be added to a field that is not an object
+	resource, err = utils.ConvertToUnstructured([]byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta2","kind":"OCIRepository","metadata":{"name":"podinfo"},"spec":{"url":"oci://ghcr.io/stefanprodan/manifests/podinfo","ref":"6.2.0"}}`))
+	assert.NilError(t, err)
+	iv.policyContext = NewPolicyContext().WithNewResource(*resource)
+	_, _, err = iv.handleMutateDigest(digest, artifact)
+	assert.ErrorContains(t, err, "/spec/ref is not an object")
+
+	// without digest field, the digest of an artifact is not mutated
+	artifact.DigestPointer = ""
+	patch, _, err = iv.handleMutateDigest(digest, artifact)
+	assert.NilError(t, err)
+	assert.Assert(t, patch == nil)
+}
+
+func Test_ResolveSemver(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	img, err := random.Image(256, 1)
+	assert.NilError(t, err)
+	for _, tag := range []string{"6.0.0", "6.1.8", "6.2.0", "6.3.0-rc.1", "v7.0.0", "7.1.0-rc.1", "latest"} {
+		ref, err := name.NewTag(host + "/stefanprodan/manifests/podinfo:" + tag)
+		assert.NilError(t, err)
+		assert.NilError(t, remote.Write(ref, img))
+	}
+
+	iv := &imageVerifier{logger: logging.GlobalLogger(), rclient: registryclient.NewOrDie()}
+	testCases := []struct {
+		semver string
+		tag    string
+	}{
+		{semver: ">=6.0.0 <7.0.0", tag: "6.2.0"},
+		{semver: ">=7.0.0", tag: "v7.0.0"},
+		// flux constraint syntax
+		{semver: "^6.1.0", tag: "6.2.0"},
+		{semver: "~6.1", tag: "6.1.8"},
+		{semver: "6.x", tag: "6.2.0"},
+		// prerelease tags only match constraints with a prerelease
+		{semver: ">=6.0.0", tag: "v7.0.0"},
+		{semver: ">=7.1.0-rc.0", tag: "7.1.0-rc.1"},
+	}
+	for _, tc := range testCases {
+		imageInfo := apiutils.ImageInfo{
+			ImageInfo: imageutils.ImageInfo{Registry: host, Name: "podinfo", Path: "stefanprodan/manifests/podinfo", Tag: "latest"},
+			Semver:    tc.semver,
+		}
+		resolved, err := iv.resolveSemver(imageInfo)
+		assert.NilError(t, err, tc.semver)
+		assert.Equal(t, resolved.Tag, tc.tag, tc.semver)
+		assert.Equal(t, resolved.Semver, "")
+	}
+
+	imageInfo := apiutils.ImageInfo{
+		ImageInfo: imageutils.ImageInfo{Registry: host, Name: "podinfo", Path: "stefanprodan/manifests/podinfo", Tag: "latest"},
+		Semver:    ">=8.0.0",
+	}
+	_, err = iv.resolveSemver(imageInfo)
+	assert.ErrorContains(t, err, "no tag of "+host+"/stefanprodan/manifests/podinfo matches >=8.0.0")
+}
@@ -71,6 +71,10 @@ var (
 	base64Decode           = "base64_decode"
 	base64Encode           = "base64_encode"
 	timeSince              = "time_since"
+	timeNow                = "time_now"
+	timeAdd                = "time_add"
+	timeBefore             = "time_before"
+	timeAfter              = "time_after"
 	pathCanonicalize       = "path_canonicalize"
 	truncate               = "truncate"
 	semverCompare          = "semver_compare"
@@ -118,6 +122,11 @@ func (f *FunctionEntry) String() string {
 }
 
 func GetFunctions() []*FunctionEntry {
+	return getFunctions(time.Now)
+}
+
+// getFunctions returns the functions, time functions use the clock as the current time
+func getFunctions(clock Clock) []*FunctionEntry {
 	return []*FunctionEntry{
 		{
 			Entry: &gojmespath.FunctionEntry{
@@ -355,9 +364,53 @@ func GetFunctions() []*FunctionEntry {
 					{Types: []JpType{JpString}},
 					{Types: []JpType{JpString}},
 				},
-				Handler: jpTimeSince,
+				Handler: jpTimeSince(clock),
+			},
+			ReturnType: []JpType{JpString},
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name:    timeNow,
+				Handler: jpTimeNow(clock),
 			},
 			ReturnType: []JpType{JpString},
+			Note:       "returns the current time, i.e. the admission time, in RFC3339 format",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeAdd,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeAdd,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "adds a duration to an RFC3339 time, ex. \"{{ time_add(time_now(), '-168h') }}\"",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeBefore,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeBefore,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "returns true if the first RFC3339 time is before the second one",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: timeAfter,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpTimeAfter,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "returns true if the first RFC3339 time is after the second one",
 		},
 		{
 			Entry: &gojmespath.FunctionEntry{
@@ -765,47 +818,123 @@ func jpBase64Encode(arguments []interface{}) (interface{}, error) {
 	return base64.StdEncoding.EncodeToString([]byte(str.String())), nil
 }
 
-func jpTimeSince(arguments []interface{}) (interface{}, error) {
-	var err error
-	layout, err := validateArg("", arguments, 0, reflect.String)
+// jpTimeSince returns the time_since handler, the clock is the current time when the end time is empty
+func jpTimeSince(clock Clock) func(arguments []interface{}) (interface{}, error) {
+	return func(arguments []interface{}) (interface{}, error) {
+		var err error
+		layout, err := validateArg("", arguments, 0, reflect.String)
+		if err != nil {
+			return nil, err
+		}
+
+		ts1, err := validateArg("", arguments, 1, reflect.String)
+		if err != nil {
+			return nil, err
+		}
+
+		ts2, err := validateArg("", arguments, 2, reflect.String)
+		if err != nil {
+			return nil, err
+		}
+
+		var t1, t2 time.Time
+		if layout.String() != "" {
+			t1, err = time.Parse(layout.String(), ts1.String())
+		} else {
+			t1, err = time.Parse(time.RFC3339, ts1.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		t2 = clock()
+		if ts2.String() != "" {
+			if layout.String() != "" {
+				t2, err = time.Parse(layout.String(), ts2.String())
+			} else {
+				t2, err = time.Parse(time.RFC3339, ts2.String())
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return t2.Sub(t1).String(), nil
+	}
+}
+
+// jpTimeNow returns the time_now handler, the clock is the current time
+func jpTimeNow(clock Clock) func(arguments []interface{}) (interface{}, error) {
+	return func(arguments []interface{}) (interface{}, error) {
+		return clock().UTC().Format(time.RFC3339), nil
+	}
+}
+
+func jpTimeAdd(arguments []interface{}) (interface{}, error) {
+	t, err := parseTimeArg(timeAdd, arguments, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	ts1, err := validateArg("", arguments, 1, reflect.String)
+	d, err := validateArg(timeAdd, arguments, 1, reflect.String)
 	if err != nil {
 		return nil, err
 	}
 
-	ts2, err := validateArg("", arguments, 2, reflect.String)
+	duration, err := time.ParseDuration(d.String())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf(genericError, timeAdd, err.Error())
 	}
 
-	var t1, t2 time.Time
-	if layout.String() != "" {
-		t1, err = time.Parse(layout.String(), ts1.String())
-	} else {
-		t1, err = time.Parse(time.RFC3339, ts1.String())
+	return t.Add(duration).UTC().Format(time.RFC3339), nil
+}
+
+func jpTimeBefore(arguments []interface{}) (interface{}, error) {
+	t1, t2, err := parseTimeArgs(timeBefore, arguments)
+	if err != nil {
+		return nil, err
 	}
+
+	return t1.Before(t2), nil
+}
+
+func jpTimeAfter(arguments []interface{}) (interface{}, error) {
+	t1, t2, err := parseTimeArgs(timeAfter, arguments)
 	if err != nil {
 		return nil, err
 	}
 
-	t2 = time.Now()
-	if ts2.String() != "" {
-		if layout.String() != "" {
-			t2, err = time.Parse(layout.String(), ts2.String())
-		} else {
-			t2, err = time.Parse(time.RFC3339, ts2.String())
-		}
+	return t1.After(t2), nil
+}
 
-		if err != nil {
-			return nil, err
-		}
+func parseTimeArgs(f string, arguments []interface{}) (time.Time, time.Time, error) {
+	t1, err := parseTimeArg(f, arguments, 0)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	t2, err := parseTimeArg(f, arguments, 1)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return t1, t2, nil
+}
+
+// parseTimeArg parses an RFC3339 time argument, fractional seconds are accepted
+func parseTimeArg(f string, arguments []interface{}, index int) (time.Time, error) {
+	arg, err := validateArg(f, arguments, index, reflect.String)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, arg.String())
+	if err != nil {
+		return time.Time{}, fmt.Errorf(genericError, f, err.Error())
 	}
 
-	return t2.Sub(t1).String(), nil
+	return t, nil
 }
 
 func jpPathCanonicalize(arguments []interface{}) (interface{}, error) {
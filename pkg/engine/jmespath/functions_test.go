@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 )
@@ -1191,6 +1192,75 @@ func Test_TimeSince(t *testing.T) {
 	}
 }
 
+func Test_TimeSinceWithClock(t *testing.T) {
+	clock := func() time.Time {
+		return time.Date(2021, 1, 10, 3, 14, 5, 0, time.FixedZone("", -7*60*60))
+	}
+	query, err := NewWithClock("time_since('', '2021-01-02T15:04:05-07:00', '')", clock)
+	assert.NilError(t, err)
+	res, err := query.Search("")
+	assert.NilError(t, err)
+	assert.Equal(t, res, "180h10m0s")
+}
+
+func Test_TimeFunctions(t *testing.T) {
+	clock := func() time.Time {
+		return time.Date(2021, 1, 10, 3, 14, 5, 0, time.FixedZone("", -7*60*60))
+	}
+	testCases := []struct {
+		test           string
+		expectedResult interface{}
+	}{
+		{
+			test:           "time_now()",
+			expectedResult: "2021-01-10T10:14:05Z",
+		},
+		{
+			test:           "time_add('2021-01-02T15:04:05-07:00', '-24h')",
+			expectedResult: "2021-01-01T22:04:05Z",
+		},
+		{
+			test:           "time_add(time_now(), '-168h')",
+			expectedResult: "2021-01-03T10:14:05Z",
+		},
+		{
+			test:           "time_before('2021-01-02T15:04:05.123456Z', time_now())",
+			expectedResult: true,
+		},
+		{
+			test:           "time_after('2021-01-02T15:04:05Z', time_add(time_now(), '-168h'))",
+			expectedResult: false,
+		},
+		{
+			test:           "time_after('2021-01-09T15:04:05Z', time_add(time_now(), '-168h'))",
+			expectedResult: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			query, err := NewWithClock(tc.test, clock)
+			assert.NilError(t, err)
+
+			res, err := query.Search("")
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_TimeFunctionsInvalid(t *testing.T) {
+	for _, test := range []string{
+		"time_add('yesterday', '24h')",
+		"time_add('2021-01-02T15:04:05Z', 'a day')",
+		"time_before('2021-01-02', '2021-01-03T00:00:00Z')",
+	} {
+		query, err := New(test)
+		assert.NilError(t, err)
+		_, err = query.Search("")
+		assert.Assert(t, err != nil, test)
+	}
+}
+
 func Test_PathCanonicalize(t *testing.T) {
 	testCases := []struct {
 		jmesPath       string
@@ -1,16 +1,29 @@
 package jmespath
 
 import (
+	"time"
+
 	gojmespath "github.com/jmespath/go-jmespath"
 )
 
+// Clock returns the current time
+type Clock func() time.Time
+
 func New(query string) (*gojmespath.JMESPath, error) {
+	return NewWithClock(query, time.Now)
+}
+
+// NewWithClock compiles a query, time functions use the clock as the current time
+func NewWithClock(query string, clock Clock) (*gojmespath.JMESPath, error) {
+	if clock == nil {
+		clock = time.Now
+	}
 	jp, err := gojmespath.Compile(query)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, function := range GetFunctions() {
+	for _, function := range getFunctions(clock) {
 		jp.Register(function.Entry)
 	}
 
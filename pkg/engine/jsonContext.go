@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -11,6 +12,7 @@ import (
 	jmespath "github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // LoadContext - Fetches and adds external data to the Context.
@@ -19,9 +21,13 @@ func LoadContext(logger logr.Logger, rclient registryclient.Client, contextEntri
 		return nil
 	}
 
-	policyName := ctx.policy.GetName()
-	if store.GetMock() {
-		rule := store.GetPolicyRuleFromContext(policyName, ruleName)
+	if cliStore := ctx.cliStore; cliStore.GetMock() {
+		// cleanup policies are not kyverno policies, they have no values
+		var policyName string
+		if ctx.policy != nil {
+			policyName = ctx.policy.GetName()
+		}
+		rule := cliStore.GetPolicyRuleFromContext(policyName, ruleName)
 		if rule != nil && len(rule.Values) > 0 {
 			variables := rule.Values
 			for key, value := range variables {
@@ -31,12 +37,13 @@ func LoadContext(logger logr.Logger, rclient registryclient.Client, contextEntri
 			}
 		}
 
-		hasRegistryAccess := store.GetRegistryAccess()
+		hasRegistryAccess := cliStore.GetRegistryAccess()
 
 		// Context Variable should be loaded after the values loaded from values file
+		// Context entries with fixtures are loaded from the fixtures, overriding the values
 		for _, entry := range contextEntries {
-			if entry.ImageRegistry != nil && hasRegistryAccess {
-				rclient := store.GetRegistryClient()
+			if entry.ImageRegistry != nil && (hasRegistryAccess || cliStore.HasImageFixtures()) {
+				rclient := cliStore.GetRegistryClient()
 				if err := loadImageData(rclient, logger, entry, ctx); err != nil {
 					return err
 				}
@@ -44,16 +51,24 @@ func LoadContext(logger logr.Logger, rclient registryclient.Client, contextEntri
 				if err := loadVariable(logger, entry, ctx); err != nil {
 					return err
 				}
-			} else if entry.APICall != nil && store.IsAllowApiCall() {
+			} else if entry.ConfigMap != nil && cliStore.HasConfigMapFixtures() {
+				if err := loadConfigMap(logger, entry, ctx); err != nil {
+					return err
+				}
+			} else if entry.APICall != nil && (cliStore.IsAllowApiCall() || cliStore.HasAPICallFixtures() || cliStore.HasServiceCallFixtures()) {
 				if err := loadAPIData(logger, entry, ctx); err != nil {
 					return err
 				}
+			} else if entry.GlobalReference != nil && (cliStore.HasGlobalContextFixtures() || !hasValue(rule, entry.Name)) {
+				if err := loadGlobalContext(logger, entry, ctx); err != nil {
+					return err
+				}
 			}
 		}
 
 		if rule != nil && len(rule.ForeachValues) > 0 {
 			for key, value := range rule.ForeachValues {
-				if err := ctx.jsonContext.AddVariable(key, value[store.ForeachElement]); err != nil {
+				if err := ctx.jsonContext.AddVariable(key, value[cliStore.GetForeachElement()]); err != nil {
 					return err
 				}
 			}
@@ -76,12 +91,29 @@ func LoadContext(logger logr.Logger, rclient registryclient.Client, contextEntri
 				if err := loadVariable(logger, entry, ctx); err != nil {
 					return err
 				}
+			} else if entry.GlobalReference != nil {
+				if err := loadGlobalContext(logger, entry, ctx); err != nil {
+					return err
+				}
 			}
 		}
 	}
 	return nil
 }
 
+// hasValue checks the values of the rule provide the context entry, or one of its fields
+func hasValue(rule *store.Rule, name string) bool {
+	if rule == nil {
+		return false
+	}
+	for key := range rule.Values {
+		if key == name || strings.HasPrefix(key, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
 func loadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) (err error) {
 	path := ""
 	if entry.Variable.JMESPath != "" {
@@ -169,7 +201,7 @@ func fetchImageData(rclient registryclient.Client, logger logr.Logger, entry kyv
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.ImageRegistry.JMESPath, err)
 	}
-	imageData, err := fetchImageDataMap(rclient, refString)
+	imageData, err := fetchImageDataMap(rclient, ctx.cliStore, refString)
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +215,15 @@ func fetchImageData(rclient registryclient.Client, logger logr.Logger, entry kyv
 }
 
 // FetchImageDataMap fetches image information from the remote registry.
-func fetchImageDataMap(rclient registryclient.Client, ref string) (interface{}, error) {
+func fetchImageDataMap(rclient registryclient.Client, cliStore *store.Store, ref string) (interface{}, error) {
+	if cliStore.GetMock() {
+		if data, ok := cliStore.GetImageFixture(ref); ok {
+			return data, nil
+		}
+		if rclient == nil {
+			return nil, fmt.Errorf("no image registry fixture for image reference: %s", ref)
+		}
+	}
 	desc, err := rclient.FetchImageDescriptor(context.TODO(), ref)
 	if err != nil {
 		return nil, err
@@ -299,6 +339,10 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 		return nil, fmt.Errorf("missing APICall in context entry %s %v", entry.Name, entry.APICall)
 	}
 
+	if entry.APICall.Service != nil {
+		return fetchServiceData(log, entry, ctx)
+	}
+
 	path, err := variables.SubstituteAll(log, ctx.jsonContext, entry.APICall.URLPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.APICall.URLPath, err)
@@ -306,15 +350,25 @@ func fetchAPIData(log logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyCont
 
 	pathStr := path.(string)
 
-	jsonData, err := getResource(ctx, pathStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get resource with raw url\n: %s: %v", pathStr, err)
-	}
+	return cachedAPICall(ctx, "urlPath:"+pathStr, true, func() ([]byte, error) {
+		jsonData, err := getResource(ctx, pathStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource with raw url\n: %s: %v", pathStr, err)
+		}
 
-	return jsonData, nil
+		return jsonData, nil
+	})
 }
 
 func getResource(ctx *PolicyContext, p string) ([]byte, error) {
+	if ctx.cliStore.GetMock() {
+		if data, ok := ctx.cliStore.GetAPICallFixture(p); ok {
+			return data, nil
+		}
+		if ctx.client == nil {
+			return nil, fmt.Errorf("no API call fixture for %s", p)
+		}
+	}
 	return ctx.client.RawAbsPath(context.TODO(), p)
 }
 
@@ -349,9 +403,18 @@ func fetchConfigMap(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *Polic
 		namespace = "default"
 	}
 
-	obj, err := ctx.informerCacheResolvers.Get(context.TODO(), namespace.(string), name.(string))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
+	var obj *corev1.ConfigMap
+	if ctx.cliStore.GetMock() {
+		cm, ok := ctx.cliStore.GetConfigMapFixture(namespace.(string), name.(string))
+		if !ok {
+			return nil, fmt.Errorf("failed to get configmap %s/%s : no config map fixture", namespace, name)
+		}
+		obj = cm
+	} else {
+		obj, err = ctx.informerCacheResolvers.Get(context.TODO(), namespace.(string), name.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap %s/%s : %v", namespace, name, err)
+		}
 	}
 
 	// extract configmap data
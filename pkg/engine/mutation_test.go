@@ -246,8 +246,9 @@ func Test_variableSubstitutionCLI(t *testing.T) {
 
 	expectedPatch := []byte(`{"op":"add","path":"/metadata/labels","value":{"my-environment-name":"dev1"}}`)
 
-	store.SetContext(configMapVariableContext)
-	store.SetMock(true)
+	var cliStore store.Store
+	cliStore.SetContext(configMapVariableContext)
+	cliStore.SetMock(true)
 	var policy kyverno.ClusterPolicy
 	err := json.Unmarshal(policyRaw, &policy)
 	assert.NilError(t, err)
@@ -262,6 +263,7 @@ func Test_variableSubstitutionCLI(t *testing.T) {
 		policy:      &policy,
 		jsonContext: ctx,
 		newResource: *resourceUnstructured,
+		cliStore:    &cliStore,
 	}
 
 	er := Mutate(registryclient.NewOrDie(), policyContext)
@@ -444,7 +446,8 @@ func Test_precondition(t *testing.T) {
 }`)
 	expectedPatch := []byte(`{"op":"add","path":"/metadata/labels/my-added-label","value":"test"}`)
 
-	store.SetMock(true)
+	var cliStore store.Store
+	cliStore.SetMock(true)
 	var policy kyverno.ClusterPolicy
 	err := json.Unmarshal(policyRaw, &policy)
 	assert.NilError(t, err)
@@ -459,6 +462,7 @@ func Test_precondition(t *testing.T) {
 		policy:      &policy,
 		jsonContext: ctx,
 		newResource: *resourceUnstructured,
+		cliStore:    &cliStore,
 	}
 
 	er := Mutate(registryclient.NewOrDie(), policyContext)
@@ -541,7 +545,8 @@ func Test_nonZeroIndexNumberPatchesJson6902(t *testing.T) {
 
 	expectedPatch := []byte(`{"op":"add","path":"/subsets/0/addresses/1","value":{"ip":"192.168.42.172"}}`)
 
-	store.SetMock(true)
+	var cliStore store.Store
+	cliStore.SetMock(true)
 	var policy kyverno.ClusterPolicy
 	err := json.Unmarshal(policyraw, &policy)
 	assert.NilError(t, err)
@@ -556,6 +561,7 @@ func Test_nonZeroIndexNumberPatchesJson6902(t *testing.T) {
 		policy:      &policy,
 		jsonContext: ctx,
 		newResource: *resourceUnstructured,
+		cliStore:    &cliStore,
 	}
 
 	er := Mutate(registryclient.NewOrDie(), policyContext)
@@ -3,11 +3,16 @@ package engine
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/apicallcache"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -53,6 +58,24 @@ type PolicyContext struct {
 
 	// informerCacheResolvers - used to get resources from informer cache
 	informerCacheResolvers resolvers.ConfigmapResolver
+
+	// peLister - used to lookup policy exceptions
+	peLister kyvernov2alpha1listers.PolicyExceptionLister
+
+	// imageVerifyCache - used to cache image verification results
+	imageVerifyCache imageverifycache.Cache
+
+	// apiCallCache - used to cache APICall results across requests
+	apiCallCache apicallcache.Cache
+
+	// apiCallRequestCache - used to deduplicate APICall lookups in the scope of a request
+	apiCallRequestCache apicallcache.Cache
+
+	// globalContext - used to lookup global context entries
+	globalContext globalcontextstore.Store
+
+	// cliStore - used by the CLI to mock external data, nil when running in the cluster
+	cliStore *store.Store
 }
 
 // Getters
@@ -147,6 +170,36 @@ func (c *PolicyContext) WithInformerCacheResolver(informerCacheResolver resolver
 	return copy
 }
 
+func (c *PolicyContext) WithExceptions(peLister kyvernov2alpha1listers.PolicyExceptionLister) *PolicyContext {
+	copy := c.Copy()
+	copy.peLister = peLister
+	return copy
+}
+
+func (c *PolicyContext) WithImageVerifyCache(imageVerifyCache imageverifycache.Cache) *PolicyContext {
+	copy := c.Copy()
+	copy.imageVerifyCache = imageVerifyCache
+	return copy
+}
+
+func (c *PolicyContext) WithAPICallCache(apiCallCache apicallcache.Cache) *PolicyContext {
+	copy := c.Copy()
+	copy.apiCallCache = apiCallCache
+	return copy
+}
+
+func (c *PolicyContext) WithGlobalContext(globalContext globalcontextstore.Store) *PolicyContext {
+	copy := c.Copy()
+	copy.globalContext = globalContext
+	return copy
+}
+
+func (c *PolicyContext) WithCLIStore(cliStore *store.Store) *PolicyContext {
+	copy := c.Copy()
+	copy.cliStore = cliStore
+	return copy
+}
+
 // Constructors
 
 func NewPolicyContextWithJsonContext(jsonContext context.Interface) *PolicyContext {
@@ -156,6 +209,7 @@ func NewPolicyContextWithJsonContext(jsonContext context.Interface) *PolicyConte
 		excludeResourceFunc: func(string, string, string) bool {
 			return false
 		},
+		apiCallRequestCache: apicallcache.NewRequestCache(),
 	}
 }
 
@@ -169,6 +223,7 @@ func NewPolicyContextFromAdmissionRequest(
 	configuration config.Configuration,
 	client dclient.Interface,
 	informerCacheResolver resolvers.ConfigmapResolver,
+	peLister kyvernov2alpha1listers.PolicyExceptionLister,
 ) (*PolicyContext, error) {
 	ctx, err := newVariablesContext(request, &admissionInfo)
 	if err != nil {
@@ -188,7 +243,8 @@ func NewPolicyContextFromAdmissionRequest(
 		WithConfiguration(configuration).
 		WithClient(client).
 		WithAdmissionOperation(true).
-		WithInformerCacheResolver(informerCacheResolver)
+		WithInformerCacheResolver(informerCacheResolver).
+		WithExceptions(peLister)
 	return policyContext, nil
 }
 
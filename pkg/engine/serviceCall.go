@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+const (
+	// defaultServiceCallTimeout is the default timeout of service calls
+	defaultServiceCallTimeout = 10 * time.Second
+	// maxServiceCallResponseSize is the maximum size of a service call response
+	maxServiceCallResponseSize = 10 * 1024 * 1024
+)
+
+// fetchServiceData performs the service call defined in the context entry and returns the JSON response
+func fetchServiceData(logger logr.Logger, entry kyvernov1.ContextEntry, ctx *PolicyContext) ([]byte, error) {
+	service, err := substituteServiceCall(logger, ctx, entry.APICall.Service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s service call: %v", entry.Name, err)
+	}
+	if ctx.cliStore.GetMock() {
+		if data, ok := ctx.cliStore.GetServiceCallFixture(service.URL); ok {
+			return data, nil
+		}
+		if !ctx.cliStore.IsAllowApiCall() {
+			return nil, fmt.Errorf("no service call fixture for %s", service.URL)
+		}
+	}
+	key, err := json.Marshal(service)
+	if err != nil {
+		return nil, err
+	}
+	// only GET requests are cached across admission requests
+	global := service.Method == "" || service.Method == kyvernov1.MethodGet
+	return cachedAPICall(ctx, "service:"+string(key), global, func() ([]byte, error) {
+		return callService(logger, entry, service)
+	})
+}
+
+func callService(logger logr.Logger, entry kyvernov1.ContextEntry, service *kyvernov1.ServiceCall) ([]byte, error) {
+	req, err := buildServiceRequest(service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build service request for context entry %s: %v", entry.Name, err)
+	}
+	client, err := buildServiceClient(service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build http client for context entry %s: %v", entry.Name, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute service request for context entry %s: %v", entry.Name, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxServiceCallResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service response for context entry %s: %v", entry.Name, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("service request for context entry %s failed with status %s: %s", entry.Name, resp.Status, string(body))
+	}
+	logger.V(4).Info("executed service request", "url", service.URL, "method", service.Method, "status", resp.StatusCode, "len", len(body))
+	return body, nil
+}
+
+// substituteServiceCall substitutes variables in the service call url, headers and data
+func substituteServiceCall(logger logr.Logger, ctx *PolicyContext, service *kyvernov1.ServiceCall) (*kyvernov1.ServiceCall, error) {
+	data, err := json.Marshal(service)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	document, err = variables.SubstituteAll(logger, ctx.jsonContext, document)
+	if err != nil {
+		return nil, err
+	}
+	data, err = json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var substituted kyvernov1.ServiceCall
+	if err := json.Unmarshal(data, &substituted); err != nil {
+		return nil, err
+	}
+	return &substituted, nil
+}
+
+func buildServiceRequest(service *kyvernov1.ServiceCall) (*http.Request, error) {
+	method := service.Method
+	if method == "" {
+		method = kyvernov1.MethodGet
+	}
+	var body io.Reader
+	if method == kyvernov1.MethodPost {
+		data := map[string]interface{}{}
+		for _, d := range service.Data {
+			var value interface{}
+			if d.Value != nil {
+				if err := json.Unmarshal(d.Value.Raw, &value); err != nil {
+					return nil, fmt.Errorf("failed to decode data %s: %v", d.Key, err)
+				}
+			}
+			data[d.Key] = value
+		}
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(context.TODO(), string(method), service.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	for _, header := range service.Headers {
+		req.Header.Set(header.Key, header.Value)
+	}
+	return req, nil
+}
+
+func buildServiceClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
+	timeout := defaultServiceCallTimeout
+	if service.Timeout != nil {
+		timeout = service.Timeout.Duration
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if service.CABundle != "" {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM([]byte(service.CABundle)) {
+			return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", service.URL)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    caCertPool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
@@ -0,0 +1,230 @@
+package engine
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/apicallcache"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newServiceCallServer(t *testing.T) (*httptest.Server, string) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("boom"))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		var data interface{}
+		if len(body) > 0 {
+			assert.NilError(t, json.Unmarshal(body, &data))
+		}
+		response := map[string]interface{}{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"query":  r.URL.RawQuery,
+			"team":   r.Header.Get("X-Team"),
+			"data":   data,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NilError(t, json.NewEncoder(w).Encode(response))
+	}))
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, string(caBundle)
+}
+
+func newServiceCallPolicyContext(t *testing.T) *PolicyContext {
+	ctx := context.NewContext()
+	assert.NilError(t, context.AddResource(ctx, []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"apps","labels":{"team":"payments"}}}`)))
+	return NewPolicyContextWithJsonContext(ctx)
+}
+
+func Test_ServiceCall(t *testing.T) {
+	server, caBundle := newServiceCallServer(t)
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		service  kyvernov1.ServiceCall
+		jmesPath string
+		expected interface{}
+		wantErr  string
+	}{{
+		name: "GET with variables in url and headers",
+		service: kyvernov1.ServiceCall{
+			URL:      server.URL + "/owners/{{request.object.metadata.namespace}}?name={{request.object.metadata.name}}",
+			CABundle: caBundle,
+			Headers:  []kyvernov1.HTTPHeader{{Key: "X-Team", Value: "{{request.object.metadata.labels.team}}"}},
+		},
+		expected: map[string]interface{}{
+			"method": "GET",
+			"path":   "/owners/apps",
+			"query":  "name=test",
+			"team":   "payments",
+			"data":   nil,
+		},
+	}, {
+		name: "POST with variables in data and JMESPath",
+		service: kyvernov1.ServiceCall{
+			URL:      server.URL + "/lookup",
+			Method:   kyvernov1.MethodPost,
+			CABundle: caBundle,
+			Data: []kyvernov1.RequestData{{
+				Key:   "namespace",
+				Value: &apiextv1.JSON{Raw: []byte(`"{{request.object.metadata.namespace}}"`)},
+			}, {
+				Key:   "labels",
+				Value: &apiextv1.JSON{Raw: []byte(`"{{request.object.metadata.labels}}"`)},
+			}},
+		},
+		jmesPath: "data",
+		expected: map[string]interface{}{
+			"namespace": "apps",
+			"labels":    map[string]interface{}{"team": "payments"},
+		},
+	}, {
+		name: "untrusted server certificate",
+		service: kyvernov1.ServiceCall{
+			URL: server.URL + "/owners",
+		},
+		wantErr: "failed to execute service request",
+	}, {
+		name: "invalid CA bundle",
+		service: kyvernov1.ServiceCall{
+			URL:      server.URL + "/owners",
+			CABundle: "invalid",
+		},
+		wantErr: "failed to parse PEM CA bundle",
+	}, {
+		name: "error status",
+		service: kyvernov1.ServiceCall{
+			URL:      server.URL + "/error",
+			CABundle: caBundle,
+		},
+		wantErr: "failed with status 500 Internal Server Error: boom",
+	}, {
+		name: "timeout",
+		service: kyvernov1.ServiceCall{
+			URL:      server.URL + "/slow",
+			CABundle: caBundle,
+			Timeout:  &metav1.Duration{Duration: 50 * time.Millisecond},
+		},
+		wantErr: "Client.Timeout exceeded",
+	}}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			service := test.service
+			entry := kyvernov1.ContextEntry{
+				Name: "svc",
+				APICall: &kyvernov1.APICall{
+					Service:  &service,
+					JMESPath: test.jmesPath,
+				},
+			}
+			policyContext := newServiceCallPolicyContext(t)
+			err := loadAPIData(logging.GlobalLogger(), entry, policyContext)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			result, err := policyContext.JSONContext().Query("svc")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, test.expected)
+		})
+	}
+}
+
+func Test_APICallCache(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"owner":"payments"}`))
+	}))
+	defer server.Close()
+
+	get := kyvernov1.ContextEntry{
+		Name: "svc",
+		APICall: &kyvernov1.APICall{
+			Service:  &kyvernov1.ServiceCall{URL: server.URL + "/owners/{{request.object.metadata.namespace}}"},
+			JMESPath: "owner",
+		},
+	}
+	post := kyvernov1.ContextEntry{
+		Name: "svc",
+		APICall: &kyvernov1.APICall{
+			Service: &kyvernov1.ServiceCall{URL: server.URL + "/owners", Method: kyvernov1.MethodPost},
+		},
+	}
+	globalCache := apicallcache.New(10, time.Minute)
+
+	// identical lookups in the same request are deduplicated
+	policyContext := newServiceCallPolicyContext(t).WithAPICallCache(globalCache)
+	assert.NilError(t, loadAPIData(logging.GlobalLogger(), get, policyContext))
+	assert.NilError(t, loadAPIData(logging.GlobalLogger(), get, policyContext.WithPolicy(&kyvernov1.ClusterPolicy{})))
+	assert.Equal(t, calls, 1)
+	result, err := policyContext.JSONContext().Query("svc")
+	assert.NilError(t, err)
+	assert.Equal(t, result, "payments")
+
+	// GET lookups are served from the global cache in other requests
+	assert.NilError(t, loadAPIData(logging.GlobalLogger(), get, newServiceCallPolicyContext(t).WithAPICallCache(globalCache)))
+	assert.Equal(t, calls, 1)
+
+	// POST lookups are only cached in the request scope
+	policyContext = newServiceCallPolicyContext(t).WithAPICallCache(globalCache)
+	assert.NilError(t, loadAPIData(logging.GlobalLogger(), post, policyContext))
+	assert.NilError(t, loadAPIData(logging.GlobalLogger(), post, policyContext))
+	assert.Equal(t, calls, 2)
+	assert.NilError(t, loadAPIData(logging.GlobalLogger(), post, newServiceCallPolicyContext(t).WithAPICallCache(globalCache)))
+	assert.Equal(t, calls, 3)
+
+	// without global cache every request queries the service
+	assert.NilError(t, loadAPIData(logging.GlobalLogger(), get, newServiceCallPolicyContext(t)))
+	assert.Equal(t, calls, 4)
+}
+
+func Test_ServiceCallFixtures(t *testing.T) {
+	server, _ := newServiceCallServer(t)
+	defer server.Close()
+	cliStore := &store.Store{}
+	cliStore.SetMock(true)
+	cliStore.SetFixtures(store.Fixtures{
+		ServiceCalls: map[string][]byte{"https://approvals.kyverno.svc/namespaces/apps": []byte(`{"approved":true}`)},
+	})
+	newEntry := func(url string) kyvernov1.ContextEntry {
+		return kyvernov1.ContextEntry{
+			Name:    "approval",
+			APICall: &kyvernov1.APICall{Service: &kyvernov1.ServiceCall{URL: url}, JMESPath: "approved"},
+		}
+	}
+
+	policyContext := newServiceCallPolicyContext(t).WithCLIStore(cliStore)
+	err := LoadContext(logging.GlobalLogger(), nil, []kyvernov1.ContextEntry{newEntry("https://approvals.kyverno.svc/namespaces/{{request.object.metadata.namespace}}")}, policyContext, "rule")
+	assert.NilError(t, err)
+	result, err := policyContext.JSONContext().Query("approval")
+	assert.NilError(t, err)
+	assert.Equal(t, result, true)
+
+	// the service is not called in mock mode
+	policyContext = newServiceCallPolicyContext(t).WithCLIStore(cliStore)
+	err = LoadContext(logging.GlobalLogger(), nil, []kyvernov1.ContextEntry{newEntry(server.URL + "/approvals")}, policyContext, "rule")
+	assert.ErrorContains(t, err, "no service call fixture for "+server.URL+"/approvals")
+}
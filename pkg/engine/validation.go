@@ -10,7 +10,6 @@ import (
 	"github.com/go-logr/logr"
 	gojmespath "github.com/jmespath/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/engine/common"
 	"github.com/kyverno/kyverno/pkg/engine/context"
@@ -123,6 +122,12 @@ func validateResource(log logr.Logger, rclient registryclient.Client, ctx *Polic
 			continue
 		}
 
+		// check if there is a policy exception matches the incoming resource
+		if exceptionResp := hasPolicyExceptions(log, response.Validation, ctx, rule); exceptionResp != nil {
+			addRuleResponse(log, resp, exceptionResp, time.Now())
+			continue
+		}
+
 		log.V(3).Info("processing validation rule", "matchCount", matchCount, "applyRules", applyRules)
 		ctx.jsonContext.Reset()
 		startTime := time.Now()
@@ -333,7 +338,7 @@ func (v *validator) validateElements(foreach kyvernov1.ForEachValidation, elemen
 		if e == nil {
 			continue
 		}
-		store.SetForeachElement(i)
+		v.ctx.cliStore.SetForeachElement(i)
 		v.ctx.jsonContext.Reset()
 
 		ctx := v.ctx.Copy()
@@ -572,13 +577,13 @@ func isEmptyUnstructured(u *unstructured.Unstructured) bool {
 
 // matches checks if either the new or old resource satisfies the filter conditions defined in the rule
 func matches(logger logr.Logger, rule *kyvernov1.Rule, ctx *PolicyContext) bool {
-	err := MatchesResourceDescription(ctx.newResource, *rule, ctx.admissionInfo, ctx.excludeGroupRole, ctx.namespaceLabels, "")
+	err := MatchesResourceDescription(ctx.newResource, *rule, ctx.admissionInfo, ctx.excludeGroupRole, ctx.namespaceLabels, "", ctx.cliStore)
 	if err == nil {
 		return true
 	}
 
 	if !reflect.DeepEqual(ctx.oldResource, unstructured.Unstructured{}) {
-		err := MatchesResourceDescription(ctx.oldResource, *rule, ctx.admissionInfo, ctx.excludeGroupRole, ctx.namespaceLabels, "")
+		err := MatchesResourceDescription(ctx.oldResource, *rule, ctx.admissionInfo, ctx.excludeGroupRole, ctx.namespaceLabels, "", ctx.cliStore)
 		if err == nil {
 			return true
 		}
@@ -7,7 +7,9 @@ import (
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	urkyverno "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
@@ -15,6 +17,7 @@ import (
 	utils2 "github.com/kyverno/kyverno/pkg/utils"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 func TestGetAnchorsFromMap_ThereAreAnchors(t *testing.T) {
@@ -2228,8 +2231,9 @@ func TestValidate_context_variable_substitution_CLI(t *testing.T) {
 		},
 	}
 
-	store.SetContext(configMapVariableContext)
-	store.SetMock(true)
+	var cliStore store.Store
+	cliStore.SetContext(configMapVariableContext)
+	cliStore.SetMock(true)
 
 	var policy kyverno.ClusterPolicy
 	err := json.Unmarshal(rawPolicy, &policy)
@@ -2240,7 +2244,7 @@ func TestValidate_context_variable_substitution_CLI(t *testing.T) {
 	msgs := []string{
 		"restrict pod counts to be no more than 10 on node minikube",
 	}
-	er := Validate(registryclient.NewOrDie(), &PolicyContext{policy: &policy, newResource: *resourceUnstructured, jsonContext: context.NewContext()})
+	er := Validate(registryclient.NewOrDie(), &PolicyContext{policy: &policy, newResource: *resourceUnstructured, jsonContext: context.NewContext(), cliStore: &cliStore})
 	for index, r := range er.PolicyResponse.Rules {
 		assert.Equal(t, r.Message, msgs[index])
 	}
@@ -2744,10 +2748,11 @@ func Test_foreach_context_preconditions(t *testing.T) {
 		},
 	}
 
-	store.SetContext(configMapVariableContext)
-	store.SetMock(true)
+	var cliStore store.Store
+	cliStore.SetContext(configMapVariableContext)
+	cliStore.SetMock(true)
 
-	testForEach(t, policyraw, resourceRaw, "", response.RuleStatusPass)
+	testForEachWithCLIStore(t, &cliStore, policyraw, resourceRaw, "", response.RuleStatusPass)
 }
 
 func Test_foreach_context_preconditions_fail(t *testing.T) {
@@ -2839,10 +2844,11 @@ func Test_foreach_context_preconditions_fail(t *testing.T) {
 		},
 	}
 
-	store.SetContext(configMapVariableContext)
-	store.SetMock(true)
+	var cliStore store.Store
+	cliStore.SetContext(configMapVariableContext)
+	cliStore.SetMock(true)
 
-	testForEach(t, policyraw, resourceRaw, "", response.RuleStatusFail)
+	testForEachWithCLIStore(t, &cliStore, policyraw, resourceRaw, "", response.RuleStatusFail)
 }
 
 func Test_foreach_element_validation(t *testing.T) {
@@ -2992,6 +2998,10 @@ func Test_foreach_skip_initContainer_pass(t *testing.T) {
 }
 
 func testForEach(t *testing.T, policyraw []byte, resourceRaw []byte, msg string, status response.RuleStatus) {
+	testForEachWithCLIStore(t, nil, policyraw, resourceRaw, msg, status)
+}
+
+func testForEachWithCLIStore(t *testing.T, cliStore *store.Store, policyraw []byte, resourceRaw []byte, msg string, status response.RuleStatus) {
 	var policy kyverno.ClusterPolicy
 	assert.NilError(t, json.Unmarshal(policyraw, &policy))
 	resourceUnstructured, err := utils.ConvertToUnstructured(resourceRaw)
@@ -3004,7 +3014,9 @@ func testForEach(t *testing.T, policyraw []byte, resourceRaw []byte, msg string,
 	policyContext := &PolicyContext{
 		policy:      &policy,
 		jsonContext: ctx,
-		newResource: *resourceUnstructured}
+		newResource: *resourceUnstructured,
+		cliStore:    cliStore,
+	}
 	er := Validate(registryclient.NewOrDie(), policyContext)
 
 	assert.Equal(t, er.PolicyResponse.Rules[0].Status, status)
@@ -3147,3 +3159,103 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_policy_exception(t *testing.T) {
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "nginx",
+			"namespace": "team-a"
+		},
+		"spec": {
+			"containers": [
+				{
+					"name": "nginx",
+					"image": "nginx"
+				}
+			]
+		}
+	}`)
+
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-labels"},
+		"spec": {
+			"validationFailureAction": "enforce",
+			"rules": [
+				{
+					"name": "check-team",
+					"match": {"resources": {"kinds": ["Pod"]}},
+					"validate": {
+						"message": "label 'team' is required",
+						"pattern": {"metadata": {"labels": {"team": "?*"}}}
+					}
+				}
+			]
+		}
+	}`)
+
+	polexRaw := []byte(`{
+		"apiVersion": "kyverno.io/v2alpha1",
+		"kind": "PolicyException",
+		"metadata": {"name": "allow-nginx", "namespace": "team-a"},
+		"spec": {
+			"exceptions": [
+				{"policyName": "require-labels", "ruleNames": ["check-*"]}
+			],
+			"match": {
+				"any": [
+					{"resources": {"kinds": ["Pod"], "names": ["nginx"]}}
+				]
+			}
+		}
+	}`)
+
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(policyRaw, &policy))
+	var polex kyvernov2alpha1.PolicyException
+	assert.NilError(t, json.Unmarshal(polexRaw, &polex))
+	resourceUnstructured, err := utils.ConvertToUnstructured(resourceRaw)
+	assert.NilError(t, err)
+
+	ctx := context.NewContext()
+	err = context.AddResource(ctx, resourceRaw)
+	assert.NilError(t, err)
+
+	policyContext := &PolicyContext{
+		policy:      &policy,
+		jsonContext: ctx,
+		newResource: *resourceUnstructured,
+	}
+	er := Validate(registryclient.NewOrDie(), policyContext)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusFail)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, indexer.Add(&polex))
+	er = Validate(registryclient.NewOrDie(), policyContext.WithExceptions(kyvernov2alpha1listers.NewPolicyExceptionLister(indexer)))
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusSkip)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Message, "rule skipped due to policy exception team-a/allow-nginx")
+
+	other := policyContext.WithNewResource(*resourceUnstructured.DeepCopy())
+	other.newResource.SetName("httpd")
+	er = Validate(registryclient.NewOrDie(), other.WithExceptions(kyvernov2alpha1listers.NewPolicyExceptionLister(indexer)))
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusFail)
+
+	// an exception does not apply to resources outside of its namespace
+	otherNamespace := policyContext.WithNewResource(*resourceUnstructured.DeepCopy())
+	otherNamespace.newResource.SetNamespace("team-b")
+	er = Validate(registryclient.NewOrDie(), otherNamespace.WithExceptions(kyvernov2alpha1listers.NewPolicyExceptionLister(indexer)))
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusFail)
+
+	clusterScoped := policyContext.WithNewResource(*resourceUnstructured.DeepCopy())
+	clusterScoped.newResource.SetNamespace("")
+	er = Validate(registryclient.NewOrDie(), clusterScoped.WithExceptions(kyvernov2alpha1listers.NewPolicyExceptionLister(indexer)))
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, response.RuleStatusFail)
+}
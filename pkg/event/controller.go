@@ -7,7 +7,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	corev1 "k8s.io/api/core/v1"
 	errors "k8s.io/apimachinery/pkg/api/errors"
@@ -26,6 +28,10 @@ type Generator struct {
 	cpLister kyvernov1listers.ClusterPolicyLister
 	// list/get policy
 	pLister kyvernov1listers.PolicyLister
+	// list/get cluster cleanup policy
+	cleanupCpLister kyvernov2alpha1listers.ClusterCleanupPolicyLister
+	// list/get cleanup policy
+	cleanupPLister kyvernov2alpha1listers.CleanupPolicyLister
 	// queue to store event generation requests
 	queue workqueue.RateLimitingInterface
 	// events generated at policy controller
@@ -36,6 +42,8 @@ type Generator struct {
 	genPolicyRecorder record.EventRecorder
 	// events generated at mutateExisting controller
 	mutateExistingRecorder record.EventRecorder
+	// events generated at cleanup controller
+	cleanupRecorder record.EventRecorder
 
 	maxQueuedEvents int
 
@@ -64,6 +72,20 @@ func NewEventGenerator(client dclient.Interface, cpInformer kyvernov1informers.C
 	return &gen
 }
 
+// NewEventCleanupGenerator to generate a new event controller for the cleanup controller
+func NewEventCleanupGenerator(client dclient.Interface, cpInformer kyvernov2alpha1informers.ClusterCleanupPolicyInformer, pInformer kyvernov2alpha1informers.CleanupPolicyInformer, maxQueuedEvents int, log logr.Logger) *Generator {
+	gen := Generator{
+		client:          client,
+		cleanupCpLister: cpInformer.Lister(),
+		cleanupPLister:  pInformer.Lister(),
+		queue:           workqueue.NewNamedRateLimitingQueue(rateLimiter(), eventWorkQueueName),
+		cleanupRecorder: initRecorder(client, CleanupController, log),
+		maxQueuedEvents: maxQueuedEvents,
+		log:             log,
+	}
+	return &gen
+}
+
 func rateLimiter() workqueue.RateLimiter {
 	return workqueue.DefaultItemBasedRateLimiter()
 }
@@ -188,6 +210,18 @@ func (gen *Generator) syncHandler(key Info) error {
 			logger.Error(err, "failed to get policy", "name", key.Name)
 			return err
 		}
+	case "ClusterCleanupPolicy":
+		robj, err = gen.cleanupCpLister.Get(key.Name)
+		if err != nil {
+			logger.Error(err, "failed to get cluster cleanup policy", "name", key.Name)
+			return err
+		}
+	case "CleanupPolicy":
+		robj, err = gen.cleanupPLister.CleanupPolicies(key.Namespace).Get(key.Name)
+		if err != nil {
+			logger.Error(err, "failed to get cleanup policy", "name", key.Name)
+			return err
+		}
 	default:
 		robj, err = gen.client.GetResource(context.TODO(), "", key.Kind, key.Namespace, key.Name)
 		if err != nil {
@@ -203,7 +237,7 @@ func (gen *Generator) syncHandler(key Info) error {
 	// if skip/pass, reason will be: NORMAL
 	// else reason will be: WARNING
 	eventType := corev1.EventTypeWarning
-	if key.Reason == PolicyApplied.String() || key.Reason == PolicySkipped.String() {
+	if key.Reason == PolicyApplied.String() || key.Reason == PolicySkipped.String() || key.Reason == CleanupDryRun.String() {
 		eventType = corev1.EventTypeNormal
 	}
 
@@ -217,6 +251,8 @@ func (gen *Generator) syncHandler(key Info) error {
 		gen.genPolicyRecorder.Event(robj, eventType, key.Reason, key.Message)
 	case MutateExistingController:
 		gen.mutateExistingRecorder.Event(robj, eventType, key.Reason, key.Message)
+	case CleanupController:
+		gen.cleanupRecorder.Event(robj, eventType, key.Reason, key.Message)
 	default:
 		logger.Info("info.source not defined for the request")
 	}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/engine/response"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -125,3 +126,29 @@ func NewBackgroundSuccessEvent(policy, rule string, source Source, r *unstructur
 
 	return events
 }
+
+func getCleanupPolicyKind(policy kyvernov2alpha1.CleanupPolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return "CleanupPolicy"
+	}
+
+	return "ClusterCleanupPolicy"
+}
+
+func NewCleanupDryRunEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured) Info {
+	var msg string
+	if resource.GetNamespace() != "" {
+		msg = fmt.Sprintf("%s %s/%s would be deleted (dry run)", resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	} else {
+		msg = fmt.Sprintf("%s %s would be deleted (dry run)", resource.GetKind(), resource.GetName())
+	}
+
+	return Info{
+		Kind:      getCleanupPolicyKind(policy),
+		Name:      policy.GetName(),
+		Namespace: policy.GetNamespace(),
+		Reason:    CleanupDryRun.String(),
+		Source:    CleanupController,
+		Message:   msg,
+	}
+}
@@ -8,6 +8,7 @@ const (
 	PolicyApplied
 	PolicyError
 	PolicySkipped
+	CleanupDryRun
 )
 
 func (r Reason) String() string {
@@ -16,5 +17,6 @@ func (r Reason) String() string {
 		"PolicyApplied",
 		"PolicyError",
 		"PolicySkipped",
+		"CleanupDryRun",
 	}[r]
 }
@@ -12,6 +12,8 @@ const (
 	GeneratePolicyController
 	// MutateExistingController : event generated for mutateExisting policies
 	MutateExistingController
+	// CleanupController : event generated by the cleanup controller
+	CleanupController
 )
 
 func (s Source) String() string {
@@ -20,5 +22,6 @@ func (s Source) String() string {
 		"kyverno-scan",
 		"kyverno-generate",
 		"kyverno-mutate",
+		"kyverno-cleanup",
 	}[s]
 }
@@ -0,0 +1,79 @@
+package externalapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Caller fetches the JSON data of the entry.
+type Caller = func(context.Context) ([]byte, error)
+
+// RefreshHandler is called after each refresh with the refresh error, if any.
+type RefreshHandler = func(error)
+
+type entry struct {
+	sync.RWMutex
+	data   interface{}
+	err    error
+	loaded bool
+	stop   func()
+}
+
+// New creates a global context entry storing the data returned by the caller,
+// refreshed every interval until the entry is stopped.
+// On refresh failures the entry keeps serving the last data successfully fetched.
+func New(ctx context.Context, interval time.Duration, caller Caller, onRefresh RefreshHandler) store.Entry {
+	ctx, cancel := context.WithCancel(ctx)
+	e := &entry{
+		stop: cancel,
+	}
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		err := e.refresh(ctx, caller)
+		if onRefresh != nil && ctx.Err() == nil {
+			onRefresh(err)
+		}
+	}, interval)
+	return e
+}
+
+func (e *entry) refresh(ctx context.Context, caller Caller) error {
+	data, err := caller(ctx)
+	if err == nil {
+		var value interface{}
+		if err = json.Unmarshal(data, &value); err == nil {
+			e.Lock()
+			defer e.Unlock()
+			e.data, e.err, e.loaded = value, nil, true
+			return nil
+		}
+		err = fmt.Errorf("failed to unmarshal JSON data: %v", err)
+	}
+	e.Lock()
+	defer e.Unlock()
+	if !e.loaded {
+		e.err = err
+	}
+	return err
+}
+
+func (e *entry) Get() (interface{}, error) {
+	e.RLock()
+	defer e.RUnlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	if !e.loaded {
+		return nil, fmt.Errorf("data not yet available")
+	}
+	return e.data, nil
+}
+
+func (e *entry) Stop() {
+	e.stop()
+}
@@ -0,0 +1,64 @@
+package externalapi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func waitForRefresh(t *testing.T, refreshed chan error) error {
+	select {
+	case err := <-refreshed:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for refresh")
+		return nil
+	}
+}
+
+func onRefresh(refreshed chan error) RefreshHandler {
+	return func(err error) {
+		select {
+		case refreshed <- err:
+		default:
+		}
+	}
+}
+
+func TestEntry(t *testing.T) {
+	var calls int32
+	caller := func(context.Context) ([]byte, error) {
+		if atomic.AddInt32(&calls, 1) == 2 {
+			return nil, errors.New("unavailable")
+		}
+		return []byte(`{"foo":"bar"}`), nil
+	}
+	refreshed := make(chan error, 10)
+	e := New(context.Background(), 10*time.Millisecond, caller, onRefresh(refreshed))
+	defer e.Stop()
+	assert.NilError(t, waitForRefresh(t, refreshed))
+	data, err := e.Get()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, data, map[string]interface{}{"foo": "bar"})
+	// failed refreshes keep the last data
+	assert.Error(t, waitForRefresh(t, refreshed), "unavailable")
+	data, err = e.Get()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, data, map[string]interface{}{"foo": "bar"})
+}
+
+func TestEntry_Error(t *testing.T) {
+	caller := func(context.Context) ([]byte, error) {
+		return nil, errors.New("unavailable")
+	}
+	refreshed := make(chan error, 10)
+	e := New(context.Background(), time.Hour, caller, onRefresh(refreshed))
+	defer e.Stop()
+	assert.Error(t, waitForRefresh(t, refreshed), "unavailable")
+	_, err := e.Get()
+	assert.Error(t, err, "unavailable")
+}
@@ -0,0 +1,55 @@
+package k8sresource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// syncTimeout is the maximum time to wait for the informer cache to sync
+const syncTimeout = 30 * time.Second
+
+type entry struct {
+	informer cache.SharedIndexInformer
+	stop     func()
+}
+
+// New creates a global context entry storing the list of resources of the given type,
+// kept up to date with an informer. It returns once the informer cache is synced,
+// or fails if the cache could not be synced in time.
+func New(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, namespace string) (store.Entry, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	informer := dynamicinformer.NewFilteredDynamicInformer(client, gvr, namespace, 0, cache.Indexers{}, nil).Informer()
+	go informer.Run(ctx.Done())
+	syncCtx, syncCancel := context.WithTimeout(ctx, syncTimeout)
+	defer syncCancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		cancel()
+		return nil, fmt.Errorf("failed to sync informer for %s", gvr)
+	}
+	return &entry{
+		informer: informer,
+		stop:     cancel,
+	}, nil
+}
+
+func (e *entry) Get() (interface{}, error) {
+	var items []interface{}
+	for _, obj := range e.informer.GetStore().List() {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			items = append(items, u.UnstructuredContent())
+		}
+	}
+	return items, nil
+}
+
+func (e *entry) Stop() {
+	e.stop()
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"sync"
+)
+
+// Entry holds the data of a global context entry.
+type Entry interface {
+	// Get returns the current data of the entry.
+	Get() (interface{}, error)
+	// Stop releases the resources used to keep the entry up to date.
+	Stop()
+}
+
+// Store holds the global context entries by name.
+type Store interface {
+	// Set stores the entry, stopping the entry previously stored with the same name.
+	Set(name string, entry Entry)
+	// Get returns the entry stored with the given name.
+	Get(name string) (Entry, bool)
+	// Delete stops and removes the entry stored with the given name.
+	Delete(name string)
+}
+
+type store struct {
+	sync.RWMutex
+	entries map[string]Entry
+}
+
+// New creates a new global context store.
+func New() Store {
+	return &store{
+		entries: map[string]Entry{},
+	}
+}
+
+func (s *store) Set(name string, entry Entry) {
+	s.Lock()
+	defer s.Unlock()
+	if old := s.entries[name]; old != nil {
+		old.Stop()
+	}
+	s.entries[name] = entry
+}
+
+func (s *store) Get(name string) (Entry, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	entry, ok := s.entries[name]
+	return entry, ok
+}
+
+func (s *store) Delete(name string) {
+	s.Lock()
+	defer s.Unlock()
+	if entry := s.entries[name]; entry != nil {
+		entry.Stop()
+	}
+	delete(s.entries, name)
+}
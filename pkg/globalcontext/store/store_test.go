@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type fakeEntry struct {
+	data    interface{}
+	stopped bool
+}
+
+func (e *fakeEntry) Get() (interface{}, error) { return e.data, nil }
+func (e *fakeEntry) Stop()                     { e.stopped = true }
+
+func TestStore(t *testing.T) {
+	s := New()
+	_, ok := s.Get("foo")
+	assert.Assert(t, !ok)
+	first := &fakeEntry{data: "first"}
+	s.Set("foo", first)
+	entry, ok := s.Get("foo")
+	assert.Assert(t, ok)
+	data, err := entry.Get()
+	assert.NilError(t, err)
+	assert.Equal(t, data, "first")
+	second := &fakeEntry{data: "second"}
+	s.Set("foo", second)
+	assert.Assert(t, first.stopped)
+	entry, ok = s.Get("foo")
+	assert.Assert(t, ok)
+	data, err = entry.Get()
+	assert.NilError(t, err)
+	assert.Equal(t, data, "second")
+	s.Delete("foo")
+	assert.Assert(t, second.stopped)
+	_, ok = s.Get("foo")
+	assert.Assert(t, !ok)
+}
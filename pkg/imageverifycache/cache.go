@@ -0,0 +1,105 @@
+package imageverifycache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+// Cache stores the results of successful image signature and attestation verifications
+// so that the same image is not verified against the registry on every request.
+type Cache interface {
+	// Get returns the cached response for the given key
+	Get(ctx context.Context, key string) (*cosign.Response, bool)
+	// Set stores the response for the given key
+	Set(ctx context.Context, key string, response *cosign.Response)
+}
+
+// ErrorCache is implemented by the caches that also store failed verifications, so that a verification
+// fails without going to the registry again, the engine stores the errors when the cache implements it
+type ErrorCache interface {
+	// GetError returns the cached error for the given key
+	GetError(ctx context.Context, key string) (error, bool)
+	// SetError stores the error for the given key
+	SetError(ctx context.Context, key string, err error)
+}
+
+type lruCache struct {
+	cache         *cache.LRUExpireCache
+	ttl           time.Duration
+	metricsConfig metrics.MetricsConfigManager
+}
+
+// New creates a LRU cache holding at most maxSize entries, entries expire after ttl.
+// metricsConfig is optional and used to record cache hits and misses.
+func New(maxSize int, ttl time.Duration, metricsConfig metrics.MetricsConfigManager) Cache {
+	return &lruCache{
+		cache:         cache.NewLRUExpireCache(maxSize),
+		ttl:           ttl,
+		metricsConfig: metricsConfig,
+	}
+}
+
+// NewWithErrors creates a LRU cache like New that also stores failed verifications, for callers that
+// verify the same images repeatedly in a bounded time, like a periodic verification run
+func NewWithErrors(maxSize int, ttl time.Duration) Cache {
+	return &errorCache{
+		lruCache: lruCache{
+			cache: cache.NewLRUExpireCache(maxSize),
+			ttl:   ttl,
+		},
+	}
+}
+
+func (c *lruCache) Get(ctx context.Context, key string) (*cosign.Response, bool) {
+	if value, ok := c.cache.Get(key); ok {
+		if c.metricsConfig != nil {
+			c.metricsConfig.RecordImageVerifyCacheHit(ctx)
+		}
+		return value.(*cosign.Response), true
+	}
+	if c.metricsConfig != nil {
+		c.metricsConfig.RecordImageVerifyCacheMiss(ctx)
+	}
+	return nil, false
+}
+
+func (c *lruCache) Set(ctx context.Context, key string, response *cosign.Response) {
+	c.cache.Add(key, response, c.ttl)
+}
+
+type errorCache struct {
+	lruCache
+}
+
+func errorKey(key string) string {
+	return "error/" + key
+}
+
+func (c *errorCache) GetError(ctx context.Context, key string) (error, bool) {
+	if value, ok := c.cache.Get(errorKey(key)); ok {
+		return value.(error), true
+	}
+	return nil, false
+}
+
+func (c *errorCache) SetError(ctx context.Context, key string, err error) {
+	c.cache.Add(errorKey(key), err, c.ttl)
+}
+
+// Key builds a cache key from the policy and rule names, the verification operation
+// and the verification options (image reference pinned to its digest, attestors, key version, predicate type...).
+func Key(policy, rule, operation string, options interface{}) (string, error) {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return policy + "/" + rule + "/" + operation + "/" + hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,72 @@
+package imageverifycache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"gotest.tools/assert"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.TODO()
+	c := New(2, time.Minute, nil)
+	_, ok := c.Get(ctx, "a")
+	assert.Assert(t, !ok)
+	c.Set(ctx, "a", &cosign.Response{Digest: "sha256:a"})
+	c.Set(ctx, "b", &cosign.Response{Digest: "sha256:b"})
+	resp, ok := c.Get(ctx, "a")
+	assert.Assert(t, ok)
+	assert.Equal(t, resp.Digest, "sha256:a")
+	// "b" is the least recently used entry and gets evicted
+	c.Set(ctx, "c", &cosign.Response{Digest: "sha256:c"})
+	_, ok = c.Get(ctx, "b")
+	assert.Assert(t, !ok)
+	_, ok = c.Get(ctx, "c")
+	assert.Assert(t, ok)
+}
+
+func TestCache_TTL(t *testing.T) {
+	ctx := context.TODO()
+	c := New(10, time.Millisecond, nil)
+	c.Set(ctx, "a", &cosign.Response{Digest: "sha256:a"})
+	time.Sleep(5 * time.Millisecond)
+	_, ok := c.Get(ctx, "a")
+	assert.Assert(t, !ok)
+}
+
+func TestCache_Errors(t *testing.T) {
+	ctx := context.TODO()
+	_, ok := New(10, time.Minute, nil).(ErrorCache)
+	assert.Assert(t, !ok)
+	c := NewWithErrors(10, time.Minute)
+	errCache, ok := c.(ErrorCache)
+	assert.Assert(t, ok)
+	_, ok = errCache.GetError(ctx, "a")
+	assert.Assert(t, !ok)
+	errCache.SetError(ctx, "a", errors.New("signature revoked"))
+	err, ok := errCache.GetError(ctx, "a")
+	assert.Assert(t, ok)
+	assert.Error(t, err, "signature revoked")
+	// errors and responses don't share entries
+	_, ok = c.Get(ctx, "a")
+	assert.Assert(t, !ok)
+}
+
+func TestKey(t *testing.T) {
+	opts := cosign.Options{ImageRef: "ghcr.io/kyverno/test@sha256:abc", Key: "key"}
+	k1, err := Key("policy", "rule", "verify", opts)
+	assert.NilError(t, err)
+	k2, err := Key("policy", "rule", "verify", opts)
+	assert.NilError(t, err)
+	assert.Equal(t, k1, k2)
+	opts.Key = "other"
+	k3, err := Key("policy", "rule", "verify", opts)
+	assert.NilError(t, err)
+	assert.Assert(t, k1 != k3)
+	k4, err := Key("policy", "other", "verify", opts)
+	assert.NilError(t, err)
+	assert.Assert(t, k3 != k4)
+}
@@ -34,6 +34,8 @@ type MetricsConfig struct {
 	policyResultsMetric           syncint64.Counter
 	policyExecutionDurationMetric syncfloat64.Histogram
 	clientQueriesMetric           syncint64.Counter
+	imageVerifyCacheHitsMetric    syncint64.Counter
+	imageVerifyCacheMissesMetric  syncint64.Counter
 
 	// config
 	config kconfig.MetricsConfiguration
@@ -46,6 +48,8 @@ type MetricsConfigManager interface {
 	RecordPolicyChanges(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, policyChangeType string)
 	RecordPolicyExecutionDuration(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, ruleName string, ruleResult RuleResult, ruleType RuleType, ruleExecutionCause RuleExecutionCause, ruleExecutionLatency float64)
 	RecordClientQueries(ctx context.Context, clientQueryOperation ClientQueryOperation, clientType ClientType, resourceKind string, resourceNamespace string)
+	RecordImageVerifyCacheHit(ctx context.Context)
+	RecordImageVerifyCacheMiss(ctx context.Context)
 }
 
 func (m *MetricsConfig) Config() kconfig.MetricsConfiguration {
@@ -75,6 +79,16 @@ func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) er
 		m.Log.Error(err, "Failed to create instrument, kyverno_client_queries")
 		return err
 	}
+	m.imageVerifyCacheHitsMetric, err = meter.SyncInt64().Counter("kyverno_image_verify_cache_hits", instrument.WithDescription("can be used to track the number of image verification results served from the cache"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_image_verify_cache_hits")
+		return err
+	}
+	m.imageVerifyCacheMissesMetric, err = meter.SyncInt64().Counter("kyverno_image_verify_cache_misses", instrument.WithDescription("can be used to track the number of image verifications not found in the cache and sent to the registry"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_image_verify_cache_misses")
+		return err
+	}
 	return nil
 }
 
@@ -236,3 +250,11 @@ func (m *MetricsConfig) RecordClientQueries(ctx context.Context, clientQueryOper
 	}
 	m.clientQueriesMetric.Add(ctx, 1, commonLabels...)
 }
+
+func (m *MetricsConfig) RecordImageVerifyCacheHit(ctx context.Context) {
+	m.imageVerifyCacheHitsMetric.Add(ctx, 1)
+}
+
+func (m *MetricsConfig) RecordImageVerifyCacheMiss(ctx context.Context) {
+	m.imageVerifyCacheMissesMetric.Add(ctx, 1)
+}
@@ -0,0 +1,214 @@
+package notary
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	mediaTypePayload = "application/vnd.cncf.notary.payload.v1+json"
+
+	headerSigningScheme        = "io.cncf.notary.signingScheme"
+	headerSigningTime          = "io.cncf.notary.signingTime"
+	headerAuthenticSigningTime = "io.cncf.notary.authenticSigningTime"
+	headerExpiry               = "io.cncf.notary.expiry"
+
+	signingSchemeX509                 = "notary.x509"
+	signingSchemeX509SigningAuthority = "notary.x509.signingAuthority"
+)
+
+// criticalHeaders lists the protected headers that can be marked as critical
+var criticalHeaders = map[string]bool{
+	headerSigningScheme:        true,
+	headerAuthenticSigningTime: true,
+	headerExpiry:               true,
+}
+
+// jwsEnvelope is a JWS signature envelope using the flattened JSON serialization
+type jwsEnvelope struct {
+	Payload   string `json:"payload"`
+	Protected string `json:"protected"`
+	Header    struct {
+		CertChain [][]byte `json:"x5c"`
+	} `json:"header"`
+	Signature string `json:"signature"`
+}
+
+// jwsProtectedHeader contains the protected headers of a Notary v2 JWS signature envelope
+type jwsProtectedHeader struct {
+	Algorithm            string     `json:"alg"`
+	ContentType          string     `json:"cty"`
+	Critical             []string   `json:"crit,omitempty"`
+	SigningScheme        string     `json:"io.cncf.notary.signingScheme"`
+	SigningTime          *time.Time `json:"io.cncf.notary.signingTime,omitempty"`
+	AuthenticSigningTime *time.Time `json:"io.cncf.notary.authenticSigningTime,omitempty"`
+	Expiry               *time.Time `json:"io.cncf.notary.expiry,omitempty"`
+}
+
+// payload is the Notary v2 signature payload
+type payload struct {
+	TargetArtifact gcrv1.Descriptor `json:"targetArtifact"`
+}
+
+func verifyJWSEnvelope(data []byte, roots *x509.CertPool, target gcrv1.Descriptor, annotations map[string]string) error {
+	var envelope jwsEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return errors.Wrap(err, "failed to decode JWS envelope")
+	}
+	header, err := decodeProtectedHeader(envelope.Protected)
+	if err != nil {
+		return err
+	}
+	if len(envelope.Header.CertChain) == 0 {
+		return errors.New("missing certificate chain in JWS envelope")
+	}
+	var certs []*x509.Certificate
+	for _, der := range envelope.Header.CertChain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse certificate chain")
+		}
+		certs = append(certs, cert)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(envelope.Signature)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signature")
+	}
+	signingInput := []byte(envelope.Protected + "." + envelope.Payload)
+	if err := verifySignature(header.Algorithm, certs[0].PublicKey, signingInput, signature); err != nil {
+		return err
+	}
+	now := time.Now()
+	if header.Expiry != nil && now.After(*header.Expiry) {
+		return fmt.Errorf("signature expired on %s", header.Expiry.Format(time.RFC3339))
+	}
+	verifyTime := now
+	if header.SigningScheme == signingSchemeX509SigningAuthority {
+		verifyTime = *header.AuthenticSigningTime
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   verifyTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return errors.Wrap(err, "certificate chain is not trusted")
+	}
+	return verifyPayload(envelope.Payload, target, annotations)
+}
+
+func decodeProtectedHeader(encoded string) (*jwsProtectedHeader, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode protected header")
+	}
+	var header jwsProtectedHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, errors.Wrap(err, "failed to decode protected header")
+	}
+	if header.ContentType != mediaTypePayload {
+		return nil, fmt.Errorf("unsupported payload content type %s", header.ContentType)
+	}
+	for _, crit := range header.Critical {
+		if !criticalHeaders[crit] {
+			return nil, fmt.Errorf("unsupported critical header %s", crit)
+		}
+	}
+	switch header.SigningScheme {
+	case signingSchemeX509:
+		if header.SigningTime == nil {
+			return nil, fmt.Errorf("missing %s header", headerSigningTime)
+		}
+	case signingSchemeX509SigningAuthority:
+		if header.AuthenticSigningTime == nil {
+			return nil, fmt.Errorf("missing %s header", headerAuthenticSigningTime)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported signing scheme %s", header.SigningScheme)
+	}
+	return &header, nil
+}
+
+func verifySignature(algorithm string, publicKey crypto.PublicKey, signingInput, signature []byte) error {
+	var hash crypto.Hash
+	switch algorithm {
+	case "PS256", "ES256":
+		hash = crypto.SHA256
+	case "PS384", "ES384":
+		hash = crypto.SHA384
+	case "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signature algorithm %s", algorithm)
+	}
+	hasher := hash.New()
+	hasher.Write(signingInput)
+	digest := hasher.Sum(nil)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if algorithm[0] != 'P' {
+			return fmt.Errorf("signature algorithm %s doesn't match RSA key", algorithm)
+		}
+		if err := rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			return errors.Wrap(err, "invalid signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if algorithm[0] != 'E' {
+			return fmt.Errorf("signature algorithm %s doesn't match ECDSA key", algorithm)
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+func verifyPayload(encoded string, target gcrv1.Descriptor, annotations map[string]string) error {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode payload")
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return errors.Wrap(err, "failed to decode payload")
+	}
+	signed := p.TargetArtifact
+	if signed.Digest != target.Digest {
+		return fmt.Errorf("signed digest %s does not match image digest %s", signed.Digest, target.Digest)
+	}
+	if signed.Size != target.Size {
+		return fmt.Errorf("signed size %d does not match image size %d", signed.Size, target.Size)
+	}
+	if signed.MediaType != "" && target.MediaType != "" && signed.MediaType != target.MediaType {
+		return fmt.Errorf("signed media type %s does not match image media type %s", signed.MediaType, target.MediaType)
+	}
+	for key, val := range annotations {
+		if val != signed.Annotations[key] {
+			return fmt.Errorf("annotations mismatch: %s does not match expected value %s for key %s", signed.Annotations[key], val, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package notary
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName("notary")
@@ -0,0 +1,134 @@
+package notary
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"go.uber.org/multierr"
+)
+
+const (
+	// ArtifactType is the artifact type of Notary v2 signatures
+	ArtifactType = "application/vnd.cncf.notary.signature"
+	// MediaTypeJWS is the media type of JWS signature envelopes
+	MediaTypeJWS = "application/jose+json"
+	// MediaTypeCOSE is the media type of COSE signature envelopes
+	MediaTypeCOSE = "application/cose"
+)
+
+// VerifySignature verifies that the image has at least one Notary v2 signature issued by a
+// certificate chaining up to the trust store built from the options certificates (Cert and CertChain).
+// Signatures are discovered as OCI referrers of the image digest.
+func VerifySignature(rclient registryclient.Client, opts cosign.Options) (*cosign.Response, error) {
+	ctx := context.TODO()
+	roots, err := loadTrustStore(opts.Cert, opts.CertChain)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := name.ParseReference(opts.ImageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image %s", opts.ImageRef)
+	}
+	desc, err := rclient.FetchImageDescriptor(ctx, opts.ImageRef)
+	if err != nil {
+		return nil, err
+	}
+	target := desc.Descriptor
+	repo := ref.Context()
+	if opts.Repository != "" {
+		repo, err = name.NewRepository(opts.Repository)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse repository %s", opts.Repository)
+		}
+	}
+	var referrers []gcrv1.Descriptor
+	tracing.DoInSpan(ctx, "notary", "fetch_referrers", func(ctx context.Context) {
+		referrers, err = rclient.FetchReferrers(ctx, repo.Digest(target.Digest.String()).String(), ArtifactType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(referrers) == 0 {
+		return nil, fmt.Errorf("no notary signatures found for image %s", opts.ImageRef)
+	}
+	var errs []error
+	for _, referrer := range referrers {
+		sigRef := repo.Digest(referrer.Digest.String()).String()
+		if err := verifySignatureManifest(ctx, rclient, sigRef, roots, target, opts.Annotations); err != nil {
+			errs = append(errs, errors.Wrapf(err, "signature %s", referrer.Digest.String()))
+			continue
+		}
+		logger.V(3).Info("verified image", "image", opts.ImageRef, "signature", sigRef)
+		return &cosign.Response{Digest: target.Digest.String()}, nil
+	}
+	err = multierr.Combine(errs...)
+	logger.Info("image verification failed", "error", err.Error())
+	return nil, errors.Wrap(err, "notary signature verification failed")
+}
+
+func verifySignatureManifest(ctx context.Context, rclient registryclient.Client, sigRef string, roots *x509.CertPool, target gcrv1.Descriptor, annotations map[string]string) error {
+	desc, err := rclient.FetchImageDescriptor(ctx, sigRef)
+	if err != nil {
+		return err
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return errors.Wrap(err, "failed to load signature manifest")
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return errors.Wrap(err, "failed to load signature manifest")
+	}
+	if len(manifest.Layers) != 1 {
+		return fmt.Errorf("signature manifest must have exactly one layer, found %d", len(manifest.Layers))
+	}
+	envelopeDesc := manifest.Layers[0]
+	if string(envelopeDesc.MediaType) != MediaTypeJWS {
+		return fmt.Errorf("unsupported signature envelope media type %s", envelopeDesc.MediaType)
+	}
+	layer, err := img.LayerByDigest(envelopeDesc.Digest)
+	if err != nil {
+		return errors.Wrap(err, "failed to load signature envelope")
+	}
+	reader, err := layer.Compressed()
+	if err != nil {
+		return errors.Wrap(err, "failed to load signature envelope")
+	}
+	defer reader.Close()
+	envelope, err := io.ReadAll(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read signature envelope")
+	}
+	return verifyJWSEnvelope(envelope, roots, target, annotations)
+}
+
+func loadTrustStore(certs ...string) (*x509.CertPool, error) {
+	roots := x509.NewCertPool()
+	count := 0
+	for _, pem := range certs {
+		if pem == "" {
+			continue
+		}
+		parsed, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(pem))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal certificate from PEM format")
+		}
+		for _, cert := range parsed {
+			roots.AddCert(cert)
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, errors.New("no certificates found in the notary trust store")
+	}
+	return roots, nil
+}
@@ -0,0 +1,343 @@
+package notary
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"gotest.tools/assert"
+)
+
+type signer struct {
+	key   crypto.Signer
+	alg   string
+	certs []*x509.Certificate
+}
+
+func newCertificate(t *testing.T, key crypto.Signer, template *x509.Certificate, parent *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+	return cert
+}
+
+func certTemplate(cn string, isCA bool) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+	}
+	return template
+}
+
+// newChainSigner returns an ECDSA signer with a certificate issued by a new root CA, and the root CA
+func newChainSigner(t *testing.T) (*signer, *x509.Certificate) {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	root := newCertificate(t, rootKey, certTemplate("root", true), nil, nil)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	leaf := newCertificate(t, key, certTemplate("leaf", false), root, rootKey)
+	return &signer{key: key, alg: "ES256", certs: []*x509.Certificate{leaf, root}}, root
+}
+
+// newSelfSignedSigner returns an RSA signer with a self signed certificate
+func newSelfSignedSigner(t *testing.T) *signer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	cert := newCertificate(t, key, certTemplate("self", false), nil, nil)
+	return &signer{key: key, alg: "PS256", certs: []*x509.Certificate{cert}}
+}
+
+func toPEM(certs ...*x509.Certificate) string {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.String()
+}
+
+func (s *signer) sign(t *testing.T, target gcrv1.Descriptor, headers map[string]interface{}) []byte {
+	protected := map[string]interface{}{
+		"alg":               s.alg,
+		"cty":               mediaTypePayload,
+		"crit":              []string{headerSigningScheme},
+		headerSigningScheme: signingSchemeX509,
+		headerSigningTime:   time.Now().Format(time.RFC3339),
+	}
+	for k, v := range headers {
+		protected[k] = v
+	}
+	protectedJSON, err := json.Marshal(protected)
+	assert.NilError(t, err)
+	payloadJSON, err := json.Marshal(payload{TargetArtifact: target})
+	assert.NilError(t, err)
+	signingInput := base64.RawURLEncoding.EncodeToString(protectedJSON) + "." + base64.RawURLEncoding.EncodeToString(payloadJSON)
+	hash := crypto.SHA256.New()
+	hash.Write([]byte(signingInput))
+	digest := hash.Sum(nil)
+	var signature []byte
+	switch key := s.key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		assert.NilError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		assert.NilError(t, err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	var x5c [][]byte
+	for _, cert := range s.certs {
+		x5c = append(x5c, cert.Raw)
+	}
+	parts := strings.Split(signingInput, ".")
+	envelope := map[string]interface{}{
+		"protected": parts[0],
+		"payload":   parts[1],
+		"header":    map[string]interface{}{"x5c": x5c},
+		"signature": base64.RawURLEncoding.EncodeToString(signature),
+	}
+	data, err := json.Marshal(envelope)
+	assert.NilError(t, err)
+	return data
+}
+
+type testRegistry struct {
+	t      *testing.T
+	server *httptest.Server
+	host   string
+}
+
+// referrersAPI serves the OCI referrers API from the referrers tag schema
+func referrersAPI(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet || !strings.Contains(req.URL.Path, "/referrers/") {
+			next.ServeHTTP(w, req)
+			return
+		}
+		parts := strings.SplitN(req.URL.Path, "/referrers/", 2)
+		manifestReq := req.Clone(req.Context())
+		manifestReq.URL.Path = parts[0] + "/manifests/" + strings.Replace(parts[1], ":", "-", 1)
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, manifestReq)
+		w.Header().Set("Content-Type", string(types.OCIImageIndex))
+		if rec.Code == http.StatusNotFound {
+			_, _ = w.Write([]byte(`{"schemaVersion":2,"manifests":[]}`))
+			return
+		}
+		_, _ = w.Write(rec.Body.Bytes())
+	})
+}
+
+func newTestRegistry(t *testing.T, withReferrersAPI bool) *testRegistry {
+	var handler http.Handler = registry.New()
+	if withReferrersAPI {
+		handler = referrersAPI(handler)
+	}
+	server := httptest.NewServer(handler)
+	u, err := url.Parse(server.URL)
+	assert.NilError(t, err)
+	return &testRegistry{t: t, server: server, host: u.Host}
+}
+
+// pushImage pushes a random image and returns its reference by tag and its descriptor
+func (r *testRegistry) pushImage(repo string) (string, gcrv1.Descriptor) {
+	img, err := random.Image(256, 1)
+	assert.NilError(r.t, err)
+	ref := fmt.Sprintf("%s/%s:latest", r.host, repo)
+	tag, err := name.NewTag(ref)
+	assert.NilError(r.t, err)
+	assert.NilError(r.t, remote.Write(tag, img))
+	desc, err := remote.Get(tag)
+	assert.NilError(r.t, err)
+	return ref, desc.Descriptor
+}
+
+// pushSignature pushes a signature envelope and registers it as a referrer of the subject using the referrers tag schema
+func (r *testRegistry) pushSignature(repo string, subject gcrv1.Hash, envelope []byte) {
+	sig := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	sig = mutate.ConfigMediaType(sig, types.MediaType(ArtifactType))
+	sig, err := mutate.AppendLayers(sig, static.NewLayer(envelope, MediaTypeJWS))
+	assert.NilError(r.t, err)
+	digest, err := sig.Digest()
+	assert.NilError(r.t, err)
+	ref, err := name.NewDigest(fmt.Sprintf("%s/%s@%s", r.host, repo, digest))
+	assert.NilError(r.t, err)
+	assert.NilError(r.t, remote.Write(ref, sig))
+	size, err := sig.Size()
+	assert.NilError(r.t, err)
+	tag := strings.Replace(subject.String(), ":", "-", 1)
+	index := map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     types.OCIImageIndex,
+	}
+	var manifests []interface{}
+	if existing, err := remote.Get(ref.Context().Tag(tag)); err == nil {
+		assert.NilError(r.t, json.Unmarshal(existing.Manifest, &index))
+		manifests = index["manifests"].([]interface{})
+	}
+	index["manifests"] = append(manifests, map[string]interface{}{
+		"mediaType":    types.OCIManifestSchema1,
+		"digest":       digest,
+		"size":         size,
+		"artifactType": ArtifactType,
+	})
+	data, err := json.Marshal(index)
+	assert.NilError(r.t, err)
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/v2/%s/manifests/%s", r.server.URL, repo, tag), bytes.NewReader(data))
+	assert.NilError(r.t, err)
+	req.Header.Set("Content-Type", string(types.OCIImageIndex))
+	resp, err := http.DefaultClient.Do(req)
+	assert.NilError(r.t, err)
+	resp.Body.Close()
+	assert.Equal(r.t, resp.StatusCode, http.StatusCreated)
+}
+
+func TestVerifySignature(t *testing.T) {
+	testVerifySignature(t, false)
+}
+
+func TestVerifySignature_ReferrersAPI(t *testing.T) {
+	testVerifySignature(t, true)
+}
+
+func testVerifySignature(t *testing.T, withReferrersAPI bool) {
+	reg := newTestRegistry(t, withReferrersAPI)
+	defer reg.server.Close()
+	rclient := registryclient.NewOrDie()
+
+	chainSigner, root := newChainSigner(t)
+	selfSigner := newSelfSignedSigner(t)
+	otherSigner, otherRoot := newChainSigner(t)
+
+	signedImage, signedDesc := reg.pushImage("signed")
+	reg.pushSignature("signed", signedDesc.Digest, chainSigner.sign(t, signedDesc, nil))
+
+	selfSignedImage, selfSignedDesc := reg.pushImage("self-signed")
+	reg.pushSignature("self-signed", selfSignedDesc.Digest, selfSigner.sign(t, selfSignedDesc, nil))
+
+	unsignedImage, _ := reg.pushImage("unsigned")
+
+	multiImage, multiDesc := reg.pushImage("multi")
+	reg.pushSignature("multi", multiDesc.Digest, otherSigner.sign(t, multiDesc, nil))
+	reg.pushSignature("multi", multiDesc.Digest, chainSigner.sign(t, multiDesc, nil))
+
+	mismatchImage, mismatchDesc := reg.pushImage("mismatch")
+	_, otherDesc := reg.pushImage("other")
+	reg.pushSignature("mismatch", mismatchDesc.Digest, chainSigner.sign(t, otherDesc, nil))
+
+	expiredImage, expiredDesc := reg.pushImage("expired")
+	reg.pushSignature("expired", expiredDesc.Digest, chainSigner.sign(t, expiredDesc, map[string]interface{}{
+		headerExpiry: time.Now().Add(-time.Minute).Format(time.RFC3339),
+		"crit":       []string{headerSigningScheme, headerExpiry},
+	}))
+
+	annotatedImage, annotatedDesc := reg.pushImage("annotated")
+	annotated := annotatedDesc
+	annotated.Annotations = map[string]string{"env": "prod"}
+	reg.pushSignature("annotated", annotatedDesc.Digest, chainSigner.sign(t, annotated, nil))
+
+	tests := []struct {
+		name    string
+		opts    cosign.Options
+		digest  string
+		wantErr string
+	}{{
+		name:   "signed with certificate chain",
+		opts:   cosign.Options{ImageRef: signedImage, Cert: toPEM(root)},
+		digest: signedDesc.Digest.String(),
+	}, {
+		name:   "signed with certificate chain, trust store in cert chain",
+		opts:   cosign.Options{ImageRef: signedImage, CertChain: toPEM(otherRoot, root)},
+		digest: signedDesc.Digest.String(),
+	}, {
+		name:   "signed with self signed certificate",
+		opts:   cosign.Options{ImageRef: selfSignedImage, Cert: toPEM(selfSigner.certs[0])},
+		digest: selfSignedDesc.Digest.String(),
+	}, {
+		name:    "untrusted certificate",
+		opts:    cosign.Options{ImageRef: signedImage, Cert: toPEM(otherRoot)},
+		wantErr: "certificate chain is not trusted",
+	}, {
+		name:    "unsigned image",
+		opts:    cosign.Options{ImageRef: unsignedImage, Cert: toPEM(root)},
+		wantErr: "no notary signatures found",
+	}, {
+		name:   "one of multiple signatures is trusted",
+		opts:   cosign.Options{ImageRef: multiImage, Cert: toPEM(root)},
+		digest: multiDesc.Digest.String(),
+	}, {
+		name:    "signature of another image",
+		opts:    cosign.Options{ImageRef: mismatchImage, Cert: toPEM(root)},
+		wantErr: "does not match image digest",
+	}, {
+		name:    "expired signature",
+		opts:    cosign.Options{ImageRef: expiredImage, Cert: toPEM(root)},
+		wantErr: "signature expired",
+	}, {
+		name:   "annotations match",
+		opts:   cosign.Options{ImageRef: annotatedImage, Cert: toPEM(root), Annotations: map[string]string{"env": "prod"}},
+		digest: annotatedDesc.Digest.String(),
+	}, {
+		name:    "annotations mismatch",
+		opts:    cosign.Options{ImageRef: annotatedImage, Cert: toPEM(root), Annotations: map[string]string{"env": "dev"}},
+		wantErr: "annotations mismatch",
+	}, {
+		name:    "empty trust store",
+		opts:    cosign.Options{ImageRef: signedImage},
+		wantErr: "no certificates found",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := VerifySignature(rclient, test.opts)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, resp.Digest, test.digest)
+		})
+	}
+}
@@ -597,7 +597,7 @@ func getAllowedVariables(background bool) *regexp.Regexp {
 
 func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.MockContext) {
 	for _, contextEntry := range entries {
-		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil {
+		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil || contextEntry.GlobalReference != nil {
 			ctx.AddVariable(contextEntry.Name + "*")
 		}
 
@@ -969,16 +969,18 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 		}
 
 		var err error
-		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.GlobalReference == nil {
 			err = validateConfigMap(entry)
-		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.GlobalReference == nil {
 			err = validateAPICall(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil && entry.GlobalReference == nil {
 			err = validateImageRegistry(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil && entry.GlobalReference == nil {
 			err = validateVariable(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.GlobalReference != nil {
+			err = validateGlobalReference(entry)
 		} else {
-			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
+			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable or globalReference is required for context entries")
 		}
 
 		if err != nil {
@@ -1007,6 +1009,24 @@ func validateVariable(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
+func validateGlobalReference(entry kyvernov1.ContextEntry) error {
+	if entry.GlobalReference.Name == "" {
+		return fmt.Errorf("a name is required for globalReference context entry")
+	}
+
+	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
+	// will be inserted by the variable
+	// Skip validation if a variable is detected
+	jmesPath := variables.ReplaceAllVars(entry.GlobalReference.JMESPath, func(s string) string { return "kyvernojmespathvariable" })
+	if !strings.Contains(jmesPath, "kyvernojmespathvariable") && entry.GlobalReference.JMESPath != "" {
+		if _, err := jmespath.NewParser().Parse(entry.GlobalReference.JMESPath); err != nil {
+			return fmt.Errorf("failed to parse JMESPath %s: %v", entry.GlobalReference.JMESPath, err)
+		}
+	}
+
+	return nil
+}
+
 func validateConfigMap(entry kyvernov1.ContextEntry) error {
 	if entry.ConfigMap.Name == "" {
 		return fmt.Errorf("a name is required for configMap context entry")
@@ -1020,6 +1040,28 @@ func validateConfigMap(entry kyvernov1.ContextEntry) error {
 }
 
 func validateAPICall(entry kyvernov1.ContextEntry) error {
+	if entry.APICall.URLPath != "" && entry.APICall.Service != nil {
+		return fmt.Errorf("a single one of urlPath or service is allowed for apiCall context entry")
+	}
+
+	if entry.APICall.URLPath == "" && entry.APICall.Service == nil {
+		return fmt.Errorf("one of urlPath or service is required for apiCall context entry")
+	}
+
+	if service := entry.APICall.Service; service != nil {
+		if service.URL == "" {
+			return fmt.Errorf("a url is required for apiCall service context entry")
+		}
+
+		if service.Method != "" && service.Method != kyvernov1.MethodGet && service.Method != kyvernov1.MethodPost {
+			return fmt.Errorf("unsupported method %s for apiCall service context entry", service.Method)
+		}
+
+		if len(service.Data) > 0 && service.Method != kyvernov1.MethodPost {
+			return fmt.Errorf("data is only allowed with the POST method for apiCall service context entry")
+		}
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
 	// will be inserted by the variable
 	// Skip validation if a variable is detected
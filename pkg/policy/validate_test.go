@@ -1024,6 +1024,61 @@ func Test_Validate_ApiCall(t *testing.T) {
 			},
 			expectedResult: nil,
 		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					Service: &kyverno.ServiceCall{
+						URL:    "https://cmdb.tools:8443/api/v1/owners",
+						Method: kyverno.MethodPost,
+						Data: []kyverno.RequestData{{
+							Key:   "namespace",
+							Value: &apiextv1.JSON{Raw: []byte(`"{{request.namespace}}"`)},
+						}},
+					},
+					JMESPath: "owner",
+				},
+			},
+			expectedResult: nil,
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					URLPath: "/api/v1/namespaces",
+					Service: &kyverno.ServiceCall{
+						URL: "https://cmdb.tools:8443/api/v1/owners",
+					},
+				},
+			},
+			expectedResult: "a single one of urlPath or service is allowed for apiCall context entry",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{},
+			},
+			expectedResult: "one of urlPath or service is required for apiCall context entry",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					Service: &kyverno.ServiceCall{},
+				},
+			},
+			expectedResult: "a url is required for apiCall service context entry",
+		},
+		{
+			resource: kyverno.ContextEntry{
+				APICall: &kyverno.APICall{
+					Service: &kyverno.ServiceCall{
+						URL: "https://cmdb.tools:8443/api/v1/owners",
+						Data: []kyverno.RequestData{{
+							Key:   "namespace",
+							Value: &apiextv1.JSON{Raw: []byte(`"default"`)},
+						}},
+					},
+				},
+			},
+			expectedResult: "data is only allowed with the POST method for apiCall service context entry",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -1037,6 +1092,63 @@ func Test_Validate_ApiCall(t *testing.T) {
 	}
 }
 
+func Test_Validate_Rule_Context_GlobalReference(t *testing.T) {
+	testCases := []struct {
+		entry          kyverno.ContextEntry
+		expectedResult interface{}
+	}{
+		{
+			entry: kyverno.ContextEntry{
+				Name: "deployments",
+				GlobalReference: &kyverno.GlobalContextEntryReference{
+					Name:     "deployments",
+					JMESPath: "[?metadata.namespace == '{{request.namespace}}'] | length(@)",
+				},
+			},
+			expectedResult: nil,
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name:            "deployments",
+				GlobalReference: &kyverno.GlobalContextEntryReference{},
+			},
+			expectedResult: "a name is required for globalReference context entry",
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name: "deployments",
+				GlobalReference: &kyverno.GlobalContextEntryReference{
+					Name:     "deployments",
+					JMESPath: "items[",
+				},
+			},
+			expectedResult: "failed to parse JMESPath items[: SyntaxError: Expected tStar, received: tEOF",
+		},
+		{
+			entry: kyverno.ContextEntry{
+				Name: "deployments",
+				GlobalReference: &kyverno.GlobalContextEntryReference{
+					Name: "deployments",
+				},
+				Variable: &kyverno.Variable{
+					JMESPath: "request.namespace",
+				},
+			},
+			expectedResult: "exactly one of configMap or apiCall or imageRegistry or variable or globalReference is required for context entries",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := validateRuleContext(kyverno.Rule{Context: []kyverno.ContextEntry{testCase.entry}})
+
+		if err == nil {
+			assert.Equal(t, err, testCase.expectedResult)
+		} else {
+			assert.Equal(t, err.Error(), testCase.expectedResult)
+		}
+	}
+}
+
 func Test_Wildcards_Kind(t *testing.T) {
 	rawPolicy := []byte(`
 	{
@@ -12,6 +12,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/github"
 	"github.com/google/go-containerregistry/pkg/name"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/google"
 	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/sigstore/cosign/pkg/oci/remote"
@@ -38,6 +39,13 @@ type Client interface {
 	// and provides access to metadata about remote artifact.
 	FetchImageDescriptor(context.Context, string) (*gcrremote.Descriptor, error)
 
+	// FetchReferrers lists the descriptors of artifacts with the given
+	// artifact type referring to the given image digest reference.
+	FetchReferrers(context.Context, string, string) ([]gcrv1.Descriptor, error)
+
+	// ListTags lists the tags of the given repository.
+	ListTags(context.Context, string) ([]string, error)
+
 	// BuildRemoteOption builds remote.Option based on client.
 	BuildRemoteOption() remote.Option
 }
@@ -143,6 +151,22 @@ func (c *client) FetchImageDescriptor(ctx context.Context, imageRef string) (*gc
 	return desc, nil
 }
 
+// ListTags lists the tags of the given repository.
+func (c *client) ListTags(ctx context.Context, repository string) ([]string, error) {
+	if err := c.refreshKeychainPullSecrets(ctx); err != nil {
+		return nil, fmt.Errorf("failed to refresh image pull secrets, error: %v", err)
+	}
+	repo, err := name.NewRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse repository: %s, error: %v", repository, err)
+	}
+	tags, err := gcrremote.List(repo, gcrremote.WithAuthFromKeychain(c.keychain), gcrremote.WithTransport(c.transport), gcrremote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of repository: %s, error: %v", repository, err)
+	}
+	return tags, nil
+}
+
 func (c *client) getKeychain() authn.Keychain {
 	return c.keychain
 }
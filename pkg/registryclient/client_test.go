@@ -1,10 +1,16 @@
 package registryclient
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"gotest.tools/assert"
 )
@@ -35,3 +41,25 @@ func TestInitClientWithInsecureRegistryOption(t *testing.T) {
 	assert.Assert(t, expInsecureSkipVerify == gotInsecureSkipVerify)
 	assert.Assert(t, c.getKeychain() != nil)
 }
+
+func TestListTags(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	repository := strings.TrimPrefix(server.URL, "http://") + "/charts/podinfo"
+	img, err := random.Image(256, 1)
+	assert.NilError(t, err)
+	for _, tag := range []string{"6.0.0", "6.2.0"} {
+		ref, err := name.NewTag(repository + ":" + tag)
+		assert.NilError(t, err)
+		assert.NilError(t, remote.Write(ref, img))
+	}
+
+	c, err := New()
+	assert.NilError(t, err)
+	tags, err := c.ListTags(context.TODO(), repository)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tags, []string{"6.0.0", "6.2.0"})
+
+	_, err = c.ListTags(context.TODO(), "Invalid Repository")
+	assert.ErrorContains(t, err, "failed to parse repository")
+}
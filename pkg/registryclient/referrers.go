@@ -0,0 +1,118 @@
+package registryclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// referrerDescriptor is an OCI descriptor carrying the artifact type of the referrer
+type referrerDescriptor struct {
+	gcrv1.Descriptor
+	ArtifactType string `json:"artifactType,omitempty"`
+}
+
+// referrersIndex is the image index returned by the referrers API and stored in the referrers tag schema
+type referrersIndex struct {
+	Manifests []referrerDescriptor `json:"manifests"`
+}
+
+// FetchReferrers lists the descriptors of artifacts with the given artifact type referring to the image digest.
+// It uses the OCI referrers API and falls back to the referrers tag schema when the registry doesn't support it.
+func (c *client) FetchReferrers(ctx context.Context, imageRef string, artifactType string) ([]gcrv1.Descriptor, error) {
+	if err := c.refreshKeychainPullSecrets(ctx); err != nil {
+		return nil, fmt.Errorf("failed to refresh image pull secrets, error: %v", err)
+	}
+	ref, err := name.NewDigest(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image digest reference: %s, error: %v", imageRef, err)
+	}
+	index, found, err := c.fetchReferrersFromAPI(ctx, ref, artifactType)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		index, err = c.fetchReferrersFromTag(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var descriptors []gcrv1.Descriptor
+	for _, m := range index.Manifests {
+		if m.ArtifactType == artifactType {
+			descriptors = append(descriptors, m.Descriptor)
+		}
+	}
+	return descriptors, nil
+}
+
+func (c *client) fetchReferrersFromAPI(ctx context.Context, ref name.Digest, artifactType string) (*referrersIndex, bool, error) {
+	repo := ref.Context()
+	auth, err := c.keychain.Resolve(repo)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve registry credentials: %v", err)
+	}
+	rt, err := transport.NewWithContext(ctx, repo.Registry, auth, c.transport, []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create registry transport: %v", err)
+	}
+	u := url.URL{
+		Scheme:   repo.Registry.Scheme(),
+		Host:     repo.RegistryStr(),
+		Path:     fmt.Sprintf("/v2/%s/referrers/%s", repo.RepositoryStr(), ref.DigestStr()),
+		RawQuery: url.Values{"artifactType": []string{artifactType}}.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", string(types.OCIImageIndex))
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch referrers of %s, error: %v", ref.String(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err := transport.CheckError(resp, http.StatusOK); err != nil {
+		return nil, false, fmt.Errorf("failed to fetch referrers of %s, error: %v", ref.String(), err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	var index referrersIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, false, fmt.Errorf("failed to decode referrers of %s, error: %v", ref.String(), err)
+	}
+	return &index, true, nil
+}
+
+func (c *client) fetchReferrersFromTag(ctx context.Context, ref name.Digest) (*referrersIndex, error) {
+	tag := ref.Context().Tag(strings.Replace(ref.DigestStr(), ":", "-", 1))
+	desc, err := gcrremote.Get(tag, gcrremote.WithAuthFromKeychain(c.keychain), gcrremote.WithTransport(c.transport), gcrremote.WithContext(ctx))
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return &referrersIndex{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch referrers tag %s, error: %v", tag.String(), err)
+	}
+	var index referrersIndex
+	if err := json.Unmarshal(desc.Manifest, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode referrers tag %s, error: %v", tag.String(), err)
+	}
+	return &index, nil
+}
@@ -0,0 +1,24 @@
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+func UnmarshalPolicyException(kind string, raw []byte) (*kyvernov2alpha1.PolicyException, error) {
+	if kind == "PolicyException" {
+		var exception *kyvernov2alpha1.PolicyException
+		if err := json.Unmarshal(raw, &exception); err != nil {
+			return nil, err
+		}
+		return exception, nil
+	}
+	return nil, fmt.Errorf("admission request does not contain a policyexception")
+}
+
+func GetPolicyException(request *admissionv1.AdmissionRequest) (*kyvernov2alpha1.PolicyException, error) {
+	return UnmarshalPolicyException(request.Kind.Kind, request.Object.Raw)
+}
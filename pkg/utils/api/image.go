@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -14,6 +15,14 @@ type ImageInfo struct {
 	imageutils.ImageInfo
 	// Pointer is the path to the image object in the resource
 	Pointer string `json:"-"`
+	// DigestPointer is the path to the digest field of the artifact in the resource,
+	// when empty the digest is appended to the image at Pointer
+	DigestPointer string `json:"-"`
+	// Semver is the semver range of the artifact, resolved to the highest matching tag of the repository
+	Semver string `json:"-"`
+	// Derived is true when the reference is not the value at Pointer but is built from several fields of the resource,
+	// the value at Pointer must then never be replaced with the reference
+	Derived bool `json:"-"`
 }
 
 var (
@@ -33,35 +42,39 @@ var (
 )
 
 type imageExtractor struct {
-	Fields []string
-	Key    string
-	Value  string
-	Name   string
+	Fields   []string
+	Key      string
+	Value    string
+	Name     string
+	Artifact string
+	Tag      string
+	Semver   string
+	Digest   string
 }
 
 func (i *imageExtractor) ExtractFromResource(resource interface{}) (map[string]ImageInfo, error) {
 	imageInfo := map[string]ImageInfo{}
-	if err := extract(resource, []string{}, i.Key, i.Value, i.Fields, &imageInfo); err != nil {
+	if err := i.extract(resource, []string{}, i.Fields, &imageInfo); err != nil {
 		return nil, err
 	}
 	return imageInfo, nil
 }
 
-func extract(obj interface{}, path []string, keyPath, valuePath string, fields []string, imageInfos *map[string]ImageInfo) error {
+func (i *imageExtractor) extract(obj interface{}, path []string, fields []string, imageInfos *map[string]ImageInfo) error {
 	if obj == nil {
 		return nil
 	}
 	if len(fields) > 0 && fields[0] == "*" {
 		switch typedObj := obj.(type) {
 		case []interface{}:
-			for i, v := range typedObj {
-				if err := extract(v, append(path, strconv.Itoa(i)), keyPath, valuePath, fields[1:], imageInfos); err != nil {
+			for j, v := range typedObj {
+				if err := i.extract(v, append(path, strconv.Itoa(j)), fields[1:], imageInfos); err != nil {
 					return err
 				}
 			}
 		case map[string]interface{}:
-			for i, v := range typedObj {
-				if err := extract(v, append(path, i), keyPath, valuePath, fields[1:], imageInfos); err != nil {
+			for k, v := range typedObj {
+				if err := i.extract(v, append(path, k), fields[1:], imageInfos); err != nil {
 					return err
 				}
 			}
@@ -77,28 +90,67 @@ func extract(obj interface{}, path []string, keyPath, valuePath string, fields [
 	}
 
 	if len(fields) == 0 {
-		pointer := fmt.Sprintf("/%s/%s", strings.Join(path, "/"), valuePath)
+		pointer := fmt.Sprintf("/%s/%s", strings.Join(path, "/"), i.Value)
 		key := pointer
-		if keyPath != "" {
-			key, ok = output[keyPath].(string)
+		if i.Key != "" {
+			key, ok = output[i.Key].(string)
 			if !ok {
 				return fmt.Errorf("invalid key")
 			}
 		}
-		value, ok := output[valuePath].(string)
+		value, ok := output[i.Value].(string)
 		if !ok {
 			return fmt.Errorf("invalid value")
 		}
-		if imageInfo, err := imageutils.GetImageInfo(value); err != nil {
-			return fmt.Errorf("invalid image %s", value)
+		image, semverRange, err := i.artifactReference(output, value)
+		if err != nil {
+			return err
+		}
+		if imageInfo, err := imageutils.GetImageInfo(image); err != nil {
+			return fmt.Errorf("invalid image %s", image)
 		} else {
-			(*imageInfos)[key] = ImageInfo{*imageInfo, pointer}
+			info := ImageInfo{ImageInfo: *imageInfo, Pointer: pointer, Semver: semverRange, Derived: image != value || semverRange != ""}
+			if i.Digest != "" {
+				info.DigestPointer = fmt.Sprintf("/%s/%s", strings.Join(path, "/"), i.Digest)
+			}
+			(*imageInfos)[key] = info
 		}
 		return nil
 	}
 
 	currentPath := fields[0]
-	return extract(output[currentPath], append(path, currentPath), keyPath, valuePath, fields[1:], imageInfos)
+	return i.extract(output[currentPath], append(path, currentPath), fields[1:], imageInfos)
+}
+
+// artifactReference builds the reference of an OCI artifact from the value and the artifact, digest, semver
+// and tag fields of the object, and returns the semver range to resolve when there is no digest
+func (i *imageExtractor) artifactReference(object map[string]interface{}, value string) (string, string, error) {
+	reference := strings.TrimPrefix(value, "oci://")
+	if artifact := nestedString(object, i.Artifact); artifact != "" {
+		reference = strings.TrimSuffix(reference, "/") + "/" + artifact
+	}
+	if digest := nestedString(object, i.Digest); digest != "" {
+		return reference + "@" + digest, "", nil
+	}
+	if semverRange := nestedString(object, i.Semver); semverRange != "" {
+		if _, err := semver.NewConstraint(semverRange); err != nil {
+			return "", "", fmt.Errorf("invalid semver range %s", semverRange)
+		}
+		return reference, semverRange, nil
+	}
+	if tag := nestedString(object, i.Tag); tag != "" {
+		return reference + ":" + tag, "", nil
+	}
+	return reference, "", nil
+}
+
+// nestedString returns the string at the slash-separated field of the object
+func nestedString(object map[string]interface{}, field string) string {
+	if field == "" {
+		return ""
+	}
+	value, _, _ := unstructured.NestedString(object, strings.Split(field, "/")...)
+	return value
 }
 
 func BuildStandardExtractors(tags ...string) []imageExtractor {
@@ -138,10 +190,14 @@ func lookupImageExtractor(kind string, configs kyvernov1.ImageExtractorConfigs)
 					fields = fields[:len(fields)-1]
 				}
 				extractors = append(extractors, imageExtractor{
-					Fields: fields,
-					Key:    c.Key,
-					Name:   name,
-					Value:  value,
+					Fields:   fields,
+					Key:      c.Key,
+					Name:     name,
+					Value:    value,
+					Artifact: c.Artifact,
+					Tag:      c.Tag,
+					Semver:   c.Semver,
+					Digest:   c.Digest,
 				})
 			}
 			return extractors
@@ -20,35 +20,35 @@ func Test_extractImageInfo(t *testing.T) {
 			images: map[string]map[string]ImageInfo{
 				"initContainers": {
 					"init": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "index.docker.io",
 							Name:     "busybox",
 							Path:     "busybox",
 							Tag:      "v1.2.3",
 						},
-						"/spec/initContainers/0/image",
+						Pointer: "/spec/initContainers/0/image",
 					},
 				},
 				"containers": {
 					"nginx": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "docker.io",
 							Name:     "nginx",
 							Path:     "nginx",
 							Tag:      "latest",
 						},
-						"/spec/containers/0/image",
+						Pointer: "/spec/containers/0/image",
 					},
 				},
 				"ephemeralContainers": {
 					"ephemeral": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "docker.io",
 							Name:     "nginx",
 							Path:     "test/nginx",
 							Tag:      "latest",
 						},
-						"/spec/ephemeralContainers/0/image",
+						Pointer: "/spec/ephemeralContainers/0/image",
 					},
 				},
 			},
@@ -58,13 +58,13 @@ func Test_extractImageInfo(t *testing.T) {
 			images: map[string]map[string]ImageInfo{
 				"containers": {
 					"nginx": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "docker.io",
 							Name:     "nginx",
 							Path:     "test/nginx",
 							Tag:      "latest",
 						},
-						"/spec/containers/0/image",
+						Pointer: "/spec/containers/0/image",
 					},
 				},
 			},
@@ -74,37 +74,37 @@ func Test_extractImageInfo(t *testing.T) {
 			images: map[string]map[string]ImageInfo{
 				"initContainers": {
 					"init": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "fictional.registry.example:10443",
 							Name:     "imagename",
 							Path:     "imagename",
 							Tag:      "tag",
 							Digest:   "sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
 						},
-						"/spec/template/spec/initContainers/0/image",
+						Pointer: "/spec/template/spec/initContainers/0/image",
 					},
 				},
 				"containers": {
 					"myapp": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "fictional.registry.example:10443",
 							Name:     "imagename",
 							Path:     "imagename",
 							Tag:      "latest",
 						},
-						"/spec/template/spec/containers/0/image",
+						Pointer: "/spec/template/spec/containers/0/image",
 					},
 				},
 				"ephemeralContainers": {
 					"ephemeral": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "fictional.registry.example:10443",
 							Name:     "imagename",
 							Path:     "imagename",
 							Tag:      "tag",
 							Digest:   "sha256:eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
 						},
-						"/spec/template/spec/ephemeralContainers/0/image",
+						Pointer: "/spec/template/spec/ephemeralContainers/0/image",
 					},
 				},
 			},
@@ -114,13 +114,13 @@ func Test_extractImageInfo(t *testing.T) {
 			images: map[string]map[string]ImageInfo{
 				"containers": {
 					"hello": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "test.example.com",
 							Name:     "my-app",
 							Path:     "test/my-app",
 							Tag:      "v2",
 						},
-						"/spec/jobTemplate/spec/template/spec/containers/0/image",
+						Pointer: "/spec/jobTemplate/spec/template/spec/containers/0/image",
 					},
 				},
 			},
@@ -135,31 +135,31 @@ func Test_extractImageInfo(t *testing.T) {
 			images: map[string]map[string]ImageInfo{
 				"custom": {
 					"/spec/steps/0/image": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "docker.io",
 							Name:     "ubuntu",
 							Path:     "ubuntu",
 							Tag:      "latest",
 						},
-						"/spec/steps/0/image",
+						Pointer: "/spec/steps/0/image",
 					},
 					"/spec/steps/1/image": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "gcr.io",
 							Name:     "build-example",
 							Path:     "example-builders/build-example",
 							Tag:      "latest",
 						},
-						"/spec/steps/1/image",
+						Pointer: "/spec/steps/1/image",
 					},
 					"/spec/steps/2/image": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "gcr.io",
 							Name:     "push-example",
 							Path:     "example-builders/push-example",
 							Tag:      "latest",
 						},
-						"/spec/steps/2/image",
+						Pointer: "/spec/steps/2/image",
 					},
 				},
 			},
@@ -173,22 +173,22 @@ func Test_extractImageInfo(t *testing.T) {
 			images: map[string]map[string]ImageInfo{
 				"steps": {
 					"dockerfile-pushexample": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "gcr.io",
 							Name:     "push-example",
 							Path:     "example-builders/push-example",
 							Tag:      "latest",
 						},
-						"/spec/steps/1/image",
+						Pointer: "/spec/steps/1/image",
 					},
 					"ubuntu-example": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "docker.io",
 							Name:     "ubuntu",
 							Path:     "ubuntu",
 							Tag:      "latest",
 						},
-						"/spec/steps/0/image",
+						Pointer: "/spec/steps/0/image",
 					},
 				},
 			},
@@ -203,13 +203,82 @@ func Test_extractImageInfo(t *testing.T) {
 			images: map[string]map[string]ImageInfo{
 				"steps": {
 					"echo": {
-						imageutils.ImageInfo{
+						ImageInfo: imageutils.ImageInfo{
 							Registry: "docker.io",
 							Name:     "alpine",
 							Path:     "alpine",
 							Tag:      "latest",
 						},
-						"/spec/steps/0/image",
+						Pointer: "/spec/steps/0/image",
+					},
+				},
+			},
+		},
+		{
+			extractionConfig: kyvernov1.ImageExtractorConfigs{
+				"OCIRepository": []kyvernov1.ImageExtractorConfig{
+					{Name: "artifacts", Path: "/spec", Value: "url", Tag: "ref/tag", Semver: "ref/semver", Digest: "ref/digest"},
+				},
+			},
+			raw: []byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta2","kind":"OCIRepository","metadata":{"name":"podinfo"},"spec":{"url":"oci://ghcr.io/stefanprodan/manifests/podinfo","ref":{"tag":"latest","semver":">=6.1.0 <7.0.0"}}}`),
+			images: map[string]map[string]ImageInfo{
+				"artifacts": {
+					"/spec/url": {
+						ImageInfo: imageutils.ImageInfo{
+							Registry: "ghcr.io",
+							Name:     "podinfo",
+							Path:     "stefanprodan/manifests/podinfo",
+							Tag:      "latest",
+						},
+						Pointer:       "/spec/url",
+						DigestPointer: "/spec/ref/digest",
+						Semver:        ">=6.1.0 <7.0.0",
+						Derived:       true,
+					},
+				},
+			},
+		},
+		{
+			extractionConfig: kyvernov1.ImageExtractorConfigs{
+				"OCIRepository": []kyvernov1.ImageExtractorConfig{
+					{Name: "artifacts", Path: "/spec", Value: "url", Tag: "ref/tag", Semver: "ref/semver", Digest: "ref/digest"},
+				},
+			},
+			raw: []byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta2","kind":"OCIRepository","metadata":{"name":"podinfo"},"spec":{"url":"oci://ghcr.io/stefanprodan/manifests/podinfo","ref":{"semver":"6.x","digest":"sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3"}}}`),
+			images: map[string]map[string]ImageInfo{
+				"artifacts": {
+					"/spec/url": {
+						ImageInfo: imageutils.ImageInfo{
+							Registry: "ghcr.io",
+							Name:     "podinfo",
+							Path:     "stefanprodan/manifests/podinfo",
+							Digest:   "sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3",
+						},
+						Pointer:       "/spec/url",
+						DigestPointer: "/spec/ref/digest",
+						Derived:       true,
+					},
+				},
+			},
+		},
+		{
+			extractionConfig: kyvernov1.ImageExtractorConfigs{
+				"Application": []kyvernov1.ImageExtractorConfig{
+					{Name: "charts", Path: "/spec/source", Value: "repoURL", Artifact: "chart", Tag: "targetRevision"},
+				},
+			},
+			raw: []byte(`{"apiVersion":"argoproj.io/v1alpha1","kind":"Application","metadata":{"name":"argo-cd"},"spec":{"source":{"repoURL":"ghcr.io/argoproj/argo-helm","chart":"argo-cd","targetRevision":"5.16.0"}}}`),
+			images: map[string]map[string]ImageInfo{
+				"charts": {
+					"/spec/source/repoURL": {
+						ImageInfo: imageutils.ImageInfo{
+							Registry: "ghcr.io",
+							Name:     "argo-cd",
+							Path:     "argoproj/argo-helm/argo-cd",
+							Tag:      "5.16.0",
+						},
+						Pointer: "/spec/source/repoURL",
+						Derived: true,
 					},
 				},
 			},
@@ -224,3 +293,24 @@ func Test_extractImageInfo(t *testing.T) {
 		assert.DeepEqual(t, test.images, images)
 	}
 }
+
+func Test_extractImageInfoInvalidSemver(t *testing.T) {
+	resource, err := utils.ConvertToUnstructured([]byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta2","kind":"OCIRepository","metadata":{"name":"podinfo"},"spec":{"url":"oci://ghcr.io/stefanprodan/manifests/podinfo","ref":{"semver":"invalid"}}}`))
+	assert.NilError(t, err)
+	_, err = ExtractImagesFromResource(*resource, kyvernov1.ImageExtractorConfigs{
+		"OCIRepository": []kyvernov1.ImageExtractorConfig{{Path: "/spec", Value: "url", Semver: "ref/semver"}},
+	})
+	assert.Error(t, err, "invalid semver range invalid")
+}
+
+func Test_extractImageInfoFluxSemver(t *testing.T) {
+	for _, semverRange := range []string{"^6.1.0", "~6.1", "6.x", ">= 6.1.0, < 7.0.0", ">=7.0.0-rc.0"} {
+		resource, err := utils.ConvertToUnstructured([]byte(`{"apiVersion":"source.toolkit.fluxcd.io/v1beta2","kind":"OCIRepository","metadata":{"name":"podinfo"},"spec":{"url":"oci://ghcr.io/stefanprodan/manifests/podinfo","ref":{"semver":"` + semverRange + `"}}}`))
+		assert.NilError(t, err)
+		images, err := ExtractImagesFromResource(*resource, kyvernov1.ImageExtractorConfigs{
+			"OCIRepository": []kyvernov1.ImageExtractorConfig{{Name: "artifacts", Path: "/spec", Value: "url", Semver: "ref/semver"}},
+		})
+		assert.NilError(t, err, semverRange)
+		assert.Equal(t, images["artifacts"]["/spec/url"].Semver, semverRange)
+	}
+}
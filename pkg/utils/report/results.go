@@ -13,6 +13,10 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
+// SourceImageVerify is the source of the results of the periodic verification of the images of the
+// running pods, the background scan keeps them when it rebuilds a report
+const SourceImageVerify = "kyverno-image-verify"
+
 func SortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
 	slices.SortFunc(results, func(a policyreportv1alpha2.PolicyReportResult, b policyreportv1alpha2.PolicyReportResult) bool {
 		if a.Policy != b.Policy {
@@ -67,7 +71,8 @@ func toPolicyResult(status response.RuleStatus) policyreportv1alpha2.PolicyResul
 	return ""
 }
 
-func severityFromString(severity string) policyreportv1alpha2.PolicySeverity {
+// SeverityFromString returns the policy report severity matching the given severity annotation value
+func SeverityFromString(severity string) policyreportv1alpha2.PolicySeverity {
 	switch severity {
 	case policyreportv1alpha2.SeverityHigh:
 		return policyreportv1alpha2.SeverityHigh
@@ -95,7 +100,7 @@ func EngineResponseToReportResults(response *response.EngineResponse) []policyre
 				Seconds: time.Now().Unix(),
 			},
 			Category: annotations[kyvernov1.AnnotationPolicyCategory],
-			Severity: severityFromString(annotations[kyvernov1.AnnotationPolicySeverity]),
+			Severity: SeverityFromString(annotations[kyvernov1.AnnotationPolicySeverity]),
 		}
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
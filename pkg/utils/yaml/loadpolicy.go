@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	log "github.com/kyverno/kyverno/pkg/logging"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -73,3 +74,62 @@ func addPolicy(policies []kyvernov1.PolicyInterface, us *unstructured.Unstructur
 	policies = append(policies, policy)
 	return policies, nil
 }
+
+// GetCleanupPolicy extracts cleanup policies from YAML bytes
+func GetCleanupPolicy(bytes []byte) (policies []kyvernov2alpha1.CleanupPolicyInterface, err error) {
+	documents, err := SplitDocuments(bytes)
+	if err != nil {
+		return nil, err
+	}
+	for _, thisPolicyBytes := range documents {
+		policyBytes, err := yaml.ToJSON(thisPolicyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to JSON: %v", err)
+		}
+		us := &unstructured.Unstructured{}
+		if err := json.Unmarshal(policyBytes, us); err != nil {
+			return nil, fmt.Errorf("failed to decode cleanup policy: %v", err)
+		}
+		if us.IsList() {
+			list, err := us.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode cleanup policy list: %v", err)
+			}
+			for i := range list.Items {
+				if policies, err = addCleanupPolicy(policies, &list.Items[i]); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			if policies, err = addCleanupPolicy(policies, us); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return policies, nil
+}
+
+func addCleanupPolicy(policies []kyvernov2alpha1.CleanupPolicyInterface, us *unstructured.Unstructured) ([]kyvernov2alpha1.CleanupPolicyInterface, error) {
+	switch us.GetKind() {
+	case "":
+		log.V(3).Info("skipping file as cleanup policy kind not found")
+		return policies, nil
+	case "ClusterCleanupPolicy":
+		policy := &kyvernov2alpha1.ClusterCleanupPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(us.Object, policy); err != nil {
+			return nil, fmt.Errorf("failed to decode cleanup policy: %v", err)
+		}
+		policy.Namespace = ""
+		return append(policies, policy), nil
+	case "CleanupPolicy":
+		policy := &kyvernov2alpha1.CleanupPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(us.Object, policy); err != nil {
+			return nil, fmt.Errorf("failed to decode cleanup policy: %v", err)
+		}
+		if policy.Namespace == "" {
+			policy.Namespace = "default"
+		}
+		return append(policies, policy), nil
+	}
+	return nil, fmt.Errorf("resource %s/%s is not a CleanupPolicy or a ClusterCleanupPolicy", us.GetKind(), us.GetName())
+}
@@ -315,3 +315,73 @@ items:
 		})
 	}
 }
+
+func TestGetCleanupPolicy(t *testing.T) {
+	type policy struct {
+		kind      string
+		namespace string
+	}
+	tests := []struct {
+		name         string
+		bytes        []byte
+		wantPolicies []policy
+		wantErr      bool
+	}{{
+		name: "cleanup policy and cluster cleanup policy",
+		bytes: []byte(`
+apiVersion: kyverno.io/v2alpha1
+kind: CleanupPolicy
+metadata:
+  name: cleanup-pods
+spec:
+  schedule: "*/5 * * * *"
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+---
+apiVersion: kyverno.io/v2alpha1
+kind: ClusterCleanupPolicy
+metadata:
+  name: cleanup-namespaces
+  namespace: ignored
+spec:
+  schedule: "0 0 * * *"
+  match:
+    any:
+    - resources:
+        kinds:
+        - Namespace
+`),
+		wantPolicies: []policy{
+			{"CleanupPolicy", "default"},
+			{"ClusterCleanupPolicy", ""},
+		},
+	}, {
+		name: "kyverno policy",
+		bytes: []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: generate-policy
+`),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPolicies, err := GetCleanupPolicy(tt.bytes)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				if assert.Equal(t, len(tt.wantPolicies), len(gotPolicies)) {
+					for i := range tt.wantPolicies {
+						assert.Equal(t, tt.wantPolicies[i].kind, gotPolicies[i].GetKind())
+						assert.Equal(t, tt.wantPolicies[i].namespace, gotPolicies[i].GetNamespace())
+					}
+				}
+			}
+		})
+	}
+}
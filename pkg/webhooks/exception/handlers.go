@@ -0,0 +1,32 @@
+package exception
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+type handlers struct{}
+
+func NewHandlers() webhooks.ExceptionHandlers {
+	return &handlers{}
+}
+
+// Validate performs the validation check on policy exception resources
+func (h *handlers) Validate(_ context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, _ time.Time) *admissionv1.AdmissionResponse {
+	polex, err := admissionutils.GetPolicyException(request)
+	if err != nil {
+		logger.Error(err, "failed to unmarshal policy exception from admission request")
+		return admissionutils.Response(request.UID, err)
+	}
+	if errs := polex.Validate(); len(errs) != 0 {
+		err := errs.ToAggregate()
+		logger.Error(err, "policy exception validation errors")
+		return admissionutils.Response(request.UID, err)
+	}
+	return admissionutils.ResponseSuccess(request.UID)
+}
@@ -0,0 +1,47 @@
+package exception
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		raw     string
+		allowed bool
+		message string
+	}{
+		{
+			name:    "valid",
+			raw:     `{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"allow-nginx","namespace":"team-a"},"spec":{"exceptions":[{"policyName":"require-labels","ruleNames":["check-team"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`,
+			allowed: true,
+		},
+		{
+			name:    "invalid",
+			raw:     `{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"allow-nginx","namespace":"team-a"},"spec":{"exceptions":[{"policyName":"require-labels"}]}}`,
+			allowed: false,
+			message: "[spec.match: Required value: must specify at least one match statement, spec.exceptions[0].ruleNames: Required value: must specify at least one rule name]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &admissionv1.AdmissionRequest{
+				Kind:   metav1.GroupVersionKind{Group: "kyverno.io", Version: "v2alpha1", Kind: "PolicyException"},
+				Object: runtime.RawExtension{Raw: []byte(tc.raw)},
+			}
+			response := NewHandlers().Validate(context.TODO(), logging.GlobalLogger(), request, time.Now())
+			assert.Equal(t, response.Allowed, tc.allowed)
+			if !tc.allowed {
+				assert.Equal(t, response.Result.Message, tc.message)
+			}
+		})
+	}
+}
@@ -2,10 +2,14 @@ package utils
 
 import (
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/apicallcache"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	globalcontextstore "github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/userinfo"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -22,6 +26,10 @@ type policyContextBuilder struct {
 	rbLister               rbacv1listers.RoleBindingLister
 	crbLister              rbacv1listers.ClusterRoleBindingLister
 	informerCacheResolvers resolvers.ConfigmapResolver
+	peLister               kyvernov2alpha1listers.PolicyExceptionLister
+	imageVerifyCache       imageverifycache.Cache
+	apiCallCache           apicallcache.Cache
+	globalContext          globalcontextstore.Store
 }
 
 func NewPolicyContextBuilder(
@@ -30,6 +38,10 @@ func NewPolicyContextBuilder(
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
 	informerCacheResolvers resolvers.ConfigmapResolver,
+	peLister kyvernov2alpha1listers.PolicyExceptionLister,
+	imageVerifyCache imageverifycache.Cache,
+	apiCallCache apicallcache.Cache,
+	globalContext globalcontextstore.Store,
 ) PolicyContextBuilder {
 	return &policyContextBuilder{
 		configuration:          configuration,
@@ -37,6 +49,10 @@ func NewPolicyContextBuilder(
 		rbLister:               rbLister,
 		crbLister:              crbLister,
 		informerCacheResolvers: informerCacheResolvers,
+		peLister:               peLister,
+		imageVerifyCache:       imageVerifyCache,
+		apiCallCache:           apiCallCache,
+		globalContext:          globalContext,
 	}
 }
 
@@ -50,5 +66,9 @@ func (b *policyContextBuilder) Build(request *admissionv1.AdmissionRequest) (*en
 		userRequestInfo.Roles = roles
 		userRequestInfo.ClusterRoles = clusterRoles
 	}
-	return engine.NewPolicyContextFromAdmissionRequest(request, userRequestInfo, b.configuration, b.client, b.informerCacheResolvers)
+	policyContext, err := engine.NewPolicyContextFromAdmissionRequest(request, userRequestInfo, b.configuration, b.client, b.informerCacheResolvers, b.peLister)
+	if err != nil {
+		return nil, err
+	}
+	return policyContext.WithImageVerifyCache(b.imageVerifyCache).WithAPICallCache(b.apiCallCache).WithGlobalContext(b.globalContext), nil
 }